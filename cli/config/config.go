@@ -8,30 +8,126 @@ type FormatMapping struct {
 	Type string `json:"type"`
 }
 
+// Configuration for C# code generation. Controls the output directory, namespace, nullable reference type annotations, and custom format type mappings. The generated code targets System.Text.Json on .NET 9 or later.
+type CSharpConfig struct {
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps common formats to standard .NET types (e.g. "uuid" to Guid, "date-time" to DateTimeOffset). Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the C# type and the namespace to import with a using directive.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The C# namespace for the generated file, emitted as a file-scoped namespace declaration. Defaults to "Generated" if not specified. Can be overridden by the --package CLI flag.
+	Namespace *string `json:"namespace,omitempty"`
+	// Whether the generated file enables nullable reference types with a "#nullable enable" directive and annotates optional reference types with "?". Defaults to true. Disable this for projects that do not use nullable reference types; optional value types are always emitted as Nullable<T>.
+	NullableReferenceTypes *bool `json:"nullable_reference_types,omitempty"`
+	// The output directory path where the generated C# file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+}
+
+// Configuration for Dart code generation. Controls the output directory, filename, and custom format type mappings. The generated code depends on the json_annotation package and is completed by json_serializable.
+type DartConfig struct {
+	// The filename for the generated Dart library. Defaults to "models.dart" if not specified. The part directive in the generated file refers to the matching ".g.dart" file.
+	Filename *string `json:"filename,omitempty"`
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps "date-time" to DateTime, "uri" to Uri and other formats to String. Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the Dart type and the library URI to import (e.g. "package:decimal/decimal.dart").
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The output directory path where the generated Dart file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+}
+
+// A Go package that the types of a schema file are generated into.
+type GoPackage struct {
+	// The import path of the package, such as "github.com/acme/shared/money".
+	Import string `json:"import"`
+	// The directory the package is written to. When omitted, no code is generated for the package, for packages generated separately and shared between several services.
+	Output *string `json:"output,omitempty"`
+	// The package name. Defaults to the last element of the import path.
+	Package *string `json:"package,omitempty"`
+}
+
 // Configuration for Go code generation. Controls the output directory, package name, how optional fields are represented, and custom type mappings for JSON Schema format values.
 type GolangConfig struct {
+	// When true, structs whose schema allows additionalProperties get an AdditionalProperties map holding the properties the schema doesn't declare, with MarshalJSON and UnmarshalJSON methods keeping them through a round trip and Validate checking them against the additionalProperties schema. Defaults to false.
+	AdditionalProperties *bool `json:"additional_properties,omitempty"`
+	// Controls how generated types are split across files. Supported values are "single" (the default), which writes every type to one file named after the package, "source", which writes one file per input schema file following its $ref boundaries (types from the root schema go to the package file, types from "address.yaml" go to "address.go"), and "type", which writes each top-level type to its own snake_case file. Code shared between files goes to "helpers.go".
+	FileLayout *string `json:"file_layout,omitempty"`
 	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps common formats to standard Go types (e.g. "uuid" to github.com/google/uuid.UUID, "date-time" to time.Time). Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string (e.g. "uuid", "date-time", "email") and the value describes the Go type and import path to use.
 	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
-	// Controls how optional (non-required) fields are represented in the generated Go structs. Supported values are "pointer" (the default), which uses Go pointer types (e.g. *string, *int), and "opt", which uses the github.com/Southclaws/opt library's Optional[T] generic type. Can be overridden by the --optional-style CLI flag.
+	// Selects the encoding/json package the generated code is written for. Supported values are "std" (the default), which uses encoding/json, and "v2", which uses encoding/json/v2: optional fields are tagged omitzero, and discriminated unions implement MarshalJSONTo and UnmarshalJSONFrom, which decode an object whose first property is the discriminator straight into its variant, and buffer other objects to scan them for the discriminator with the jsontext tokenizer first. Files generated for "v2" are constrained to go1.27.
+	JSONBackend *string `json:"json_backend,omitempty"`
+	// Controls how optional (non-required) fields are represented in the generated Go structs. Supported values are "pointer" (the default), which uses Go pointer types (e.g. *string, *int), "opt", which uses the github.com/Southclaws/opt library's Optional[T] generic type, and "nullable", which uses a generated Field[T] type that tells an absent field apart from an explicit null. Can be overridden by the --optional-style CLI flag.
 	OptionalStyle *string `json:"optional_style,omitempty"`
 	// The output directory path where generated Go files will be written. The directory will be created if it does not exist. The generated file will be named after the package (e.g. "models.go" for package "models"). This field is required for the language to be included in multi-language generation mode.
 	Output *string `json:"output,omitempty"`
 	// The Go package name for the generated source file. This appears in the "package" declaration at the top of the generated file. Defaults to "generated" if not specified. Can be overridden by the --package CLI flag.
 	Package *string `json:"package,omitempty"`
+	// Generates the types defined in other schema files into separate Go packages, which the main package imports instead of holding its own copy. The map key is a schema file as referenced from the root schema (e.g. "common.yaml"), or the $id of the file. Only references with a fragment, such as "common.yaml#/$defs/Money", keep their file boundary.
+	Packages map[string]GoPackage `json:"packages,omitempty"`
+	// When true, every enum gets an UnmarshalJSON method rejecting values that are not one of its <Name>Values, so unknown values fail when decoding instead of passing through. Defaults to false.
+	StrictEnums *bool `json:"strict_enums,omitempty"`
+	// Struct tags to add to every field besides json, such as "yaml", "db", "bson" or "mapstructure". The map key is the tag and the value is the casing of the JSON property name it holds: "json" (unchanged), "snake", "camel", "pascal" or "kebab". Fields can set their own tags with the "x-go-tag" extension, such as 'db:"id" validate:"required"', replacing generated tags with the same key. The json tag can't be set there.
+	StructTags map[string]string `json:"struct_tags,omitempty"`
+	// When true, every discriminated union gets an Unknown<Union> variant holding the discriminator value and raw JSON of payloads whose discriminator is not one of the known variants, instead of failing to decode them. The raw JSON is encoded unchanged by MarshalJSON. Defaults to false.
+	UnknownVariants *bool `json:"unknown_variants,omitempty"`
+	// When true, every struct with optional fields that have a "default" in the schema gets an UnmarshalJSON method applying the defaults to the fields missing from the JSON. The New<Type> constructors and ApplyDefaults methods are generated either way. Defaults to false.
+	UnmarshalDefaults *bool `json:"unmarshal_defaults,omitempty"`
+	// When true, a Validate() error method is generated for every struct and union, checking required fields, string lengths and patterns, numeric bounds, array sizes, unique items and enum membership, and recursing into nested types. Failures are returned as a *ValidationError listing the JSON pointer of every value that failed. Defaults to false.
+	Validation *bool `json:"validation,omitempty"`
+}
+
+// Configuration for GraphQL SDL generation. Controls the output directory, filename, input type generation, and custom scalar mappings for formats.
+type GraphqlConfig struct {
+	// The filename for the generated SDL file. Defaults to "schema.graphql" if not specified.
+	Filename *string `json:"filename,omitempty"`
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps "date-time" to a DateTime scalar, "date" to Date, "uuid" to UUID and other formats to String. Any type that is not a built-in GraphQL scalar is declared as a custom scalar. The map key is the JSON Schema format string and the value describes the GraphQL type; the import field is ignored.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// When true, an input type named <Type>Input is generated for each object type, with field defaults taken from the schema. Discriminated unions become @oneOf input types with one field per variant. Defaults to false.
+	InputTypes *bool `json:"input_types,omitempty"`
+	// The output directory path where the generated SDL file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
 }
 
 // Configuration for Java code generation. Controls the output directory, package name, accessor method generation, and custom format type mappings. Each top-level type is generated as a separate Java file with Jackson annotations.
 type JavaConfig struct {
 	// When true, generates getter and setter methods for all fields instead of using public fields. The fields become private and are accessed through getFieldName()/setFieldName() methods following standard JavaBean conventions. Defaults to false.
 	Accessors *bool `json:"accessors,omitempty"`
-	// Controls Jackson @JsonInclude behavior on generated classes. Supported values are "non_null" (the default), which omits null fields on serialization; "non_empty", which also omits empty collections and maps; and "always", which emits no @JsonInclude annotation.
-	PropertyInclusion *string `json:"property_inclusion,omitempty"`
 	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps common formats to standard Java types (e.g. "uuid" to java.util.UUID, "date-time" to java.time.OffsetDateTime). Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the Java type and import path.
 	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
 	// The output directory path where generated Java files will be written. The directory will be created if it does not exist. Each top-level type produces a separate .java file. This field is required for the language to be included in multi-language generation mode.
 	Output *string `json:"output,omitempty"`
 	// The Java package name for the generated classes. This appears in the "package" declaration at the top of each generated file. Defaults to "generated" if not specified. Can be overridden by the --package CLI flag.
 	Package *string `json:"package,omitempty"`
+	// Controls Jackson @JsonInclude behavior on generated classes. "non_null" (default) omits null fields on serialization. "non_empty" also omits empty collections and maps. "always" emits no @JsonInclude annotation.
+	PropertyInclusion *string `json:"property_inclusion,omitempty"`
+}
+
+// Configuration for Kotlin code generation. Controls the output directory, package name, and custom format type mappings. The generated code uses kotlinx.serialization annotations and validates schema constraints in init blocks.
+type KotlinConfig struct {
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps "date-time" and "date" to kotlinx.datetime types and other formats to String. Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the Kotlin type and import path.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The output directory path where the generated Kotlin file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+	// The Kotlin package name for the generated file. This appears in the "package" declaration at the top of the generated file. Defaults to "generated" if not specified. Can be overridden by the --package CLI flag.
+	Package *string `json:"package,omitempty"`
+}
+
+// Configuration for OpenAPI 3.1 export. Controls the output directory, filename and document info. Format mappings do not apply, since schemas are exported as JSON Schema.
+type OpenapiConfig struct {
+	// The filename for the generated document. Defaults to "openapi.yaml" if not specified. A .json extension writes JSON, any other extension writes YAML.
+	Filename *string `json:"filename,omitempty"`
+	// The output directory path where the generated OpenAPI document will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+	// The info.title of the document. Defaults to the schema title.
+	Title *string `json:"title,omitempty"`
+	// The info.version of the document. Defaults to "1.0.0" if not specified.
+	Version *string `json:"version,omitempty"`
+}
+
+// Configuration for Protocol Buffers generation. Controls the output directory, package name, go_package option, and custom format type mappings. Field numbers can be pinned per property with the x-proto-field extension; all other numbers are recorded in models.lock.json in the output directory, which should be committed alongside the generated .proto file.
+type ProtobufConfig struct {
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps "date-time" to google.protobuf.Timestamp, "byte" to bytes and other formats to string. Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the protobuf type and the .proto file to import.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The value of the go_package file option, for use with protoc-gen-go. Omitted if not specified.
+	GoPackage *string `json:"go_package,omitempty"`
+	// The output directory path where the generated models.proto and models.lock.json files will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+	// The protobuf package name for the generated file. Defaults to "generated" if not specified. Can be overridden by the --package CLI flag.
+	Package *string `json:"package,omitempty"`
 }
 
 // Configuration for Python code generation. Controls the output directory and custom format type mappings. The generated code uses Pydantic v2 BaseModel classes with full type annotations.
@@ -42,6 +138,36 @@ type PythonConfig struct {
 	Output *string `json:"output,omitempty"`
 }
 
+// Configuration for resolving external references. Remote http(s) references are fetched and cached on disk, so later runs can resolve them without network access.
+type RefsConfig struct {
+	// The directory where fetched remote schemas are cached, keyed by URL and content hash. Defaults to a "schemancer" directory in the user cache directory. Can be overridden by the --cache-dir CLI flag.
+	CacheDir *string `json:"cache_dir,omitempty"`
+	// What to do when hoisted definitions share a name but differ in structure, whether they come from different files or are nested in the same file. "error" (the default) fails with an error naming both. Earlier versions silently kept one of them, so schemas with such conflicts that used to generate now fail until this is set. "prefix" namespaces the later definition with its file name, so Address from billing.yaml becomes BillingAddress, or with its enclosing definition, so Address nested in Order becomes OrderAddress. Identical definitions are always merged. Can be overridden by the --collisions CLI flag.
+	Collisions *string `json:"collisions,omitempty"`
+	// Maps URI prefixes to local directories, so references to schemas identified by an $id URI load a local copy instead of being fetched. For example "https://example.com/schemas/": "./vendor" resolves "https://example.com/schemas/a.json" to "./vendor/a.json". The longest matching prefix is used, and relative paths are resolved against the directory of the config file.
+	Mappings map[string]string `json:"mappings,omitempty"`
+	// When true, remote references are resolved from the cache only and no requests are made. A reference that has not been cached yet is an error. Defaults to false. Can be enabled by the --offline CLI flag.
+	Offline *bool `json:"offline,omitempty"`
+	// How long to wait for each remote document before failing, as a Go duration such as "30s" or "2m". Defaults to 30s.
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// Configuration for Rust code generation. Controls the output directory and custom format type mappings. The generated code uses serde derives and requires the serde (with the derive feature) and serde_json crates.
+type RustConfig struct {
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps common formats to fully qualified Rust types (e.g. "uuid" to uuid::Uuid, "date-time" to chrono::DateTime<chrono::Utc>). Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the Rust type and an optional path for a use declaration.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The output directory path where the generated Rust file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+}
+
+// Configuration for Swift code generation. Controls the output directory and custom format type mappings. The generated code only depends on Foundation.
+type SwiftConfig struct {
+	// Custom type mappings for JSON Schema "format" values. By default, schemancer maps "date-time" to Date, "uuid" to UUID, "byte" to Data and other formats to String. Use this to override defaults or add mappings for custom formats. The map key is the JSON Schema format string and the value describes the Swift type and the module to import.
+	FormatMappings map[string]FormatMapping `json:"format_mappings,omitempty"`
+	// The output directory path where the generated Swift file will be written. The directory will be created if it does not exist. This field is required for the language to be included in multi-language generation mode.
+	Output *string `json:"output,omitempty"`
+}
+
 // Configuration for TypeScript code generation. Controls the output directory, output filename, optional field representation, branded primitive types, and custom format type mappings.
 type TypeScriptConfig struct {
 	// When true, primitive type aliases are generated as branded types instead of plain type aliases. For example, instead of "type UserId = string", it generates a branded type that prevents accidental assignment between different string-based types. This provides stronger type safety at the cost of slightly more verbose usage. Defaults to false. Can be overridden by the --branded-primitives CLI flag.
@@ -68,12 +194,30 @@ type TypeScriptZodConfig struct {
 
 // The schemancer configuration file structure. This file is typically named schemancer.yaml and placed in the root of your project alongside your JSON Schema definitions. It controls how code is generated for each target language, including output paths, package names, language-specific options, and custom format type mappings. Each top-level key corresponds to a supported target language. Only languages with a configuration block will be included when running schemancer in multi-language mode (i.e. without explicit language and output arguments on the command line).
 type Config struct {
+	// C#-specific generation options. When present with an output path set, schemancer will generate a C# source file of records annotated for System.Text.Json, with polymorphic abstract records for discriminated unions.
+	Csharp *CSharpConfig `json:"csharp,omitempty"`
+	// Dart-specific generation options. When present with an output path set, schemancer will generate a Dart library annotated for json_serializable, with sealed class hierarchies for discriminated unions. Run build_runner afterwards to produce the accompanying .g.dart part file.
+	Dart *DartConfig `json:"dart,omitempty"`
 	// Go-specific generation options. When present with an output path set, schemancer will generate Go source files. The generated code uses standard encoding/json struct tags and idiomatic Go naming conventions.
 	Golang *GolangConfig `json:"golang,omitempty"`
+	// GraphQL generation options. When present with an output path set, schemancer will generate a GraphQL SDL file with object types, enums, unions for discriminated unions, and optionally mirrored input types.
+	Graphql *GraphqlConfig `json:"graphql,omitempty"`
 	// Java-specific generation options. When present with an output path set, schemancer will generate Java class files. Each top-level type is emitted as a separate .java file with Jackson annotations for JSON serialization/deserialization.
 	Java *JavaConfig `json:"java,omitempty"`
+	// Kotlin-specific generation options. When present with an output path set, schemancer will generate a Kotlin source file using kotlinx.serialization data classes, with sealed interfaces for discriminated unions.
+	Kotlin *KotlinConfig `json:"kotlin,omitempty"`
+	// OpenAPI export options. When present with an output path set, schemancer will write an OpenAPI 3.1 document whose components.schemas are the dereferenced schema definitions, with discriminated unions described by OpenAPI discriminators.
+	Openapi *OpenapiConfig `json:"openapi,omitempty"`
+	// Protocol Buffers generation options. When present with an output path set, schemancer will generate a proto3 file with messages, enums and oneofs for discriminated unions, along with a lock file that keeps field numbers stable across regenerations.
+	Protobuf *ProtobufConfig `json:"protobuf,omitempty"`
 	// Python-specific generation options. When present with an output path set, schemancer will generate Python source files using Pydantic v2 BaseModel classes with full type annotations and validation support.
 	Python *PythonConfig `json:"python,omitempty"`
+	// Options for resolving external $ref references, including remote references to schemas published over HTTP(S).
+	Refs *RefsConfig `json:"refs,omitempty"`
+	// Rust-specific generation options. When present with an output path set, schemancer will generate a Rust source file with serde derives for JSON serialization/deserialization. Discriminated unions become internally tagged enums.
+	Rust *RustConfig `json:"rust,omitempty"`
+	// Swift-specific generation options. When present with an output path set, schemancer will generate a Swift source file of Codable structs, with enums carrying associated values for discriminated unions.
+	Swift *SwiftConfig `json:"swift,omitempty"`
 	// TypeScript-specific generation options. When present with an output path set, schemancer will generate TypeScript type definitions. The generated code produces interfaces and type aliases suitable for use with any TypeScript project.
 	Typescript *TypeScriptConfig `json:"typescript,omitempty"`
 	// TypeScript Zod-specific generation options. When present with an output path set, schemancer will generate Zod v4 schema definitions with inferred TypeScript types. The generated code produces z.object() schemas with full runtime validation support, including constraints like min/max length, numeric bounds, and array limits.
@@ -58,6 +58,62 @@ func (c *Config) GetConfiguredLanguages() []LanguageOutput {
 		})
 	}
 
+	if c.Rust != nil && c.Rust.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageRust,
+			Output:   *c.Rust.Output,
+		})
+	}
+
+	if c.Kotlin != nil && c.Kotlin.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageKotlin,
+			Output:   *c.Kotlin.Output,
+		})
+	}
+
+	if c.Csharp != nil && c.Csharp.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageCSharp,
+			Output:   *c.Csharp.Output,
+		})
+	}
+
+	if c.Swift != nil && c.Swift.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageSwift,
+			Output:   *c.Swift.Output,
+		})
+	}
+
+	if c.Dart != nil && c.Dart.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageDart,
+			Output:   *c.Dart.Output,
+		})
+	}
+
+	if c.Protobuf != nil && c.Protobuf.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageProtobuf,
+			Output:   *c.Protobuf.Output,
+		})
+	}
+
+	if c.Graphql != nil && c.Graphql.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageGraphQL,
+			Output:   *c.Graphql.Output,
+		})
+	}
+
+	if c.Openapi != nil && c.Openapi.Output != nil {
+		languages = append(languages, LanguageOutput{
+			Language: generators.LanguageOpenAPI,
+			Output:   *c.Openapi.Output,
+		})
+	}
+
 	return languages
 }
 
@@ -109,6 +165,34 @@ func (c *Config) GetFormatMappings(language generators.Language) map[ir.IRFormat
 		if c.Python != nil {
 			mappings = c.Python.FormatMappings
 		}
+	case generators.LanguageRust:
+		if c.Rust != nil {
+			mappings = c.Rust.FormatMappings
+		}
+	case generators.LanguageKotlin:
+		if c.Kotlin != nil {
+			mappings = c.Kotlin.FormatMappings
+		}
+	case generators.LanguageCSharp:
+		if c.Csharp != nil {
+			mappings = c.Csharp.FormatMappings
+		}
+	case generators.LanguageSwift:
+		if c.Swift != nil {
+			mappings = c.Swift.FormatMappings
+		}
+	case generators.LanguageDart:
+		if c.Dart != nil {
+			mappings = c.Dart.FormatMappings
+		}
+	case generators.LanguageProtobuf:
+		if c.Protobuf != nil {
+			mappings = c.Protobuf.FormatMappings
+		}
+	case generators.LanguageGraphQL:
+		if c.Graphql != nil {
+			mappings = c.Graphql.FormatMappings
+		}
 	}
 
 	if len(mappings) == 0 {
@@ -4,15 +4,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/spf13/cobra"
 
 	"github.com/Southclaws/schemancer/cli/config"
 	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/deref"
 	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/csharp"
+	"github.com/Southclaws/schemancer/schemancer/generators/dart"
 	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/generators/graphql"
 	"github.com/Southclaws/schemancer/schemancer/generators/java"
+	"github.com/Southclaws/schemancer/schemancer/generators/kotlin"
+	"github.com/Southclaws/schemancer/schemancer/generators/openapi"
+	"github.com/Southclaws/schemancer/schemancer/generators/protobuf"
 	"github.com/Southclaws/schemancer/schemancer/generators/typescript"
 	typescriptzod "github.com/Southclaws/schemancer/schemancer/generators/typescript-zod"
 	"github.com/Southclaws/schemancer/schemancer/loader"
@@ -21,6 +29,9 @@ import (
 var (
 	// Global options
 	configFile string
+	offline    bool
+	cacheDir   string
+	collisions string
 
 	// Go options
 	goPackage       string
@@ -48,10 +59,13 @@ specific language to that output path.`,
 
 	// Global flags
 	rootCmd.Flags().StringVar(&configFile, "config", "schemancer.yaml", "Path to config file")
+	rootCmd.Flags().BoolVar(&offline, "offline", false, "Resolve remote $ref references from the cache only")
+	rootCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Directory for cached remote $ref documents")
+	rootCmd.Flags().StringVar(&collisions, "collisions", "", "Conflicting definition names: error (default, previously one was kept silently) or prefix")
 
 	// Go flags
 	rootCmd.Flags().StringVar(&goPackage, "package", "", "Go: package name for generated code")
-	rootCmd.Flags().StringVar(&goOptionalStyle, "optional-style", "", "Go: how to represent optional fields (pointer, opt)")
+	rootCmd.Flags().StringVar(&goOptionalStyle, "optional-style", "", "Go: how to represent optional fields (pointer, opt, nullable)")
 
 	// TypeScript flags
 	rootCmd.Flags().BoolVar(&tsNullOptional, "null-optional", false, "TypeScript: use null instead of undefined for optional fields")
@@ -71,7 +85,12 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	schema, err := loader.FromFile(schemaFile)
+	derefOpts, err := getDerefOptions(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	schema, err := loader.FromFile(schemaFile, derefOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to load schema: %w", err)
 	}
@@ -90,6 +109,71 @@ func run(cmd *cobra.Command, args []string) error {
 	return fmt.Errorf("invalid arguments: provide either 1 argument (schema) or 3 arguments (schema language output)")
 }
 
+// getDerefOptions configures how external references are resolved from the
+// config and flags, with remote documents cached in the user cache directory
+// by default. Without a user cache directory, such as in containers without
+// HOME, remote documents aren't cached.
+func getDerefOptions(cmd *cobra.Command, cfg *config.Config) ([]deref.Option, error) {
+	resolver := &deref.RemoteResolver{}
+
+	if cfg != nil && cfg.Refs != nil && cfg.Refs.CacheDir != nil {
+		resolver.CacheDir = *cfg.Refs.CacheDir
+	}
+	if cmd.Flags().Changed("cache-dir") {
+		resolver.CacheDir = cacheDir
+	}
+	if resolver.CacheDir == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			resolver.CacheDir = filepath.Join(dir, "schemancer")
+		}
+	}
+
+	if cfg != nil && cfg.Refs != nil && cfg.Refs.Timeout != nil {
+		timeout, err := time.ParseDuration(*cfg.Refs.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid refs.timeout %q: must be a positive duration such as 30s", *cfg.Refs.Timeout)
+		}
+		resolver.Timeout = timeout
+	}
+
+	if cfg != nil && cfg.Refs != nil && cfg.Refs.Offline != nil {
+		resolver.Offline = *cfg.Refs.Offline
+	}
+	if cmd.Flags().Changed("offline") {
+		resolver.Offline = offline
+	}
+
+	opts := []deref.Option{deref.WithResolver(resolver)}
+
+	var strategy deref.CollisionStrategy
+	if cfg != nil && cfg.Refs != nil && cfg.Refs.Collisions != nil {
+		strategy = deref.CollisionStrategy(*cfg.Refs.Collisions)
+	}
+	if cmd.Flags().Changed("collisions") {
+		strategy = deref.CollisionStrategy(collisions)
+	}
+	if strategy != "" {
+		if strategy != deref.CollisionError && strategy != deref.CollisionPrefix {
+			return nil, fmt.Errorf("invalid refs.collisions %q: must be error or prefix", strategy)
+		}
+		opts = append(opts, deref.WithCollisionStrategy(strategy))
+	}
+
+	// Mapped directories are relative to the config file
+	if cfg != nil && cfg.Refs != nil && len(cfg.Refs.Mappings) > 0 {
+		mappings := make(map[string]string, len(cfg.Refs.Mappings))
+		for prefix, target := range cfg.Refs.Mappings {
+			if !deref.IsRemote(target) && !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(configFile), target)
+			}
+			mappings[prefix] = target
+		}
+		opts = append(opts, deref.WithMappings(mappings))
+	}
+
+	return opts, nil
+}
+
 func generateFromConfig(cmd *cobra.Command, cfg *config.Config, schema *jsonschema.Schema) error {
 	languages := cfg.GetConfiguredLanguages()
 	if len(languages) == 0 {
@@ -106,7 +190,7 @@ func generateFromConfig(cmd *cobra.Command, cfg *config.Config, schema *jsonsche
 }
 
 func generateSingle(cmd *cobra.Command, cfg *config.Config, schema *jsonschema.Schema, language, outputPath string) error {
-	genOpts, err := getGeneratorOptions(cmd, cfg, language)
+	genOpts, err := getGeneratorOptions(cmd, cfg, language, outputPath)
 	if err != nil {
 		return err
 	}
@@ -136,6 +220,9 @@ func generateSingle(cmd *cobra.Command, cfg *config.Config, schema *jsonschema.S
 		}
 		for _, f := range files {
 			outFile := filepath.Join(outputPath, f.Filename)
+			if err := os.MkdirAll(filepath.Dir(outFile), 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
 			if err := os.WriteFile(outFile, f.Content, 0o644); err != nil {
 				return fmt.Errorf("failed to write %s: %w", outFile, err)
 			}
@@ -146,7 +233,7 @@ func generateSingle(cmd *cobra.Command, cfg *config.Config, schema *jsonschema.S
 	return nil
 }
 
-func getGeneratorOptions(cmd *cobra.Command, cfg *config.Config, language string) ([]generators.GeneratorOption, error) {
+func getGeneratorOptions(cmd *cobra.Command, cfg *config.Config, language, outputPath string) ([]generators.GeneratorOption, error) {
 	var genOpts []generators.GeneratorOption
 
 	switch language {
@@ -171,6 +258,65 @@ func getGeneratorOptions(cmd *cobra.Command, cfg *config.Config, language string
 		}
 		genOpts = append(genOpts, golang.WithOptionalStyle(golang.OptionalStyle(optStyle)))
 
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.FileLayout != nil {
+			genOpts = append(genOpts, golang.WithFileLayout(golang.FileLayout(*cfg.Golang.FileLayout)))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.Validation != nil {
+			genOpts = append(genOpts, golang.WithValidation(*cfg.Golang.Validation))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.StrictEnums != nil {
+			genOpts = append(genOpts, golang.WithStrictEnums(*cfg.Golang.StrictEnums))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.UnknownVariants != nil {
+			genOpts = append(genOpts, golang.WithUnknownVariants(*cfg.Golang.UnknownVariants))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.UnmarshalDefaults != nil {
+			genOpts = append(genOpts, golang.WithUnmarshalDefaults(*cfg.Golang.UnmarshalDefaults))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.AdditionalProperties != nil {
+			genOpts = append(genOpts, golang.WithAdditionalProperties(*cfg.Golang.AdditionalProperties))
+		}
+
+		if cfg != nil && cfg.Golang != nil && cfg.Golang.JSONBackend != nil {
+			genOpts = append(genOpts, golang.WithJSONBackend(golang.JSONBackend(*cfg.Golang.JSONBackend)))
+		}
+
+		if cfg != nil && cfg.Golang != nil && len(cfg.Golang.StructTags) > 0 {
+			tags := make(map[string]golang.TagCasing, len(cfg.Golang.StructTags))
+			for tag, c := range cfg.Golang.StructTags {
+				tags[tag] = golang.TagCasing(c)
+			}
+			genOpts = append(genOpts, golang.WithStructTags(tags))
+		}
+
+		// Package directories are written relative to the Go output directory
+		if cfg != nil && cfg.Golang != nil && len(cfg.Golang.Packages) > 0 {
+			packages := make(map[string]golang.Package, len(cfg.Golang.Packages))
+			for source, p := range cfg.Golang.Packages {
+				pkg := golang.Package{ImportPath: p.Import}
+				if p.Package != nil {
+					pkg.Name = *p.Package
+				}
+				if p.Output != nil && *p.Output != "" {
+					pkg.Dir = *p.Output
+					if outputPath != "-" {
+						dir, err := filepath.Rel(outputPath, *p.Output)
+						if err != nil {
+							return nil, fmt.Errorf("invalid output %q for Go package %s: %w", *p.Output, p.Import, err)
+						}
+						pkg.Dir = filepath.ToSlash(dir)
+					}
+				}
+				packages[source] = pkg
+			}
+			genOpts = append(genOpts, golang.WithPackages(packages))
+		}
+
 	case "typescript":
 		// Resolve null_optional: CLI flag > config > default
 		nullOpt := false
@@ -223,6 +369,92 @@ func getGeneratorOptions(cmd *cobra.Command, cfg *config.Config, language string
 	case "python":
 		// Python has no special options yet
 
+	case "rust":
+		// Rust has no special options yet
+
+	case "kotlin":
+		// Resolve package name: CLI flag > config > default
+		pkg := "generated"
+		if cfg != nil && cfg.Kotlin != nil && cfg.Kotlin.Package != nil {
+			pkg = *cfg.Kotlin.Package
+		}
+		if goPackage != "" {
+			pkg = goPackage
+		}
+		genOpts = append(genOpts, kotlin.WithPackageName(pkg))
+
+	case "swift":
+		// Swift has no special options yet
+
+	case "dart":
+		// Resolve filename: config > default ("models.dart")
+		if cfg != nil && cfg.Dart != nil && cfg.Dart.Filename != nil && *cfg.Dart.Filename != "" {
+			genOpts = append(genOpts, dart.WithFilename(*cfg.Dart.Filename))
+		}
+
+	case "protobuf":
+		// Resolve package name: CLI flag > config > default
+		pkg := "generated"
+		if cfg != nil && cfg.Protobuf != nil && cfg.Protobuf.Package != nil {
+			pkg = *cfg.Protobuf.Package
+		}
+		if goPackage != "" {
+			pkg = goPackage
+		}
+		genOpts = append(genOpts, protobuf.WithPackageName(pkg))
+
+		if cfg != nil && cfg.Protobuf != nil && cfg.Protobuf.GoPackage != nil {
+			genOpts = append(genOpts, protobuf.WithGoPackage(*cfg.Protobuf.GoPackage))
+		}
+
+		// Field numbers are kept stable by the lock file next to the output,
+		// which isn't available when writing to stdout.
+		if outputPath != "-" {
+			lock, err := os.ReadFile(filepath.Join(outputPath, protobuf.LockFilename))
+			if err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("failed to read protobuf lock file: %w", err)
+			}
+			genOpts = append(genOpts, protobuf.WithLock(lock))
+		}
+
+	case "graphql":
+		// Resolve filename: config > default ("schema.graphql")
+		if cfg != nil && cfg.Graphql != nil && cfg.Graphql.Filename != nil && *cfg.Graphql.Filename != "" {
+			genOpts = append(genOpts, graphql.WithFilename(*cfg.Graphql.Filename))
+		}
+
+		// Resolve input_types: config > default (false)
+		if cfg != nil && cfg.Graphql != nil && cfg.Graphql.InputTypes != nil {
+			genOpts = append(genOpts, graphql.WithInputTypes(*cfg.Graphql.InputTypes))
+		}
+
+	case "openapi":
+		// Resolve filename: config > default ("openapi.yaml")
+		if cfg != nil && cfg.Openapi != nil && cfg.Openapi.Filename != nil && *cfg.Openapi.Filename != "" {
+			genOpts = append(genOpts, openapi.WithFilename(*cfg.Openapi.Filename))
+		}
+		if cfg != nil && cfg.Openapi != nil && cfg.Openapi.Title != nil {
+			genOpts = append(genOpts, openapi.WithTitle(*cfg.Openapi.Title))
+		}
+		if cfg != nil && cfg.Openapi != nil && cfg.Openapi.Version != nil {
+			genOpts = append(genOpts, openapi.WithVersion(*cfg.Openapi.Version))
+		}
+
+	case "csharp":
+		// Resolve namespace: CLI flag > config > default
+		ns := "Generated"
+		if cfg != nil && cfg.Csharp != nil && cfg.Csharp.Namespace != nil {
+			ns = *cfg.Csharp.Namespace
+		}
+		if goPackage != "" {
+			ns = goPackage
+		}
+		genOpts = append(genOpts, csharp.WithNamespace(ns))
+
+		if cfg != nil && cfg.Csharp != nil && cfg.Csharp.NullableReferenceTypes != nil {
+			genOpts = append(genOpts, csharp.WithNullableReferenceTypes(*cfg.Csharp.NullableReferenceTypes))
+		}
+
 	case "typescript-zod":
 		// Resolve filename: config > default ("schema.ts")
 		if cfg != nil && cfg.TypescriptZod != nil && cfg.TypescriptZod.Filename != nil && *cfg.TypescriptZod.Filename != "" {
@@ -230,7 +462,7 @@ func getGeneratorOptions(cmd *cobra.Command, cfg *config.Config, language string
 		}
 
 	default:
-		return nil, fmt.Errorf("unsupported language: %s (supported: golang, typescript, typescript-zod, java, python)", language)
+		return nil, fmt.Errorf("unsupported language: %s (supported: golang, typescript, typescript-zod, java, python, rust, kotlin, csharp, swift, dart, protobuf, graphql, openapi)", language)
 	}
 
 	return genOpts, nil
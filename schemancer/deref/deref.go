@@ -5,31 +5,73 @@ package deref
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/goccy/go-yaml"
 	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/Southclaws/schemancer/schemancer/ir"
+	"github.com/Southclaws/schemancer/schemancer/loader/asyncapi"
+	"github.com/Southclaws/schemancer/schemancer/loader/openapi"
 )
 
 // Schema dereferences all external $ref references in a schema in-place.
-// baseDir is used to resolve relative file paths in $ref values.
-func Schema(schema *jsonschema.Schema, baseDir string) error {
+// baseDir is used to resolve relative file paths in $ref values, and may be
+// the URL of a remote document.
+func Schema(schema *jsonschema.Schema, baseDir string, opts ...Option) error {
+	cfg := &config{resolver: DefaultResolver}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	rootDefs := make(map[string]*jsonschema.Schema)
 
+	d := &dereferencer{
+		resolver:   cfg.resolver,
+		mappings:   cfg.mappings,
+		collisions: cfg.collisions,
+		rootDefs:   rootDefs,
+		index:      newIndex(),
+		sources:    make(map[string]string),
+		defined:    make(map[*jsonschema.Schema]string),
+		hoisted:    make(map[*jsonschema.Schema]bool),
+		docs:       make(map[string]*jsonschema.Schema),
+	}
+	if err := d.index.add(schema, dirURI(baseDir), ""); err != nil {
+		return fmt.Errorf("dereference schema: %w", err)
+	}
+
 	// Copy existing definitions
 	for k, v := range schema.Defs {
-		rootDefs[k] = v
+		d.add(k, v)
 	}
 	for k, v := range schema.Definitions {
-		rootDefs[k] = v
+		d.add(k, v)
 	}
 
-	if err := dereferenceRefs(schema, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema, baseDir); err != nil {
+		return fmt.Errorf("dereference schema: %w", err)
+	}
+	if err := d.hoistDefs(schema); err != nil {
 		return fmt.Errorf("dereference schema: %w", err)
 	}
 
+	// Record where definitions from other files came from. This happens
+	// last, since it would make otherwise identical definitions differ.
+	for name, source := range d.sources {
+		if source == "" {
+			continue
+		}
+		def := rootDefs[name]
+		if def.Extra == nil {
+			def.Extra = make(map[string]any)
+		}
+		def.Extra[ir.SourceExtension] = relativeSource(baseDir, source)
+		if doc, ok := d.docs[source]; ok && doc.ID != "" {
+			def.Extra[ir.SourceIDExtension] = d.index.bases[doc]
+		}
+	}
+
 	// Hoist all definitions to $defs
 	if len(rootDefs) > 0 {
 		if schema.Defs == nil {
@@ -43,193 +85,292 @@ func Schema(schema *jsonschema.Schema, baseDir string) error {
 	return nil
 }
 
-func dereferenceRefs(schema *jsonschema.Schema, baseDir string, rootDefs map[string]*jsonschema.Schema) error {
-	if schema == nil {
-		return nil
-	}
+type dereferencer struct {
+	resolver   Resolver
+	mappings   map[string]string
+	collisions CollisionStrategy
+	rootDefs   map[string]*jsonschema.Schema
+	index      *index
 
-	// Handle external $ref (any ref that doesn't start with # is potentially external)
-	if schema.Ref != "" && !strings.HasPrefix(schema.Ref, "#") {
-		refPath := schema.Ref
-		var jsonPointer string
+	// sources holds the file each root definition was hoisted from
+	sources map[string]string
 
-		// Split file path from JSON Pointer fragment (e.g., "./events.yaml#/$defs/EventPayload")
-		if idx := strings.Index(refPath, "#"); idx != -1 {
-			jsonPointer = refPath[idx+1:] // Get everything after #
-			refPath = refPath[:idx]       // Get file path before #
-		}
+	// defined holds the name each hoisted definition was given
+	defined map[*jsonschema.Schema]string
 
-		fullPath := filepath.Join(baseDir, refPath)
+	// hoisted holds the references rewritten to point at a root definition
+	hoisted map[*jsonschema.Schema]bool
 
-		refData, err := os.ReadFile(fullPath)
-		if err != nil {
-			return fmt.Errorf("read ref %s: %w", schema.Ref, err)
-		}
+	// docs holds loaded documents by location, so each file is only loaded
+	// and hoisted once
+	docs map[string]*jsonschema.Schema
+}
 
-		// Use YAML→JSON conversion to trigger jsonschema's custom unmarshaler
-		var yamlData any
-		if err := yaml.Unmarshal(refData, &yamlData); err != nil {
-			return fmt.Errorf("parse ref %s: %w", schema.Ref, err)
-		}
-		jsonData, err := json.Marshal(yamlData)
-		if err != nil {
-			return fmt.Errorf("convert ref %s: %w", schema.Ref, err)
-		}
-		var refSchema jsonschema.Schema
-		if err := json.Unmarshal(jsonData, &refSchema); err != nil {
-			return fmt.Errorf("unmarshal ref %s: %w", schema.Ref, err)
-		}
+func (d *dereferencer) dereferenceRefs(schema *jsonschema.Schema, baseDir string) error {
+	if schema == nil {
+		return nil
+	}
 
-		refBaseDir := filepath.Dir(fullPath)
-		if err := dereferenceRefs(&refSchema, refBaseDir, rootDefs); err != nil {
+	if schema.DynamicRef != "" && schema.Ref == "" {
+		if err := d.dynamicRef(schema, baseDir); err != nil {
 			return err
 		}
+	}
 
-		// If there's a JSON Pointer, resolve it and add to root defs
-		if jsonPointer != "" {
-			targetSchema, err := resolveJSONPointer(&refSchema, jsonPointer)
-			if err != nil {
-				return fmt.Errorf("resolve pointer %s in %s: %w", jsonPointer, schema.Ref, err)
-			}
-
-			// Extract the definition name from the pointer
-			parts := strings.Split(strings.TrimPrefix(jsonPointer, "/"), "/")
-			var defName string
-
-			if len(parts) >= 2 && (parts[0] == "$defs" || parts[0] == "definitions") {
-				// Standard $defs/EventPayload format
-				defName = parts[1]
-			} else if len(parts) == 1 && parts[0] != "" {
-				// Root-level property like EventPayload (stored in Extra)
-				defName = parts[0]
-			}
-
-			if defName != "" {
-				// Add to root defs if not already present
-				if _, exists := rootDefs[defName]; !exists {
-					rootDefs[defName] = targetSchema
-				}
-
-				// Convert external ref to internal ref
-				schema.Ref = "#/$defs/" + defName
-			} else {
-				// Fallback: inline the schema if we can't determine a name
-				inlineSchema(schema, targetSchema)
+	// Local pointers such as #/$defs/Name are resolved by name after hoisting,
+	// anything else may identify a schema by URI
+	if schema.Ref != "" && !strings.HasPrefix(schema.Ref, "#/") {
+		handled, err := d.resolveRef(schema, baseDir)
+		if err != nil {
+			return err
+		}
+		if !handled {
+			if err := d.externalRef(schema, baseDir); err != nil {
+				return err
 			}
-		} else {
-			// No JSON Pointer: inline the entire file
-			inlineSchema(schema, &refSchema)
 		}
 	}
 
 	// Recursively process all nested schemas
-	if err := dereferenceRefs(schema.Items, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.Items, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.AdditionalItems, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.AdditionalItems, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.AdditionalProperties, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.AdditionalProperties, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.Contains, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.Contains, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.PropertyNames, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.PropertyNames, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.UnevaluatedItems, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.UnevaluatedItems, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.UnevaluatedProperties, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.UnevaluatedProperties, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.If, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.If, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.Then, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.Then, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.Else, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.Else, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.Not, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.Not, baseDir); err != nil {
 		return err
 	}
-	if err := dereferenceRefs(schema.ContentSchema, baseDir, rootDefs); err != nil {
+	if err := d.dereferenceRefs(schema.ContentSchema, baseDir); err != nil {
 		return err
 	}
 
 	// Process schema arrays
 	for _, s := range schema.PrefixItems {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+		if err := d.dereferenceRefs(s, baseDir); err != nil {
 			return err
 		}
 	}
 	for _, s := range schema.ItemsArray {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+		if err := d.dereferenceRefs(s, baseDir); err != nil {
 			return err
 		}
 	}
 	for _, s := range schema.AllOf {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+		if err := d.dereferenceRefs(s, baseDir); err != nil {
 			return err
 		}
 	}
 	for _, s := range schema.AnyOf {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+		if err := d.dereferenceRefs(s, baseDir); err != nil {
 			return err
 		}
 	}
 	for _, s := range schema.OneOf {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+		if err := d.dereferenceRefs(s, baseDir); err != nil {
 			return err
 		}
 	}
 
-	// Process schema maps
-	for _, s := range schema.Properties {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	// Process schema maps, in a stable order so the first of two colliding
+	// definitions is always the same
+	for _, name := range sortedKeys(schema.Properties) {
+		if err := d.dereferenceRefs(schema.Properties[name], baseDir); err != nil {
 			return err
 		}
 	}
-	for _, s := range schema.PatternProperties {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	for _, name := range sortedKeys(schema.PatternProperties) {
+		if err := d.dereferenceRefs(schema.PatternProperties[name], baseDir); err != nil {
 			return err
 		}
 	}
-	for _, s := range schema.DependentSchemas {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	for _, name := range sortedKeys(schema.DependentSchemas) {
+		if err := d.dereferenceRefs(schema.DependentSchemas[name], baseDir); err != nil {
 			return err
 		}
 	}
-	for _, s := range schema.DependencySchemas {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	for _, name := range sortedKeys(schema.DependencySchemas) {
+		if err := d.dereferenceRefs(schema.DependencySchemas[name], baseDir); err != nil {
 			return err
 		}
 	}
 
-	// Process and hoist nested definitions
-	for name, s := range schema.Defs {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	// Process nested definitions, they are hoisted once the whole document
+	// has been dereferenced
+	for _, name := range sortedKeys(schema.Defs) {
+		if err := d.dereferenceRefs(schema.Defs[name], baseDir); err != nil {
 			return err
 		}
-		if _, exists := rootDefs[name]; !exists {
-			rootDefs[name] = s
-		}
 	}
-	for name, s := range schema.Definitions {
-		if err := dereferenceRefs(s, baseDir, rootDefs); err != nil {
+	for _, name := range sortedKeys(schema.Definitions) {
+		if err := d.dereferenceRefs(schema.Definitions[name], baseDir); err != nil {
 			return err
 		}
-		if _, exists := rootDefs[name]; !exists {
-			rootDefs[name] = s
+	}
+
+	return nil
+}
+
+// externalRef loads a reference to another file, relative to the directory
+// of the file containing it, or to a remote URL.
+func (d *dereferencer) externalRef(schema *jsonschema.Schema, baseDir string) error {
+	refPath := schema.Ref
+	var jsonPointer string
+
+	// Split file path from JSON Pointer fragment (e.g., "./events.yaml#/$defs/EventPayload")
+	if idx := strings.Index(refPath, "#"); idx != -1 {
+		jsonPointer = refPath[idx+1:] // Get everything after #
+		refPath = refPath[:idx]       // Get file path before #
+
+		// OpenAPI and AsyncAPI component schemas are loaded as $defs
+		jsonPointer = openapi.RewritePointer(jsonPointer)
+	}
+
+	location, err := resolveLocation(baseDir, refPath)
+	if err != nil {
+		return fmt.Errorf("resolve ref %s: %w", schema.Ref, err)
+	}
+
+	docURI := location
+	if !IsRemote(location) {
+		docURI = fileURI(location)
+	}
+	refSchema, err := d.document(location, docURI)
+	if err != nil {
+		return fmt.Errorf("read ref %s: %w", schema.Ref, err)
+	}
+
+	// A plain name fragment is an anchor
+	if jsonPointer != "" && !strings.HasPrefix(jsonPointer, "/") {
+		return d.point(schema, refSchema, docURI, jsonPointer)
+	}
+
+	// If there's a JSON Pointer, resolve it and add to root defs
+	if jsonPointer != "" {
+		targetSchema, err := resolveJSONPointer(refSchema, jsonPointer)
+		if err != nil {
+			return fmt.Errorf("resolve pointer %s in %s: %w", jsonPointer, schema.Ref, err)
 		}
+
+		// Extract the definition name from the pointer
+		parts := strings.Split(strings.TrimPrefix(jsonPointer, "/"), "/")
+		var defName string
+
+		if len(parts) >= 2 && (parts[0] == "$defs" || parts[0] == "definitions") {
+			// Standard $defs/EventPayload format
+			defName = parts[1]
+		} else if len(parts) == 1 && parts[0] != "" {
+			// Root-level property like EventPayload (stored in Extra)
+			defName = parts[0]
+		}
+
+		if defName != "" {
+			// Add to root defs, under a different name if it collides
+			defName, err = d.define(defName, targetSchema)
+			if err != nil {
+				return err
+			}
+
+			// Convert external ref to internal ref
+			schema.Ref = "#/$defs/" + defName
+			d.hoisted[schema] = true
+		} else {
+			// Fallback: inline the schema if we can't determine a name
+			inlineSchema(schema, targetSchema)
+		}
+	} else {
+		// No JSON Pointer: inline the entire file
+		inlineSchema(schema, refSchema)
 	}
 
 	return nil
 }
 
+// document loads, dereferences and hoists the document at location. base is
+// the URI that references in the document resolve against.
+func (d *dereferencer) document(location, base string) (*jsonschema.Schema, error) {
+	if doc, ok := d.docs[location]; ok {
+		return doc, nil
+	}
+
+	doc, err := d.load(location)
+	if err != nil {
+		return nil, err
+	}
+
+	// Registered before dereferencing, so cyclic references terminate
+	d.docs[location] = doc
+	if err := d.index.add(doc, base, location); err != nil {
+		return nil, err
+	}
+	if err := d.dereferenceRefs(doc, locationBase(location)); err != nil {
+		return nil, err
+	}
+	if err := d.hoistDefs(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// load reads and parses the document at location, translating OpenAPI and
+// AsyncAPI documents.
+func (d *dereferencer) load(location string) (*jsonschema.Schema, error) {
+	refData, err := d.resolver.Resolve(location)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use YAML→JSON conversion to trigger jsonschema's custom unmarshaler
+	var yamlData any
+	if err := yaml.Unmarshal(refData, &yamlData); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if openapi.IsDocument(yamlData) {
+		yamlData, err = openapi.ToSchema(yamlData.(map[string]any))
+		if err != nil {
+			return nil, fmt.Errorf("translate: %w", err)
+		}
+	} else if asyncapi.IsDocument(yamlData) {
+		yamlData, err = asyncapi.ToSchema(yamlData.(map[string]any))
+		if err != nil {
+			return nil, fmt.Errorf("translate: %w", err)
+		}
+	}
+	jsonData, err := json.Marshal(yamlData)
+	if err != nil {
+		return nil, fmt.Errorf("convert: %w", err)
+	}
+	var refSchema jsonschema.Schema
+	if err := json.Unmarshal(jsonData, &refSchema); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &refSchema, nil
+}
+
 // inlineSchema merges the fields from src into dst, clearing the $ref.
 func inlineSchema(dst, src *jsonschema.Schema) {
 	// Clear the ref since we're inlining
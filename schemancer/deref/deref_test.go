@@ -73,3 +73,18 @@ func TestSchema_InternalRefs(t *testing.T) {
 	require.NotNil(t, schema.Defs)
 	assert.NotNil(t, schema.Defs["Name"])
 }
+
+func TestSchema_OpenAPIRefs(t *testing.T) {
+	schema, err := loader.FromFile("testdata/openapi_refs.yaml")
+	require.NoError(t, err)
+
+	// Refs into an OpenAPI document's components are hoisted like $defs
+	assert.Equal(t, "#/$defs/Error", schema.Properties["error"].Ref)
+
+	require.NotNil(t, schema.Defs)
+	errorDef := schema.Defs["Error"]
+	require.NotNil(t, errorDef)
+	assert.Equal(t, []string{"string", "null"}, errorDef.Properties["message"].Types)
+	assert.Equal(t, "#/$defs/ErrorDetail", errorDef.Properties["detail"].Ref)
+	assert.NotNil(t, schema.Defs["ErrorDetail"])
+}
@@ -0,0 +1,290 @@
+package deref
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+)
+
+// CollisionStrategy decides what happens when definitions from different
+// files share a name but differ in structure. Identical definitions are
+// always merged.
+type CollisionStrategy string
+
+const (
+	// CollisionError fails with an error naming both source files
+	CollisionError CollisionStrategy = "error"
+
+	// CollisionPrefix namespaces the later definition with its file name, so
+	// Address from billing.yaml becomes BillingAddress.
+	CollisionPrefix CollisionStrategy = "prefix"
+)
+
+// WithCollisionStrategy sets how conflicting definitions are hoisted
+// (default: CollisionError).
+func WithCollisionStrategy(strategy CollisionStrategy) Option {
+	return func(c *config) {
+		c.collisions = strategy
+	}
+}
+
+// hoistDefs hoists the definitions nested anywhere in a document to the root
+// $defs. Definitions that collide are renamed together, and the document's
+// own references to them follow, since renaming one definition can make
+// another that references it differ too.
+func (d *dereferencer) hoistDefs(doc *jsonschema.Schema) error {
+	defs, err := d.collectDefs(doc)
+	if err != nil {
+		return err
+	}
+
+	renames := make(map[string]string)
+	for {
+		changed := false
+		for _, def := range defs {
+			if _, ok := d.defined[def.schema]; ok || renames[def.name] != "" {
+				continue
+			}
+			name, err := d.defName(def.name, def.schema)
+			if err != nil {
+				return err
+			}
+			if name != def.name {
+				renames[def.name] = name
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		d.renameRefs(doc, renames)
+	}
+
+	for _, def := range defs {
+		if _, ok := d.defined[def.schema]; ok {
+			continue
+		}
+		name := def.name
+		if renamed, ok := renames[name]; ok {
+			name = renamed
+		}
+		d.add(name, def.schema)
+	}
+	return nil
+}
+
+// define hoists a single definition to the root $defs, returning the name it
+// was hoisted under.
+func (d *dereferencer) define(name string, s *jsonschema.Schema) (string, error) {
+	if defined, ok := d.defined[s]; ok {
+		return defined, nil
+	}
+	name, err := d.defName(name, s)
+	if err != nil {
+		return "", err
+	}
+	d.add(name, s)
+	return name, nil
+}
+
+func (d *dereferencer) add(name string, s *jsonschema.Schema) {
+	if _, exists := d.rootDefs[name]; !exists {
+		d.rootDefs[name] = s
+		d.sources[name] = d.index.sources[s]
+	}
+	d.defined[s] = name
+}
+
+// defName returns the name a definition is hoisted under, applying the
+// collision strategy when a different definition already has the name.
+func (d *dereferencer) defName(name string, s *jsonschema.Schema) (string, error) {
+	existing, ok := d.rootDefs[name]
+	if !ok || sameSchema(existing, s) {
+		return name, nil
+	}
+
+	source := d.index.sources[s]
+	if d.collisions != CollisionPrefix {
+		return "", fmt.Errorf("definition %q in %s conflicts with the definition of the same name in %s", name, sourceLabel(source), sourceLabel(d.sources[name]))
+	}
+
+	prefixed := sourcePrefix(source) + casing.ToPascalCase(name)
+	if existing, ok := d.rootDefs[prefixed]; ok && !sameSchema(existing, s) {
+		return "", fmt.Errorf("definition %q in %s conflicts with %q in %s, and its namespaced name %q is taken by %s", name, sourceLabel(source), name, sourceLabel(d.sources[name]), prefixed, sourceLabel(d.sources[prefixed]))
+	}
+	return prefixed, nil
+}
+
+// renameRefs points a document's references to its own definitions at their
+// new names. References hoisted from other documents already use the final
+// name and are left alone.
+func (d *dereferencer) renameRefs(s *jsonschema.Schema, renames map[string]string) {
+	if s == nil {
+		return
+	}
+	if !d.hoisted[s] {
+		for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+			rest, ok := strings.CutPrefix(s.Ref, prefix)
+			if !ok {
+				continue
+			}
+			name, pointer, _ := strings.Cut(rest, "/")
+			if renamed, ok := renames[name]; ok {
+				s.Ref = "#/$defs/" + renamed
+				if pointer != "" {
+					s.Ref += "/" + pointer
+				}
+			}
+		}
+	}
+	for _, sub := range subschemas(s) {
+		d.renameRefs(sub, renames)
+	}
+}
+
+type namedSchema struct {
+	name   string
+	schema *jsonschema.Schema
+
+	// owner is the name of the enclosing definition, empty at the top level
+	owner string
+}
+
+// collectDefs returns the definitions nested anywhere in a document. A
+// definition sharing its name with an earlier, identical one is merged into
+// it, and one that differs is handled by the collision strategy: prefix
+// namespaces it with its enclosing definition, so Address nested in Order
+// becomes OrderAddress, and the document's references to it follow.
+func (d *dereferencer) collectDefs(doc *jsonschema.Schema) ([]namedSchema, error) {
+	var defs []namedSchema
+	seen := make(map[string]namedSchema)
+	renamed := make(map[*jsonschema.Schema]string)
+
+	var walk func(s *jsonschema.Schema, owner string) error
+	walk = func(s *jsonschema.Schema, owner string) error {
+		if s == nil {
+			return nil
+		}
+
+		owners := make(map[*jsonschema.Schema]string)
+		for _, m := range []map[string]*jsonschema.Schema{s.Defs, s.Definitions} {
+			for _, name := range sortedKeys(m) {
+				def := namedSchema{name, m[name], owner}
+				if first, ok := seen[name]; ok {
+					if sameSchema(first.schema, def.schema) {
+						continue
+					}
+					var err error
+					if def.name, err = d.nestedDefName(def, first); err != nil {
+						return err
+					}
+					renamed[def.schema] = def.name
+				}
+				seen[def.name] = def
+				defs = append(defs, def)
+				owners[def.schema] = def.name
+			}
+		}
+
+		for _, sub := range subschemas(s) {
+			subOwner := owner
+			if name, ok := owners[sub]; ok {
+				subOwner = name
+			}
+			if err := walk(sub, subOwner); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(doc, ""); err != nil {
+		return nil, err
+	}
+
+	if len(renamed) > 0 {
+		d.pointRefs(doc, doc, renamed, make(map[*jsonschema.Schema]bool))
+	}
+	return defs, nil
+}
+
+// nestedDefName applies the collision strategy to a definition that differs
+// from an earlier one of the same name in the same document.
+func (d *dereferencer) nestedDefName(def, first namedSchema) (string, error) {
+	source := sourceLabel(d.index.sources[def.schema])
+	if d.collisions != CollisionPrefix {
+		return "", fmt.Errorf("definition %q %s conflicts with the definition of the same name %s in %s", def.name, ownerLabel(def.owner), ownerLabel(first.owner), source)
+	}
+	if def.owner == "" {
+		return "", fmt.Errorf("definition %q %s conflicts with the definition of the same name %s in %s, and has no enclosing definition to namespace it with", def.name, ownerLabel(def.owner), ownerLabel(first.owner), source)
+	}
+	return casing.ToPascalCase(def.owner) + casing.ToPascalCase(def.name), nil
+}
+
+// pointRefs points a document's references to renamed nested definitions at
+// their new names.
+func (d *dereferencer) pointRefs(doc, s *jsonschema.Schema, renamed map[*jsonschema.Schema]string, visited map[*jsonschema.Schema]bool) {
+	if s == nil || visited[s] {
+		return
+	}
+	visited[s] = true
+
+	if pointer, ok := strings.CutPrefix(s.Ref, "#/"); ok && !d.hoisted[s] {
+		if target, err := resolveJSONPointer(doc, pointer); err == nil {
+			if name, ok := renamed[target]; ok {
+				s.Ref = "#/$defs/" + name
+			}
+		}
+	}
+	for _, sub := range subschemas(s) {
+		d.pointRefs(doc, sub, renamed, visited)
+	}
+}
+
+func ownerLabel(owner string) string {
+	if owner == "" {
+		return "at the top level"
+	}
+	return "nested in " + owner
+}
+
+// sameSchema reports whether two definitions are structurally identical
+func sameSchema(a, b *jsonschema.Schema) bool {
+	if a == b {
+		return true
+	}
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && bytes.Equal(aj, bj)
+}
+
+// sourcePrefix derives a namespace from a file name or URL, such as Billing
+// from "schemas/billing.yaml".
+func sourcePrefix(source string) string {
+	name, _, _ := strings.Cut(path.Base(strings.ReplaceAll(source, "\\", "/")), ".")
+	return casing.ToPascalCase(name)
+}
+
+// relativeSource makes a local source path relative to the root schema
+func relativeSource(baseDir, source string) string {
+	if IsRemote(source) || IsRemote(baseDir) {
+		return source
+	}
+	if rel, err := filepath.Rel(baseDir, source); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(source)
+}
+
+func sourceLabel(source string) string {
+	if source == "" {
+		return "the root schema"
+	}
+	return source
+}
@@ -0,0 +1,57 @@
+package deref_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer/deref"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+)
+
+func TestSchema_CollisionError(t *testing.T) {
+	_, err := loader.FromFile("testdata/collisions/root.yaml")
+	require.Error(t, err)
+	assert.ErrorContains(t, err, `definition "Region" in testdata/collisions/shipping.yaml conflicts with the definition of the same name in testdata/collisions/billing.yaml`)
+}
+
+func TestSchema_CollisionPrefix(t *testing.T) {
+	schema, err := loader.FromFile("testdata/collisions/root.yaml", deref.WithCollisionStrategy(deref.CollisionPrefix))
+	require.NoError(t, err)
+
+	// The first file keeps the plain names
+	assert.Equal(t, "#/$defs/Invoice", schema.Properties["invoice"].Ref)
+	assert.Equal(t, "#/$defs/Address", schema.Defs["Invoice"].Properties["address"].Ref)
+	assert.Equal(t, "#/$defs/Region", schema.Defs["Address"].Properties["region"].Ref)
+	assert.Equal(t, []any{"eu", "us"}, schema.Defs["Region"].Enum)
+
+	// The second file's Region differs, which makes its Address differ too
+	assert.Equal(t, "#/$defs/Parcel", schema.Properties["parcel"].Ref)
+	assert.Equal(t, "#/$defs/ShippingAddress", schema.Defs["Parcel"].Properties["address"].Ref)
+	assert.Equal(t, "#/$defs/ShippingRegion", schema.Defs["ShippingAddress"].Properties["region"].Ref)
+	assert.Equal(t, []any{"north", "south"}, schema.Defs["ShippingRegion"].Enum)
+
+	// Identical definitions are merged
+	assert.Equal(t, "#/$defs/Money", schema.Defs["Parcel"].Properties["cost"].Ref)
+	assert.NotContains(t, schema.Defs, "ShippingMoney")
+}
+
+func TestSchema_NestedCollisionError(t *testing.T) {
+	_, err := loader.FromFile("testdata/collisions/nested.yaml")
+	assert.ErrorContains(t, err, `definition "Address" nested in Order conflicts with the definition of the same name at the top level in the root schema`)
+}
+
+func TestSchema_NestedCollisionPrefix(t *testing.T) {
+	schema, err := loader.FromFile("testdata/collisions/nested.yaml", deref.WithCollisionStrategy(deref.CollisionPrefix))
+	require.NoError(t, err)
+
+	// The nested definition is namespaced with its enclosing definition
+	assert.Equal(t, "#/$defs/Address", schema.Properties["home"].Ref)
+	assert.Equal(t, "#/$defs/OrderAddress", schema.Defs["Order"].Properties["shipTo"].Ref)
+	require.Contains(t, schema.Defs, "OrderAddress")
+	assert.Contains(t, schema.Defs["OrderAddress"].Properties, "locker")
+
+	// Identical definitions are merged
+	assert.NotContains(t, schema.Defs, "OrderMoney")
+}
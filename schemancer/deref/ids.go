@@ -0,0 +1,346 @@
+package deref
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// index records the base URI of every loaded schema along with the schema
+// resources ($id) and anchors ($anchor, $dynamicAnchor) they declare, so
+// references can be resolved the way JSON Schema 2020-12 describes.
+type index struct {
+	// resources holds schemas by their absolute $id, without fragment
+	resources map[string]*jsonschema.Schema
+
+	// anchors holds schemas by absolute URI with an anchor fragment. Dynamic
+	// anchors are included, since they also behave as plain anchors.
+	anchors map[string]*jsonschema.Schema
+
+	// dynamicAnchors holds the schemas declaring a $dynamicAnchor
+	dynamicAnchors map[string]*jsonschema.Schema
+
+	// bases holds the base URI in effect for each schema
+	bases map[*jsonschema.Schema]string
+
+	// scopes holds the resources enclosing each schema, outermost first,
+	// which approximates the dynamic scope for $dynamicRef.
+	scopes map[*jsonschema.Schema][]string
+
+	// defNames holds the name of each schema declared in $defs or
+	// definitions, so references to it keep that name when hoisted.
+	defNames map[*jsonschema.Schema]string
+
+	// sources holds the file each schema was loaded from, empty for the root
+	sources map[*jsonschema.Schema]string
+}
+
+func newIndex() *index {
+	return &index{
+		resources:      make(map[string]*jsonschema.Schema),
+		anchors:        make(map[string]*jsonschema.Schema),
+		dynamicAnchors: make(map[string]*jsonschema.Schema),
+		bases:          make(map[*jsonschema.Schema]string),
+		scopes:         make(map[*jsonschema.Schema][]string),
+		defNames:       make(map[*jsonschema.Schema]string),
+		sources:        make(map[*jsonschema.Schema]string),
+	}
+}
+
+// add indexes a document loaded from source, with base being the document
+// URI or, for the root schema, the URI of its directory.
+func (idx *index) add(doc *jsonschema.Schema, base, source string) error {
+	return idx.walk(doc, base, source, []string{base})
+}
+
+func (idx *index) walk(s *jsonschema.Schema, base, source string, scope []string) error {
+	if s == nil {
+		return nil
+	}
+	if _, seen := idx.bases[s]; seen {
+		return nil
+	}
+
+	if s.ID != "" {
+		id, err := resolveURI(base, s.ID)
+		if err != nil {
+			return fmt.Errorf("invalid $id %q: %w", s.ID, err)
+		}
+		uri, fragment, _ := strings.Cut(id, "#")
+		if strings.HasPrefix(s.ID, "#") {
+			// Draft 7 style "$id": "#name" declares an anchor
+			if fragment != "" {
+				if err := idx.addAnchor(uri+"#"+fragment, s); err != nil {
+					return err
+				}
+			}
+		} else {
+			if uri != base {
+				base = uri
+				scope = append(scope[:len(scope):len(scope)], uri)
+			}
+			idx.resources[uri] = s
+		}
+	}
+
+	idx.bases[s] = base
+	idx.scopes[s] = scope
+	idx.sources[s] = source
+
+	if s.Anchor != "" {
+		if err := idx.addAnchor(base+"#"+s.Anchor, s); err != nil {
+			return err
+		}
+	}
+	if s.DynamicAnchor != "" {
+		if err := idx.addAnchor(base+"#"+s.DynamicAnchor, s); err != nil {
+			return err
+		}
+		idx.dynamicAnchors[base+"#"+s.DynamicAnchor] = s
+	}
+
+	for _, defs := range []map[string]*jsonschema.Schema{s.Defs, s.Definitions} {
+		for name, def := range defs {
+			if _, ok := idx.defNames[def]; !ok {
+				idx.defNames[def] = name
+			}
+		}
+	}
+
+	for _, sub := range subschemas(s) {
+		if err := idx.walk(sub, base, source, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAnchor indexes a schema by its anchor URI. A resource can declare each
+// anchor only once.
+func (idx *index) addAnchor(uri string, s *jsonschema.Schema) error {
+	if existing, ok := idx.anchors[uri]; ok && existing != s {
+		return fmt.Errorf("anchor %s is declared more than once", uri)
+	}
+	idx.anchors[uri] = s
+	return nil
+}
+
+// resolveRef resolves $ref values that identify schemas by URI: anchors,
+// embedded resources declared with $id, and URIs mapped to local files. The
+// target is hoisted to the root $defs. It reports false for references it
+// doesn't handle, which are then loaded relative to the containing file.
+func (d *dereferencer) resolveRef(schema *jsonschema.Schema, baseDir string) (bool, error) {
+	abs, err := resolveURI(d.base(schema, baseDir), schema.Ref)
+	if err != nil {
+		return false, fmt.Errorf("resolve ref %s: %w", schema.Ref, err)
+	}
+	uri, fragment, _ := strings.Cut(abs, "#")
+
+	if target, ok := d.index.anchors[abs]; ok {
+		return true, d.hoist(schema, target, fragment)
+	}
+
+	doc, ok := d.index.resources[uri]
+	if !ok {
+		local, mapped := d.mapURI(uri)
+		if !mapped {
+			// Other local fragments, such as "#" for the root schema, are
+			// left for the generators to resolve
+			return strings.HasPrefix(schema.Ref, "#"), nil
+		}
+		if doc, err = d.loadMapped(uri, local); err != nil {
+			return false, fmt.Errorf("read ref %s: %w", schema.Ref, err)
+		}
+	}
+
+	return true, d.point(schema, doc, uri, fragment)
+}
+
+// point hoists the schema a fragment identifies within a resource
+func (d *dereferencer) point(schema, doc *jsonschema.Schema, uri, fragment string) error {
+	switch {
+	case fragment == "":
+		return d.hoist(schema, doc, resourceName(doc, uri))
+	case strings.HasPrefix(fragment, "/"):
+		target, err := resolveJSONPointer(doc, fragment)
+		if err != nil {
+			return fmt.Errorf("resolve pointer %s in %s: %w", fragment, schema.Ref, err)
+		}
+		parts := strings.Split(fragment, "/")
+		return d.hoist(schema, target, parts[len(parts)-1])
+	default:
+		target, ok := d.index.anchors[d.index.bases[doc]+"#"+fragment]
+		if !ok {
+			return fmt.Errorf("resolve ref %s: anchor %q not found", schema.Ref, fragment)
+		}
+		return d.hoist(schema, target, fragment)
+	}
+}
+
+// dynamicRef resolves a $dynamicRef. It first resolves like $ref, and when
+// the target declares a matching $dynamicAnchor, the outermost enclosing
+// resource declaring the same dynamic anchor is used instead. Since types are
+// generated once rather than per use, the enclosing resources of the
+// reference stand in for the dynamic scope.
+func (d *dereferencer) dynamicRef(schema *jsonschema.Schema, baseDir string) error {
+	abs, err := resolveURI(d.base(schema, baseDir), schema.DynamicRef)
+	if err != nil {
+		return fmt.Errorf("resolve dynamic ref %s: %w", schema.DynamicRef, err)
+	}
+	_, fragment, _ := strings.Cut(abs, "#")
+
+	target, ok := d.index.anchors[abs]
+	if !ok || fragment == "" || strings.HasPrefix(fragment, "/") {
+		// Without an anchor it behaves exactly like $ref
+		schema.Ref = schema.DynamicRef
+		schema.DynamicRef = ""
+		return nil
+	}
+
+	if target.DynamicAnchor == fragment {
+		for _, resource := range d.index.scopes[schema] {
+			if t, ok := d.index.dynamicAnchors[resource+"#"+fragment]; ok {
+				target = t
+				break
+			}
+		}
+	}
+
+	schema.DynamicRef = ""
+	return d.hoist(schema, target, fragment)
+}
+
+// hoist adds target to the root $defs and points the reference at it. A
+// target declared in $defs keeps its name, unless it collides.
+func (d *dereferencer) hoist(schema, target *jsonschema.Schema, name string) error {
+	if defName, ok := d.index.defNames[target]; ok {
+		name = defName
+	}
+	name, err := d.define(name, target)
+	if err != nil {
+		return err
+	}
+	schema.Ref = "#/$defs/" + name
+	d.hoisted[schema] = true
+	return nil
+}
+
+// loadMapped loads the local copy of a mapped URI. The document keeps the
+// URI as its base, so relative references inside it resolve against the URI
+// and are mapped again.
+func (d *dereferencer) loadMapped(uri, local string) (*jsonschema.Schema, error) {
+	doc, err := d.document(local, uri)
+	if err != nil {
+		return nil, err
+	}
+	d.index.resources[uri] = doc
+	return doc, nil
+}
+
+// mapURI maps a URI onto a local path using the longest matching prefix
+func (d *dereferencer) mapURI(uri string) (string, bool) {
+	prefixes := make([]string, 0, len(d.mappings))
+	for prefix := range d.mappings {
+		if strings.HasPrefix(uri, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if len(prefixes) == 0 {
+		return "", false
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	prefix := prefixes[0]
+	rest := strings.TrimPrefix(uri, prefix)
+	target := d.mappings[prefix]
+	if IsRemote(target) {
+		return target + rest, true
+	}
+	return filepath.Join(target, filepath.FromSlash(rest)), true
+}
+
+// base returns the base URI that a schema's references resolve against
+func (d *dereferencer) base(schema *jsonschema.Schema, baseDir string) string {
+	if base, ok := d.index.bases[schema]; ok {
+		return base
+	}
+	return dirURI(baseDir)
+}
+
+// dirURI converts the directory a document was loaded from into a base URI
+func dirURI(dir string) string {
+	if IsRemote(dir) {
+		return dir
+	}
+	return fileURI(dir) + "/"
+}
+
+// fileURI converts a local path into a file URI
+func fileURI(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		p = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(p)}).String()
+}
+
+func resolveURI(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+// resourceName names a hoisted resource after its $id, the URI it was
+// referenced by, or its title.
+func resourceName(doc *jsonschema.Schema, uri string) string {
+	for _, id := range []string{doc.ID, uri} {
+		name, _, _ := strings.Cut(path.Base(strings.TrimSuffix(id, "/")), ".")
+		if name != "" && name != "/" {
+			return name
+		}
+	}
+	if doc.Title != "" {
+		return doc.Title
+	}
+	return "Schema"
+}
+
+// subschemas returns the schemas nested directly within s
+func subschemas(s *jsonschema.Schema) []*jsonschema.Schema {
+	subs := []*jsonschema.Schema{
+		s.Items, s.AdditionalItems, s.AdditionalProperties, s.Contains,
+		s.PropertyNames, s.UnevaluatedItems, s.UnevaluatedProperties, s.If,
+		s.Then, s.Else, s.Not, s.ContentSchema,
+	}
+	for _, list := range [][]*jsonschema.Schema{s.PrefixItems, s.ItemsArray, s.AllOf, s.AnyOf, s.OneOf} {
+		subs = append(subs, list...)
+	}
+	for _, m := range []map[string]*jsonschema.Schema{
+		s.Properties, s.PatternProperties, s.DependentSchemas, s.DependencySchemas,
+		s.Defs, s.Definitions,
+	} {
+		for _, name := range sortedKeys(m) {
+			subs = append(subs, m[name])
+		}
+	}
+	return subs
+}
+
+func sortedKeys(m map[string]*jsonschema.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+package deref_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer/deref"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+)
+
+func TestSchema_IDRefs(t *testing.T) {
+	schema, err := loader.FromFile("testdata/ids.yaml", deref.WithMappings(map[string]string{
+		"https://example.com/shared/": "testdata/vendor",
+	}))
+	require.NoError(t, err)
+
+	// Relative refs resolve against the $id of the enclosing resource, and
+	// match embedded resources by their own $id
+	assert.Equal(t, "#/$defs/PostalAddress", schema.Properties["address"].Ref)
+
+	// Anchors resolve within the resource that declares them
+	assert.Equal(t, "#/$defs/street", schema.Properties["street"].Ref)
+	assert.Equal(t, "string", schema.Defs["street"].Type)
+	assert.Equal(t, "#/$defs/TagList", schema.Properties["tags"].Ref)
+
+	// Mapped URIs load the local copy, and refs inside it are mapped too
+	assert.Equal(t, "#/$defs/country", schema.Properties["country"].Ref)
+	country := schema.Defs["country"]
+	require.NotNil(t, country)
+	assert.Equal(t, "Country", country.Title)
+	assert.Equal(t, "#/$defs/region", country.Properties["region"].Ref)
+	require.NotNil(t, schema.Defs["region"])
+	assert.Len(t, schema.Defs["region"].Enum, 3)
+}
+
+func TestSchema_IDRefsUnmapped(t *testing.T) {
+	// Without a mapping, the absolute URI is treated as a remote reference
+	_, err := loader.FromFile("testdata/ids.yaml", deref.WithResolver(deref.ResolverFunc(func(location string) ([]byte, error) {
+		assert.Equal(t, "https://example.com/shared/country.json", location)
+		return nil, assert.AnError
+	})))
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestSchema_DynamicRef(t *testing.T) {
+	schema, err := loader.FromFile("testdata/dynamic.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "#/$defs/Page", schema.Ref)
+
+	// The outermost resource declaring the dynamic anchor wins over the
+	// default declared next to the reference
+	items := schema.Defs["Page"].Properties["items"].Items
+	assert.Equal(t, "#/$defs/StringItem", items.Ref)
+	assert.Empty(t, items.DynamicRef)
+}
+
+func TestSchema_DuplicateAnchor(t *testing.T) {
+	_, err := loader.FromFile("testdata/duplicate_anchor.yaml")
+	assert.ErrorContains(t, err, "#name is declared more than once")
+}
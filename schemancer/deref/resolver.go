@@ -0,0 +1,255 @@
+package deref
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Resolver loads the document an external $ref points at. The location is
+// either a file path or an absolute http(s) URL.
+type Resolver interface {
+	Resolve(location string) ([]byte, error)
+}
+
+// ResolverFunc adapts a function to the Resolver interface
+type ResolverFunc func(location string) ([]byte, error)
+
+func (f ResolverFunc) Resolve(location string) ([]byte, error) { return f(location) }
+
+// Option configures how references are dereferenced
+type Option func(*config)
+
+type config struct {
+	resolver   Resolver
+	mappings   map[string]string
+	collisions CollisionStrategy
+}
+
+// WithResolver sets the resolver used for external references. The default
+// resolver reads files and fetches remote references without caching.
+func WithResolver(r Resolver) Option {
+	return func(c *config) {
+		c.resolver = r
+	}
+}
+
+// WithMappings maps URI prefixes onto local directories or files, so schemas
+// identified by an $id URI resolve to a local copy. References to
+// "https://example.com/schemas/a.json" with the mapping
+// "https://example.com/schemas/" to "./vendor" load "./vendor/a.json". The
+// longest matching prefix is used.
+func WithMappings(mappings map[string]string) Option {
+	return func(c *config) {
+		c.mappings = mappings
+	}
+}
+
+// DefaultResolver reads local files and fetches remote documents, giving up
+// after DefaultTimeout.
+var DefaultResolver Resolver = &RemoteResolver{}
+
+// IsRemote reports whether a location is an http(s) URL
+func IsRemote(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// resolveLocation resolves a reference path against the location of the
+// document containing it, which is a directory for local files and the
+// document URL for remote ones.
+func resolveLocation(base, ref string) (string, error) {
+	if IsRemote(ref) {
+		return ref, nil
+	}
+	if IsRemote(base) {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", err
+		}
+		refURL, err := url.Parse(ref)
+		if err != nil {
+			return "", err
+		}
+		return baseURL.ResolveReference(refURL).String(), nil
+	}
+	return filepath.Join(base, ref), nil
+}
+
+// locationBase returns the base that references inside the document at
+// location are resolved against.
+func locationBase(location string) string {
+	if IsRemote(location) {
+		return location
+	}
+	return filepath.Dir(location)
+}
+
+// DefaultTimeout limits how long a RemoteResolver without a Client or Timeout
+// waits for a remote document
+const DefaultTimeout = 30 * time.Second
+
+// ErrNotCached is returned by a RemoteResolver in offline mode when a remote
+// reference has not been cached yet.
+var ErrNotCached = errors.New("not in cache")
+
+// RemoteResolver reads local files and fetches remote references over
+// HTTP(S). When CacheDir is set, fetched documents are stored there so later
+// runs can resolve them with Offline set, without network access.
+//
+// The cache holds one index entry per URL, named after the hash of the URL
+// and recording the hash of the content last fetched from it, and the
+// content itself stored under its hash.
+type RemoteResolver struct {
+	// Client is used for requests. When nil, a client limited to Timeout is
+	// used.
+	Client *http.Client
+
+	// Timeout limits each request of the default client, DefaultTimeout when
+	// zero
+	Timeout time.Duration
+
+	// CacheDir is where fetched documents are cached, no caching when empty
+	CacheDir string
+
+	// Offline resolves remote references from the cache only
+	Offline bool
+}
+
+// cacheEntry is the index entry recorded for a cached URL
+type cacheEntry struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+func (r *RemoteResolver) Resolve(location string) ([]byte, error) {
+	if !IsRemote(location) {
+		return os.ReadFile(location)
+	}
+
+	if r.Offline {
+		if r.CacheDir == "" {
+			return nil, fmt.Errorf("%s: offline mode requires a cache directory", location)
+		}
+		data, err := r.readCache(location)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: %w, fetch it once without offline mode", location, ErrNotCached)
+		}
+		return data, err
+	}
+
+	data, err := r.fetch(location)
+	if err != nil {
+		return nil, err
+	}
+	if r.CacheDir != "" {
+		if err := r.writeCache(location, data); err != nil {
+			return nil, fmt.Errorf("cache %s: %w", location, err)
+		}
+	}
+	return data, nil
+}
+
+func (r *RemoteResolver) fetch(location string) ([]byte, error) {
+	client := r.Client
+	if client == nil {
+		timeout := r.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", location, err)
+	}
+	return data, nil
+}
+
+func (r *RemoteResolver) indexPath(location string) string {
+	return filepath.Join(r.CacheDir, "index", hash([]byte(location))+".json")
+}
+
+func (r *RemoteResolver) contentPath(sum string) string {
+	return filepath.Join(r.CacheDir, "content", sum)
+}
+
+func (r *RemoteResolver) readCache(location string) ([]byte, error) {
+	indexData, err := os.ReadFile(r.indexPath(location))
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(indexData, &entry); err != nil {
+		return nil, fmt.Errorf("%s: corrupt cache index: %w", location, err)
+	}
+
+	data, err := os.ReadFile(r.contentPath(entry.SHA256))
+	if err != nil {
+		return nil, err
+	}
+	if hash(data) != entry.SHA256 {
+		return nil, fmt.Errorf("%s: cached content does not match its hash", location)
+	}
+	return data, nil
+}
+
+func (r *RemoteResolver) writeCache(location string, data []byte) error {
+	sum := hash(data)
+
+	if err := writeFile(r.contentPath(sum), data); err != nil {
+		return err
+	}
+
+	entry, err := json.MarshalIndent(cacheEntry{URL: location, SHA256: sum}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(r.indexPath(location), entry)
+}
+
+// writeFile writes a file through a temporary file, so concurrent runs never
+// observe a partially written cache entry.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
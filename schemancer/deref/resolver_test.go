@@ -0,0 +1,132 @@
+package deref_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer/deref"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+)
+
+// remoteDocs are served by the test server. The nested reference is relative,
+// so it resolves against the URL of the document containing it.
+var remoteDocs = map[string]string{
+	"/schemas/money.yaml": `
+$defs:
+  Money:
+    type: object
+    properties:
+      amount:
+        type: integer
+      currency:
+        $ref: "./currency.yaml#/$defs/Currency"
+    required: [amount, currency]
+`,
+	"/schemas/currency.yaml": `
+$defs:
+  Currency:
+    type: string
+    enum: [EUR, USD]
+`,
+}
+
+func serveSchemas(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		doc, ok := remoteDocs[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(doc))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func loadRemote(t *testing.T, srv *httptest.Server, resolver deref.Resolver) error {
+	t.Helper()
+
+	schema, err := loader.FromReader(strings.NewReader(`
+type: object
+properties:
+  price:
+    $ref: "` + srv.URL + `/schemas/money.yaml#/$defs/Money"
+`))
+	require.NoError(t, err)
+
+	if err := deref.Schema(schema, "testdata", deref.WithResolver(resolver)); err != nil {
+		return err
+	}
+
+	assert.Equal(t, "#/$defs/Money", schema.Properties["price"].Ref)
+	require.NotNil(t, schema.Defs["Money"])
+	assert.Equal(t, "#/$defs/Currency", schema.Defs["Money"].Properties["currency"].Ref)
+	require.NotNil(t, schema.Defs["Currency"])
+	assert.Equal(t, []any{"EUR", "USD"}, schema.Defs["Currency"].Enum)
+	return nil
+}
+
+func TestSchema_RemoteRefs(t *testing.T) {
+	srv, requests := serveSchemas(t)
+
+	require.NoError(t, loadRemote(t, srv, &deref.RemoteResolver{Client: srv.Client()}))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestSchema_RemoteRefsOffline(t *testing.T) {
+	srv, requests := serveSchemas(t)
+	cacheDir := t.TempDir()
+
+	// Offline before anything is cached fails without making requests
+	err := loadRemote(t, srv, &deref.RemoteResolver{CacheDir: cacheDir, Offline: true})
+	assert.ErrorIs(t, err, deref.ErrNotCached)
+	assert.Equal(t, int32(0), requests.Load())
+
+	// Fetching populates the cache
+	require.NoError(t, loadRemote(t, srv, &deref.RemoteResolver{Client: srv.Client(), CacheDir: cacheDir}))
+	assert.Equal(t, int32(2), requests.Load())
+
+	// Offline now resolves from the cache, even with the server gone
+	srv.Close()
+	require.NoError(t, loadRemote(t, srv, &deref.RemoteResolver{CacheDir: cacheDir, Offline: true}))
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestSchema_RemoteRefNotFound(t *testing.T) {
+	srv, _ := serveSchemas(t)
+
+	schema, err := loader.FromReader(strings.NewReader(`
+properties:
+  missing:
+    $ref: "` + srv.URL + `/schemas/missing.yaml#/$defs/Missing"
+`))
+	require.NoError(t, err)
+
+	err = deref.Schema(schema, "testdata", deref.WithResolver(&deref.RemoteResolver{Client: srv.Client()}))
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestRemoteResolver_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	resolver := &deref.RemoteResolver{Timeout: 50 * time.Millisecond}
+	_, err := resolver.Resolve(srv.URL + "/schemas/money.yaml")
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
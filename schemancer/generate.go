@@ -4,9 +4,17 @@ import (
 	"fmt"
 
 	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/csharp"
+	"github.com/Southclaws/schemancer/schemancer/generators/dart"
 	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/generators/graphql"
 	"github.com/Southclaws/schemancer/schemancer/generators/java"
+	"github.com/Southclaws/schemancer/schemancer/generators/kotlin"
+	"github.com/Southclaws/schemancer/schemancer/generators/openapi"
+	"github.com/Southclaws/schemancer/schemancer/generators/protobuf"
 	"github.com/Southclaws/schemancer/schemancer/generators/python"
+	"github.com/Southclaws/schemancer/schemancer/generators/rust"
+	"github.com/Southclaws/schemancer/schemancer/generators/swift"
 	"github.com/Southclaws/schemancer/schemancer/generators/typescript"
 	typescriptzod "github.com/Southclaws/schemancer/schemancer/generators/typescript-zod"
 
@@ -19,6 +27,14 @@ var Generators = map[generators.Language]generators.Generator{
 	generators.LanguageTypeScriptZod: &typescriptzod.Generator{},
 	generators.LanguageJava:          &java.Generator{},
 	generators.LanguagePython:        &python.Generator{},
+	generators.LanguageRust:          &rust.Generator{},
+	generators.LanguageKotlin:        &kotlin.Generator{},
+	generators.LanguageCSharp:        &csharp.Generator{},
+	generators.LanguageSwift:         &swift.Generator{},
+	generators.LanguageDart:          &dart.Generator{},
+	generators.LanguageProtobuf:      &protobuf.Generator{},
+	generators.LanguageGraphQL:       &graphql.Generator{},
+	generators.LanguageOpenAPI:       &openapi.Generator{},
 }
 
 func Generate(schema *jsonschema.Schema, opts generators.GlobalOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
@@ -0,0 +1,629 @@
+package csharp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in C#.
+// All default types are supported by System.Text.Json without custom converters.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "byte[]"}, // Base64 encoded by System.Text.Json
+	ir.IRFormatDateTime: {Type: "DateTimeOffset", Import: "System"},
+	ir.IRFormatDate:     {Type: "DateOnly", Import: "System"},
+	ir.IRFormatUUID:     {Type: "Guid", Import: "System"},
+	ir.IRFormatEmail:    {Type: "string"},
+	ir.IRFormatURI:      {Type: "string"},
+}
+
+// valueTypes lists the C# value types that may appear in generated code.
+// Optional value types always need a `?` suffix, whereas reference types only
+// get one when nullable reference types are enabled.
+var valueTypes = map[string]bool{
+	"long": true, "int": true, "short": true, "double": true, "float": true,
+	"decimal": true, "bool": true, "DateTimeOffset": true, "DateTime": true,
+	"DateOnly": true, "TimeOnly": true, "TimeSpan": true, "Guid": true,
+	"JsonElement": true,
+}
+
+// config holds C#-specific generator configuration
+type config struct {
+	namespace              string
+	nullableReferenceTypes bool
+}
+
+// Option is a C#-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "csharp" }
+
+// WithNamespace sets the C# namespace for generated code
+func WithNamespace(namespace string) Option {
+	return Option{apply: func(c *config) {
+		c.namespace = namespace
+	}}
+}
+
+// WithNullableReferenceTypes controls whether the generated file enables
+// nullable reference types and annotates optional reference types with `?`.
+// Enabled by default.
+func WithNullableReferenceTypes(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.nullableReferenceTypes = enabled
+	}}
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		namespace:              "Generated",
+		nullableReferenceTypes: true,
+	}
+	for _, opt := range genOpts {
+		if csOpt, ok := opt.(Option); ok {
+			csOpt.apply(cfg)
+		}
+	}
+
+	formatMappings := g.getFormatMappings(opts)
+	typeIndex := buildTypeIndex(data.Types)
+	r := &resolver{
+		formatMappings:         formatMappings,
+		typeIndex:              typeIndex,
+		nullableReferenceTypes: cfg.nullableReferenceTypes,
+	}
+
+	funcs := template.FuncMap{
+		"properties":    r.properties,
+		"variantFields": variantFields,
+		"comment":       formatComment,
+		"fieldComment":  formatFieldComment,
+		"isIntEnum":     isIntEnum,
+		"enumMembers":   enumMembers,
+		"str":           csharpString,
+	}
+
+	tmpl, err := template.New("csharp").Funcs(funcs).Parse(csharpTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tplData := r.prepareTemplateData(cfg, data)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: "Models.cs",
+		Content:  buf.Bytes(),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "    ")
+}
+
+// formatCommentWithIndent renders a description as an XML documentation
+// summary.
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	var result []string
+	result = append(result, indent+"/// <summary>")
+	for _, line := range strings.Split(description, "\n") {
+		result = append(result, strings.TrimRight(indent+"/// "+escapeXML(line), " "))
+	}
+	result = append(result, indent+"/// </summary>")
+	return strings.Join(result, "\n")
+}
+
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// isIntEnum returns true if the enum has an integer type
+func isIntEnum(t ir.IRType) bool {
+	return t.EnumType == ir.IRBuiltinInt
+}
+
+type enumMember struct {
+	Name  string
+	Value ir.IREnumValue
+}
+
+// enumMembers returns the non-null values of an enum paired with unique C#
+// member names.
+func enumMembers(t ir.IRType) []enumMember {
+	seen := make(map[string]int)
+	var members []enumMember
+	for _, v := range t.EnumValues {
+		if v.IsNull {
+			continue
+		}
+		name := toEnumMemberName(v)
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+		members = append(members, enumMember{Name: name, Value: v})
+	}
+	return members
+}
+
+// toEnumMemberName converts an enum value to a PascalCase C# member name
+func toEnumMemberName(v ir.IREnumValue) string {
+	if v.IntValue != nil {
+		return "Value" + strings.ReplaceAll(v.StringValue, "-", "Neg")
+	}
+	name := toPascal(v.StringValue)
+	if name == "" {
+		return "Empty"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "Value" + name
+	}
+	return name
+}
+
+// toPascal converts a word sequence to .NET-style PascalCase, where acronyms
+// are capitalised like ordinary words (e.g. "http_status" becomes HttpStatus).
+func toPascal(s string) string {
+	var b strings.Builder
+	for _, w := range casing.SplitWords(s) {
+		var word []rune
+		for _, r := range w {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				word = append(word, unicode.ToLower(r))
+			}
+		}
+		if len(word) == 0 {
+			continue
+		}
+		word[0] = unicode.ToUpper(word[0])
+		b.WriteString(string(word))
+	}
+	return b.String()
+}
+
+// propertyName resolves the C# property name for a field.
+// Uses x-csharp-name extension if present, otherwise PascalCase derived from
+// the JSON name. A property cannot share the name of its enclosing type, so
+// such properties get a "Value" suffix.
+func propertyName(field ir.IRField, owner string) string {
+	if name, ok := field.Extensions["x-csharp-name"]; ok {
+		return name
+	}
+	name := field.Name
+	if isCamelIdentifier(field.JSONName) {
+		name = strings.ToUpper(field.JSONName[:1]) + field.JSONName[1:]
+	} else if pascal := toPascal(field.JSONName); pascal != "" && !unicode.IsDigit(rune(pascal[0])) {
+		name = pascal
+	}
+	if name == owner {
+		name += "Value"
+	}
+	return name
+}
+
+// isCamelIdentifier reports whether s is already a lowerCamelCase identifier,
+// in which case only its first letter needs capitalising.
+func isCamelIdentifier(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// variantFields returns the fields of a union variant without the
+// discriminator, which System.Text.Json writes itself.
+func variantFields(fields []ir.IRField, discriminatorJSON string) []ir.IRField {
+	var result []ir.IRField
+	for _, f := range fields {
+		if f.JSONName != discriminatorJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+// resolver maps IR type references to C# types. C# has no namespace-level
+// type aliases, so references to alias and non-discriminated union types are
+// resolved to their underlying type.
+type resolver struct {
+	formatMappings         map[ir.IRFormat]generators.FormatTypeMapping
+	typeIndex              map[string]ir.IRType
+	nullableReferenceTypes bool
+}
+
+// csType returns the C# type for a reference, without nullability.
+func (r *resolver) csType(ref *ir.IRTypeRef) string {
+	return r.csTypeVisited(ref, make(map[string]bool))
+}
+
+func (r *resolver) csTypeVisited(ref *ir.IRTypeRef, visited map[string]bool) string {
+	if mapping, ok := r.formatMappings[ref.Format]; ok {
+		return mapping.Type
+	}
+
+	if ref.Builtin != ir.IRBuiltinNone {
+		switch ref.Builtin {
+		case ir.IRBuiltinString:
+			return "string"
+		case ir.IRBuiltinInt:
+			return "long"
+		case ir.IRBuiltinFloat:
+			return "double"
+		case ir.IRBuiltinBool:
+			return "bool"
+		}
+		return "JsonElement"
+	}
+	if ref.Array != nil {
+		return "List<" + r.csTypeVisited(ref.Array, visited) + ">"
+	}
+	if ref.Map != nil {
+		return "Dictionary<string, " + r.csTypeVisited(ref.Map, visited) + ">"
+	}
+	if ref.Name != "" {
+		t, ok := r.typeIndex[ref.Name]
+		if !ok {
+			return ref.Name
+		}
+		switch t.Kind {
+		case ir.IRKindAlias:
+			if t.Element == nil || visited[t.Name] {
+				return "JsonElement"
+			}
+			visited[t.Name] = true
+			return r.csTypeVisited(t.Element, visited)
+		case ir.IRKindUnion:
+			return "JsonElement"
+		}
+		return ref.Name
+	}
+	return "JsonElement"
+}
+
+// isValueType reports whether a resolved C# type is a value type.
+func (r *resolver) isValueType(csType string) bool {
+	if valueTypes[csType] {
+		return true
+	}
+	t, ok := r.typeIndex[csType]
+	return ok && t.Kind == ir.IRKindEnum
+}
+
+// nullableType appends `?` to a type where the language allows it to express
+// nullability.
+func (r *resolver) nullableType(csType string) string {
+	if r.isValueType(csType) || r.nullableReferenceTypes {
+		return csType + "?"
+	}
+	return csType
+}
+
+type property struct {
+	Description string
+	Lines       []string
+}
+
+// properties renders the attribute and declaration lines for each field of a
+// record. Required fields use the `required` modifier so System.Text.Json
+// rejects payloads without them, and optional fields are omitted from the
+// output when null.
+func (r *resolver) properties(fields []ir.IRField, owner string) []property {
+	var result []property
+	for _, field := range fields {
+		csType := r.csType(&field.Type)
+		name := propertyName(field, owner)
+
+		lines := []string{fmt.Sprintf("[JsonPropertyName(%s)]", csharpString(field.JSONName))}
+		var decl string
+		switch literal := r.literal(field, csType); {
+		case literal != "":
+			decl = fmt.Sprintf("public %s %s { get; init; } = %s;", csType, name, literal)
+		case field.Required && field.Type.Nullable:
+			decl = fmt.Sprintf("public required %s %s { get; init; }", r.nullableType(csType), name)
+		case field.Required:
+			decl = fmt.Sprintf("public required %s %s { get; init; }", csType, name)
+		default:
+			lines = append(lines, "[JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]")
+			decl = fmt.Sprintf("public %s %s { get; init; }", r.nullableType(csType), name)
+		}
+		result = append(result, property{
+			Description: field.Description,
+			Lines:       append(lines, decl),
+		})
+	}
+	return result
+}
+
+// literal renders a field's default value as a C# literal, or an empty string
+// if the default cannot be represented.
+func (r *resolver) literal(field ir.IRField, csType string) string {
+	if field.Default == nil {
+		return ""
+	}
+	raw := field.Default.RawValue
+
+	// Enum defaults reference the enum member rather than the raw value
+	if t, ok := r.typeIndex[csType]; ok && t.Kind == ir.IRKindEnum {
+		for _, m := range enumMembers(t) {
+			if m.Value.IntValue != nil && raw == m.Value.StringValue {
+				return t.Name + "." + m.Name
+			}
+			if m.Value.IntValue == nil && raw == strconv.Quote(m.Value.StringValue) {
+				return t.Name + "." + m.Name
+			}
+		}
+		return ""
+	}
+
+	switch {
+	case field.Default.Builtin == ir.IRBuiltinInt && csType == "long":
+		return raw
+	case field.Default.Builtin == ir.IRBuiltinFloat && csType == "double":
+		if !strings.ContainsAny(raw, ".eE") {
+			return raw + ".0"
+		}
+		return raw
+	case field.Default.Builtin == ir.IRBuiltinBool && csType == "bool":
+		return raw
+	case field.Default.Builtin == ir.IRBuiltinString && csType == "string":
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return ""
+		}
+		return csharpString(s)
+	}
+	return ""
+}
+
+// csharpString renders s as a regular C# string literal.
+func csharpString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+type templateData struct {
+	Nullable  bool
+	Namespace string
+	Usings    []string
+	Types     []ir.IRType
+}
+
+func (r *resolver) prepareTemplateData(cfg *config, data *ir.IR) templateData {
+	usingSet := map[string]bool{"System.Text.Json.Serialization": true}
+
+	var types []ir.IRType
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindAlias, ir.IRKindUnion:
+			// Resolved inline wherever they are referenced
+			continue
+		case ir.IRKindDiscriminatedUnion:
+			if t.Union != nil {
+				for _, v := range t.Union.Variants {
+					r.collectUsingsFromFields(v.Type.Fields, usingSet)
+				}
+			}
+		case ir.IRKindStruct:
+			r.collectUsingsFromFields(t.Fields, usingSet)
+		}
+		types = append(types, t)
+	}
+
+	var usings []string
+	for u := range usingSet {
+		usings = append(usings, u)
+	}
+	sort.Strings(usings)
+
+	return templateData{
+		Nullable:  cfg.nullableReferenceTypes,
+		Namespace: cfg.namespace,
+		Usings:    usings,
+		Types:     types,
+	}
+}
+
+func (r *resolver) collectUsingsFromFields(fields []ir.IRField, usingSet map[string]bool) {
+	for _, field := range fields {
+		r.collectUsingsFromRef(&field.Type, usingSet, make(map[string]bool))
+	}
+}
+
+func (r *resolver) collectUsingsFromRef(ref *ir.IRTypeRef, usingSet map[string]bool, visited map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if mapping, ok := r.formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			usingSet[mapping.Import] = true
+		}
+		return
+	}
+	if ref.Array != nil || ref.Map != nil {
+		usingSet["System.Collections.Generic"] = true
+		r.collectUsingsFromRef(ref.Array, usingSet, visited)
+		r.collectUsingsFromRef(ref.Map, usingSet, visited)
+		return
+	}
+	if ref.Name != "" {
+		t, ok := r.typeIndex[ref.Name]
+		if !ok || visited[ref.Name] {
+			return
+		}
+		visited[ref.Name] = true
+		switch {
+		case t.Kind == ir.IRKindAlias && t.Element != nil:
+			r.collectUsingsFromRef(t.Element, usingSet, visited)
+		case t.Kind == ir.IRKindAlias || t.Kind == ir.IRKindUnion:
+			usingSet["System.Text.Json"] = true
+		}
+		return
+	}
+	if ref.Builtin == ir.IRBuiltinAny || ref.Builtin == ir.IRBuiltinNone {
+		usingSet["System.Text.Json"] = true
+	}
+}
+
+const csharpTemplate = `{{if .Nullable}}#nullable enable
+
+{{end}}
+{{- range .Usings}}using {{.}};
+{{end}}
+namespace {{.Namespace}};
+{{- range .Types}}
+{{- if eq .Kind "struct"}}
+{{template "record" .}}
+{{- else if eq .Kind "enum"}}
+{{template "enum" .}}
+{{- else if eq .Kind "discriminated_union"}}
+{{template "union" .}}
+{{- end}}
+{{- end}}
+
+{{- define "record"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if .Fields}}
+public sealed record {{.Name}}
+{
+{{- template "properties" (properties .Fields .Name)}}
+}
+{{- else}}
+public sealed record {{.Name}};
+{{- end}}
+{{- end}}
+
+{{- define "properties"}}
+{{- range $i, $p := .}}
+{{- if $i}}
+{{end}}
+{{- if $p.Description}}
+{{fieldComment $p.Description}}
+{{- end}}
+{{- range $p.Lines}}
+    {{.}}
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if isIntEnum .}}
+public enum {{.Name}} : long
+{
+{{- range enumMembers .}}
+    {{.Name}} = {{.Value.IntValue}},
+{{- end}}
+}
+{{- else}}
+[JsonConverter(typeof(JsonStringEnumConverter<{{.Name}}>))]
+public enum {{.Name}}
+{
+{{- range enumMembers .}}
+    [JsonStringEnumMemberName({{str .Value.StringValue}})]
+    {{.Name}},
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "union"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+[JsonPolymorphic(TypeDiscriminatorPropertyName = {{str .Union.DiscriminatorJSON}})]
+{{- range .Union.Variants}}
+[JsonDerivedType(typeof({{.Name}}), {{str .ConstValue}})]
+{{- end}}
+public abstract record {{.Name}};
+{{- range .Union.Variants}}
+{{if .Type.Description}}
+{{comment .Type.Description}}
+{{- end}}
+{{- $fields := variantFields .Type.Fields $.Union.DiscriminatorJSON}}
+{{- if $fields}}
+public sealed record {{.Name}} : {{$.Name}}
+{
+{{- template "properties" (properties $fields .Name)}}
+}
+{{- else}}
+public sealed record {{.Name}} : {{$.Name}};
+{{- end}}
+{{- end}}
+{{- end}}
+`
@@ -0,0 +1,635 @@
+package dart
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// dartReservedWords contains Dart reserved words, which cannot be used as
+// identifiers at all.
+var dartReservedWords = map[string]bool{
+	"assert": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "default": true, "do": true, "else": true,
+	"enum": true, "extends": true, "false": true, "final": true, "finally": true,
+	"for": true, "if": true, "in": true, "is": true, "new": true, "null": true,
+	"rethrow": true, "return": true, "super": true, "switch": true, "this": true,
+	"throw": true, "true": true, "try": true, "var": true, "void": true,
+	"while": true, "with": true,
+}
+
+// enumReservedNames contains members every Dart enum already has.
+var enumReservedNames = map[string]bool{
+	"index": true, "name": true, "values": true,
+}
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in Dart.
+// Only types json_serializable converts out of the box are used.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "String"}, // Base64 encoded
+	ir.IRFormatDateTime: {Type: "DateTime"},
+	ir.IRFormatDate:     {Type: "String"},
+	ir.IRFormatUUID:     {Type: "String"},
+	ir.IRFormatEmail:    {Type: "String"},
+	ir.IRFormatURI:      {Type: "Uri"},
+}
+
+// config holds Dart-specific generator configuration
+type config struct {
+	// Output filename (default: "models.dart")
+	filename string
+}
+
+// Option is a Dart-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "dart" }
+
+// WithFilename sets the output filename (default: "models.dart"). The
+// generated part directive refers to the matching .g.dart file.
+func WithFilename(name string) Option {
+	return Option{apply: func(c *config) {
+		c.filename = name
+	}}
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		filename: "models.dart",
+	}
+	for _, opt := range genOpts {
+		if dartOpt, ok := opt.(Option); ok {
+			dartOpt.apply(cfg)
+		}
+	}
+
+	formatMappings := g.getFormatMappings(opts)
+	typeIndex := buildTypeIndex(data.Types)
+	dartType := makeDartTypeFunc(formatMappings)
+
+	funcs := template.FuncMap{
+		"dartType":      dartType,
+		"param":         makeParamFunc(typeIndex),
+		"jsonKey":       jsonKey,
+		"variantFields": variantFields,
+		"fieldName":     safeFieldName,
+		"str":           dartString,
+		"comment":       formatComment,
+		"fieldComment":  formatFieldComment,
+		"enumMembers":   enumMembers,
+		"enumValue":     enumValue,
+	}
+
+	tmpl, err := template.New("dart").Funcs(funcs).Parse(dartTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tplData := prepareTemplateData(cfg.filename, data, formatMappings)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: cfg.filename,
+		Content:  buf.Bytes(),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "  ")
+}
+
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	lines := strings.Split(description, "\n")
+	var result []string
+	for _, line := range lines {
+		if line == "" {
+			result = append(result, indent+"///")
+		} else {
+			result = append(result, indent+"/// "+line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// toCamel converts a word sequence to lowerCamelCase following Effective
+// Dart, where acronyms are capitalised like ordinary words (e.g. "user_id"
+// becomes userId and "http_status" becomes httpStatus).
+func toCamel(s string) string {
+	var b strings.Builder
+	for _, w := range casing.SplitWords(s) {
+		var word []rune
+		for _, r := range w {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				word = append(word, unicode.ToLower(r))
+			}
+		}
+		if len(word) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			word[0] = unicode.ToUpper(word[0])
+		}
+		b.WriteString(string(word))
+	}
+	return b.String()
+}
+
+// isCamelIdentifier reports whether s is already a lowerCamelCase identifier,
+// in which case it is used as the field name unchanged.
+func isCamelIdentifier(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// safeIdentifier makes name usable as a Dart identifier. Reserved words
+// cannot be escaped in Dart, so they get a trailing underscore.
+func safeIdentifier(name string, reserved map[string]bool) string {
+	if name == "" {
+		return "value"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "value" + name
+	}
+	if dartReservedWords[name] || reserved[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// safeFieldName resolves the Dart field name for a field.
+// Uses x-dart-name extension if present, otherwise the JSON name when it is
+// already lowerCamelCase, falling back to a camelCase conversion.
+func safeFieldName(field ir.IRField) string {
+	if name, ok := field.Extensions["x-dart-name"]; ok {
+		return name
+	}
+	name := field.JSONName
+	if !isCamelIdentifier(name) {
+		name = toCamel(field.JSONName)
+	}
+	return safeIdentifier(name, nil)
+}
+
+// jsonKey renders the @JsonKey annotation for a field, or an empty string if
+// the defaults suffice. Optional fields are left out of the JSON when null.
+func jsonKey(field classField) string {
+	var args []string
+	if safeFieldName(field.IRField) != field.JSONName {
+		args = append(args, "name: "+dartString(field.JSONName))
+	}
+	if field.Nullable {
+		args = append(args, "includeIfNull: false")
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return "@JsonKey(" + strings.Join(args, ", ") + ")"
+}
+
+// dartString renders s as a single-quoted Dart string literal.
+func dartString(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '$':
+			b.WriteString(`\$`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+type enumMember struct {
+	Name  string
+	Value ir.IREnumValue
+}
+
+// enumMembers returns the non-null values of an enum paired with unique Dart
+// member names.
+func enumMembers(t ir.IRType) []enumMember {
+	seen := make(map[string]int)
+	var members []enumMember
+	for _, v := range t.EnumValues {
+		if v.IsNull {
+			continue
+		}
+		name := toEnumMemberName(v)
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+		members = append(members, enumMember{Name: name, Value: v})
+	}
+	return members
+}
+
+// toEnumMemberName converts an enum value to a lowerCamelCase Dart member name
+func toEnumMemberName(v ir.IREnumValue) string {
+	if v.IntValue != nil {
+		return "value" + strings.ReplaceAll(v.StringValue, "-", "Neg")
+	}
+	name := toCamel(v.StringValue)
+	if name == "" {
+		return "empty"
+	}
+	return safeIdentifier(name, enumReservedNames)
+}
+
+// enumValue renders the @JsonValue argument for an enum member
+func enumValue(m enumMember) string {
+	if m.Value.IntValue != nil {
+		return strconv.Itoa(*m.Value.IntValue)
+	}
+	return dartString(m.Value.StringValue)
+}
+
+// variantFields returns the fields of a union variant without the
+// discriminator, which the variant's toJson adds itself.
+func variantFields(fields []ir.IRField, discriminatorJSON string) []ir.IRField {
+	var result []ir.IRField
+	for _, f := range fields {
+		if f.JSONName != discriminatorJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+func makeDartTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping) func(*ir.IRTypeRef, bool) string {
+	var dartType func(*ir.IRTypeRef) string
+	dartType = func(ref *ir.IRTypeRef) string {
+		if mapping, ok := formatMappings[ref.Format]; ok {
+			return mapping.Type
+		}
+
+		if ref.Builtin != ir.IRBuiltinNone {
+			switch ref.Builtin {
+			case ir.IRBuiltinString:
+				return "String"
+			case ir.IRBuiltinInt:
+				return "int"
+			case ir.IRBuiltinFloat:
+				return "double"
+			case ir.IRBuiltinBool:
+				return "bool"
+			}
+			return "Object?"
+		}
+		if ref.Array != nil {
+			return "List<" + dartType(ref.Array) + ">"
+		}
+		if ref.Map != nil {
+			return "Map<String, " + dartType(ref.Map) + ">"
+		}
+		if ref.Name != "" {
+			return ref.Name
+		}
+		return "Object?"
+	}
+
+	return func(ref *ir.IRTypeRef, nullable bool) string {
+		baseType := dartType(ref)
+		if (nullable || ref.Nullable) && !strings.HasSuffix(baseType, "?") {
+			return baseType + "?"
+		}
+		return baseType
+	}
+}
+
+// makeParamFunc returns a template function that renders a named constructor
+// parameter. Fields with a schema default use it, required fields are marked
+// required, and other optional fields default to null.
+func makeParamFunc(typeIndex map[string]ir.IRType) func(ir.IRField) string {
+	return func(field ir.IRField) string {
+		name := "this." + safeFieldName(field)
+		if literal := dartLiteral(field, typeIndex); literal != "" {
+			return name + " = " + literal
+		}
+		if field.Required {
+			return "required " + name
+		}
+		return name
+	}
+}
+
+// fieldNullable returns whether the declared type of a field is nullable. Fields
+// with a schema default are never null.
+func fieldNullable(field ir.IRField, typeIndex map[string]ir.IRType) bool {
+	return !field.Required && dartLiteral(field, typeIndex) == ""
+}
+
+// dartLiteral renders a field's default value as a Dart constant, or an empty
+// string if the default cannot be represented.
+func dartLiteral(field ir.IRField, typeIndex map[string]ir.IRType) string {
+	if field.Default == nil {
+		return ""
+	}
+	raw := field.Default.RawValue
+
+	// Enum defaults reference the enum member rather than the raw value
+	if t, ok := typeIndex[field.Type.Name]; ok && t.Kind == ir.IRKindEnum {
+		for _, m := range enumMembers(t) {
+			if m.Value.IntValue != nil && raw == m.Value.StringValue {
+				return t.Name + "." + m.Name
+			}
+			if m.Value.IntValue == nil && raw == strconv.Quote(m.Value.StringValue) {
+				return t.Name + "." + m.Name
+			}
+		}
+		return ""
+	}
+	if field.Type.Name != "" || field.Type.Array != nil || field.Type.Map != nil || field.Type.Format != ir.IRFormatNone {
+		return ""
+	}
+
+	switch field.Default.Builtin {
+	case ir.IRBuiltinInt, ir.IRBuiltinBool:
+		return raw
+	case ir.IRBuiltinFloat:
+		if field.Type.Builtin == ir.IRBuiltinFloat && !strings.ContainsAny(raw, ".eE") {
+			return raw + ".0"
+		}
+		return raw
+	case ir.IRBuiltinString:
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return ""
+		}
+		return dartString(s)
+	}
+	return ""
+}
+
+// classData is the data for the class template, shared by plain classes and
+// union variants.
+type classData struct {
+	Name        string
+	Description string
+	Fields      []classField
+	// Base and ConstValue are set for discriminated union variants
+	Base              string
+	DiscriminatorJSON string
+	ConstValue        string
+}
+
+type classField struct {
+	ir.IRField
+	Nullable bool
+}
+
+type templateData struct {
+	Imports []string
+	Part    string
+	Types   []ir.IRType
+	Classes map[string]classData
+}
+
+func prepareTemplateData(filename string, data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) templateData {
+	typeIndex := buildTypeIndex(data.Types)
+	importSet := map[string]bool{"package:json_annotation/json_annotation.dart": true}
+
+	newClass := func(name, description string, fields []ir.IRField) classData {
+		c := classData{Name: name, Description: description}
+		for _, f := range fields {
+			c.Fields = append(c.Fields, classField{IRField: f, Nullable: fieldNullable(f, typeIndex)})
+			collectImportsFromRef(&f.Type, formatMappings, importSet)
+		}
+		return c
+	}
+
+	classes := make(map[string]classData)
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindStruct:
+			classes[t.Name] = newClass(t.Name, t.Description, t.Fields)
+		case ir.IRKindAlias:
+			collectImportsFromRef(t.Element, formatMappings, importSet)
+		case ir.IRKindDiscriminatedUnion:
+			if t.Union == nil {
+				continue
+			}
+			for _, v := range t.Union.Variants {
+				c := newClass(v.Name, v.Type.Description, variantFields(v.Type.Fields, t.Union.DiscriminatorJSON))
+				c.Base = t.Name
+				c.DiscriminatorJSON = t.Union.DiscriminatorJSON
+				c.ConstValue = v.ConstValue
+				classes[v.Name] = c
+			}
+		}
+	}
+
+	var imports []string
+	for imp := range importSet {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	return templateData{
+		Imports: imports,
+		Part:    strings.TrimSuffix(filename, ".dart") + ".g.dart",
+		Types:   data.Types,
+		Classes: classes,
+	}
+}
+
+func collectImportsFromRef(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if mapping, ok := formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			importSet[mapping.Import] = true
+		}
+		return
+	}
+	collectImportsFromRef(ref.Array, formatMappings, importSet)
+	collectImportsFromRef(ref.Map, formatMappings, importSet)
+}
+
+const dartTemplate = `
+{{- range .Imports}}import '{{.}}';
+{{end}}
+part '{{.Part}}';
+{{- $classes := .Classes}}
+{{- range .Types}}
+{{- if eq .Kind "struct"}}
+{{template "class" (index $classes .Name)}}
+{{- else if eq .Kind "alias"}}
+{{template "alias" .}}
+{{- else if eq .Kind "enum"}}
+{{template "enum" .}}
+{{- else if eq .Kind "discriminated_union"}}
+{{template "union" .}}
+{{- range .Union.Variants}}
+{{template "class" (index $classes .Name)}}
+{{- end}}
+{{- else if eq .Kind "union"}}
+{{template "simpleunion" .}}
+{{- end}}
+{{- end}}
+
+{{- define "class"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+@JsonSerializable(explicitToJson: true)
+class {{.Name}}{{if .Base}} extends {{.Base}}{{end}} {
+{{- if .Fields}}
+  const {{.Name}}({
+{{- range .Fields}}
+    {{param .IRField}},
+{{- end}}
+  });
+{{- else}}
+  const {{.Name}}();
+{{- end}}
+
+  factory {{.Name}}.fromJson(Map<String, dynamic> json) => _${{.Name}}FromJson(json);
+{{- if .Fields}}
+{{end}}
+{{- range .Fields}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+{{- with jsonKey .}}
+  {{.}}
+{{- end}}
+  final {{dartType .Type .Nullable}} {{fieldName .IRField}};
+{{- end}}
+{{- if .Base}}
+
+  @override
+  Map<String, dynamic> toJson() => {
+        {{str .DiscriminatorJSON}}: {{str .ConstValue}},
+        ..._${{.Name}}ToJson(this),
+      };
+{{- else}}
+
+  Map<String, dynamic> toJson() => _${{.Name}}ToJson(this);
+{{- end}}
+}
+{{- end}}
+
+{{- define "alias"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if .Element}}
+typedef {{.Name}} = {{dartType .Element false}};
+{{- else}}
+typedef {{.Name}} = Object?;
+{{- end}}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+@JsonEnum()
+enum {{.Name}} {
+{{- range enumMembers .}}
+  @JsonValue({{enumValue .}})
+  {{.Name}},
+{{- end}}
+}
+{{- end}}
+
+{{- define "union"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+sealed class {{.Name}} {
+  const {{.Name}}();
+
+  factory {{.Name}}.fromJson(Map<String, dynamic> json) {
+    final discriminator = json[{{str .Union.DiscriminatorJSON}}];
+    switch (discriminator) {
+{{- range .Union.Variants}}
+      case {{str .ConstValue}}:
+        return {{.Name}}.fromJson(json);
+{{- end}}
+      default:
+        throw ArgumentError.value(discriminator, {{str .Union.DiscriminatorJSON}}, 'unknown {{.Name}} type');
+    }
+  }
+
+  Map<String, dynamic> toJson();
+}
+{{- end}}
+
+{{- define "simpleunion"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+typedef {{.Name}} = Object?;
+{{- end}}
+`
@@ -12,6 +12,14 @@ const (
 	LanguageTypeScriptZod Language = "typescript-zod"
 	LanguageJava          Language = "java"
 	LanguagePython        Language = "python"
+	LanguageRust          Language = "rust"
+	LanguageKotlin        Language = "kotlin"
+	LanguageCSharp        Language = "csharp"
+	LanguageSwift         Language = "swift"
+	LanguageDart          Language = "dart"
+	LanguageProtobuf      Language = "protobuf"
+	LanguageGraphQL       Language = "graphql"
+	LanguageOpenAPI       Language = "openapi"
 )
 
 // GeneratedFile represents a single generated output file
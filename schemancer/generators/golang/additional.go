@@ -0,0 +1,88 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// additionalField is the struct field holding the properties a schema doesn't
+// declare
+const additionalField = "AdditionalProperties"
+
+// additionalCode renders the MarshalJSON and UnmarshalJSON methods of a struct
+// allowing additionalProperties, which keep the properties that aren't
+// declared in its AdditionalProperties field. The defaults of the schema are
+// applied after decoding when applyDefaults is set, since the struct can't
+// have a second UnmarshalJSON method for them.
+func additionalCode(typeName string, fields []ir.IRField, valueType string, applyDefaults bool) string {
+	known := make([]string, len(fields))
+	for i, f := range fields {
+		known[i] = strconv.Quote(f.JSONName)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// MarshalJSON adds %s to the declared properties\n", additionalField)
+	fmt.Fprintf(&b, "func (x %s) MarshalJSON() ([]byte, error) {\n", typeName)
+	fmt.Fprintf(&b, "type plain %s\n", typeName)
+	b.WriteString("data, err := json.Marshal(plain(x))\n")
+	fmt.Fprintf(&b, "if err != nil || len(x.%s) == 0 {\nreturn data, err\n}\n", additionalField)
+	fmt.Fprintf(&b, "return marshalAdditional(data, x.%s)\n}\n\n", additionalField)
+
+	b.WriteString("// UnmarshalJSON decodes the properties that aren't declared by the schema\n")
+	fmt.Fprintf(&b, "// into %s.\n", additionalField)
+	fmt.Fprintf(&b, "func (x *%s) UnmarshalJSON(data []byte) error {\n", typeName)
+	fmt.Fprintf(&b, "type plain %s\n", typeName)
+	b.WriteString("if err := json.Unmarshal(data, (*plain)(x)); err != nil {\nreturn err\n}\n")
+	fmt.Fprintf(&b, "additional, err := unmarshalAdditional[%s](data", valueType)
+	for _, k := range known {
+		b.WriteString(", " + k)
+	}
+	b.WriteString(")\nif err != nil {\n")
+	fmt.Fprintf(&b, "return fmt.Errorf(\"%s: %%w\", err)\n}\n", typeName)
+	fmt.Fprintf(&b, "x.%s = additional\n", additionalField)
+	if applyDefaults {
+		b.WriteString("x.ApplyDefaults()\n")
+	}
+	b.WriteString("return nil\n}")
+	return b.String()
+}
+
+// withoutAdditional returns the types with their additional properties left
+// out, dropping the inline types only declared for their values.
+func withoutAdditional(types []ir.IRType) []ir.IRType {
+	drop := make(map[string]bool)
+	for _, t := range types {
+		for _, name := range t.AdditionalTypes {
+			drop[name] = true
+		}
+		if t.Union != nil {
+			for _, v := range t.Union.Variants {
+				for _, name := range v.Type.AdditionalTypes {
+					drop[name] = true
+				}
+			}
+		}
+	}
+
+	result := make([]ir.IRType, 0, len(types))
+	for _, t := range types {
+		if drop[t.Name] {
+			continue
+		}
+		t.Additional, t.AdditionalTypes = nil, nil
+		if t.Union != nil {
+			union := *t.Union
+			union.Variants = append([]ir.IRVariant(nil), union.Variants...)
+			for i := range union.Variants {
+				union.Variants[i].Type.Additional = nil
+				union.Variants[i].Type.AdditionalTypes = nil
+			}
+			t.Union = &union
+		}
+		result = append(result, t)
+	}
+	return result
+}
@@ -0,0 +1,641 @@
+package golang
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// defaultsGen renders the New<Type> constructor and ApplyDefaults method of
+// every struct and union variant with fields that have a default in the
+// schema. Defaults are checked against the type of their field when the code
+// is generated, and rendered as Go literals.
+type defaultsGen struct {
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	optStyle       OptionalStyle
+	packages       *packageSet
+	goType         func(*ir.IRTypeRef, bool) string
+
+	// unmarshal generates an UnmarshalJSON method applying the defaults
+	unmarshal bool
+
+	// types holds every type by name
+	types map[string]ir.IRType
+
+	// imports holds the packages used by the type being rendered
+	imports map[string]bool
+}
+
+func newDefaultsGen(types []ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, optStyle OptionalStyle, packages *packageSet, goType func(*ir.IRTypeRef, bool) string, unmarshal bool) *defaultsGen {
+	g := &defaultsGen{
+		formatMappings: formatMappings,
+		optStyle:       optStyle,
+		packages:       packages,
+		goType:         goType,
+		unmarshal:      unmarshal,
+		types:          make(map[string]ir.IRType),
+	}
+	for _, t := range types {
+		g.types[t.Name] = t
+	}
+	return g
+}
+
+// render returns the defaults code of a type, and the imports it needs. A
+// default that doesn't fit the type of its field is an error.
+func (g *defaultsGen) render(t ir.IRType) (string, []string, error) {
+	g.imports = make(map[string]bool)
+
+	var blocks []string
+	switch t.Kind {
+	case ir.IRKindStruct:
+		b, err := g.structCode(t.Name, t.Fields, t.Additional != nil)
+		if err != nil {
+			return "", nil, err
+		}
+		blocks = append(blocks, b)
+	case ir.IRKindDiscriminatedUnion:
+		for _, v := range t.Union.Variants {
+			b, err := g.structCode(v.Name, v.Type.Fields, v.Type.Additional != nil)
+			if err != nil {
+				return "", nil, err
+			}
+			blocks = append(blocks, b)
+		}
+	}
+
+	var code []string
+	for _, b := range blocks {
+		if b != "" {
+			code = append(code, b)
+		}
+	}
+	if len(code) == 0 {
+		return "", nil, nil
+	}
+	if g.unmarshal {
+		g.imports["encoding/json"] = true
+	}
+	var imports []string
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	return strings.Join(code, "\n\n"), imports, nil
+}
+
+// onUnmarshal reports whether a struct's defaults are applied when decoding
+// JSON
+func (g *defaultsGen) onUnmarshal(typeName string, fields []ir.IRField) (bool, error) {
+	if !g.unmarshal {
+		return false, nil
+	}
+	_, optional, err := g.fieldCode(typeName, fields)
+	return len(optional) > 0, err
+}
+
+// fieldCode renders the struct literal fields setting the defaults of
+// required fields, and the statements applying the defaults of optional ones.
+func (g *defaultsGen) fieldCode(typeName string, fields []ir.IRField) (required, optional []string, err error) {
+	for i := range fields {
+		field := fields[i]
+		if field.Default == nil {
+			continue
+		}
+		value, err := g.literal(&field.Type, field.Default.RawValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("golang: default of %s.%s: %w", typeName, field.JSONName, err)
+		}
+		if field.Required {
+			if g.wrapped(&field.Type, true) {
+				value = g.wrap(value)
+			}
+			required = append(required, fmt.Sprintf("%s: %s,", field.Name, value))
+		} else {
+			optional = append(optional, g.applyCode("x."+field.Name, &field.Type, value))
+		}
+	}
+	return required, optional, nil
+}
+
+// structCode renders the defaults of a struct. Structs allowing additional
+// properties apply the defaults in their own UnmarshalJSON.
+func (g *defaultsGen) structCode(typeName string, fields []ir.IRField, additional bool) (string, error) {
+	required, optional, err := g.fieldCode(typeName, fields)
+	if err != nil {
+		return "", err
+	}
+	if len(required) == 0 && len(optional) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// New%s returns %s %s with the defaults of its schema\n", typeName, article(typeName), typeName)
+	fmt.Fprintf(&b, "func New%s() %s {\n", typeName, typeName)
+	if len(required) > 0 {
+		fmt.Fprintf(&b, "x := %s{\n%s\n}\n", typeName, strings.Join(required, "\n"))
+	} else {
+		fmt.Fprintf(&b, "x := %s{}\n", typeName)
+	}
+	if len(optional) > 0 {
+		b.WriteString("x.ApplyDefaults()\n")
+	}
+	b.WriteString("return x\n}")
+
+	if len(optional) == 0 {
+		return b.String(), nil
+	}
+
+	b.WriteString("\n\n// ApplyDefaults sets the optional fields that are unset to the defaults of\n// the schema.\n")
+	fmt.Fprintf(&b, "func (x *%s) ApplyDefaults() {\n%s\n}", typeName, strings.Join(optional, "\n"))
+
+	if g.unmarshal && !additional {
+		b.WriteString("\n\n// UnmarshalJSON applies the defaults of the schema to the optional fields\n// missing from data.\n")
+		fmt.Fprintf(&b, "func (x *%s) UnmarshalJSON(data []byte) error {\n", typeName)
+		fmt.Fprintf(&b, "type plain %s\n", typeName)
+		b.WriteString("if err := json.Unmarshal(data, (*plain)(x)); err != nil {\nreturn err\n}\n")
+		b.WriteString("x.ApplyDefaults()\nreturn nil\n}")
+	}
+	return b.String(), nil
+}
+
+// applyCode renders the statement setting an optional field to its default
+// when it is unset
+func (g *defaultsGen) applyCode(expr string, ref *ir.IRTypeRef, value string) string {
+	if !g.wrapped(ref, false) {
+		// Slices, maps and any are nil when unset
+		return fmt.Sprintf("if %s == nil {\n%s = %s\n}", expr, expr, value)
+	}
+	var unset string
+	switch g.optStyle {
+	case OptionalStyleOpt:
+		unset = "!" + expr + ".Ok()"
+	case OptionalStyleNullable:
+		// An explicit null is kept
+		unset = expr + ".IsZero()"
+	default:
+		unset = expr + " == nil"
+	}
+	return fmt.Sprintf("if %s {\n%s = %s\n}", unset, expr, g.wrap(value))
+}
+
+// wrap renders a value as the pointer, opt.Optional or Field of an optional
+// field
+func (g *defaultsGen) wrap(value string) string {
+	switch g.optStyle {
+	case OptionalStyleOpt:
+		g.imports["github.com/Southclaws/opt"] = true
+		return "opt.New(" + value + ")"
+	case OptionalStyleNullable:
+		return "NewField(" + value + ")"
+	default:
+		return "ptrTo(" + value + ")"
+	}
+}
+
+// wrapped reports whether goType wraps a value in a pointer, opt.Optional or
+// Field
+func (g *defaultsGen) wrapped(ref *ir.IRTypeRef, required bool) bool {
+	bare := *ref
+	bare.Nullable = false
+	return g.goType(ref, required) != g.goType(&bare, true)
+}
+
+// literal renders a default as a Go expression of the field's type, without
+// the pointer or optional wrapping. The default is decoded and checked against
+// the type, and it is an error when it doesn't fit.
+func (g *defaultsGen) literal(ref *ir.IRTypeRef, raw string) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("invalid JSON %s: %w", raw, err)
+	}
+	return g.value(ref, v)
+}
+
+// value renders a decoded default as a Go expression of a type, without the
+// pointer or optional wrapping
+func (g *defaultsGen) value(ref *ir.IRTypeRef, v any) (string, error) {
+	bare := *ref
+	bare.Nullable = false
+	typ := g.goType(&bare, true)
+
+	if mapping, ok := g.formatMappings[ref.Format]; ok && mapping.Type != "string" {
+		if mapping.Import != "" {
+			g.imports[mapping.Import] = true
+		}
+		return formatLiteral(mapping.Type, ref.Format, v)
+	}
+
+	switch {
+	case ref.Array != nil:
+		items, ok := v.([]any)
+		if !ok {
+			return "", fmt.Errorf("%s isn't a %s", describe(v), typ)
+		}
+		elems := make([]string, len(items))
+		for i, item := range items {
+			elem, err := g.element(ref.Array, true, item)
+			if err != nil {
+				return "", fmt.Errorf("item %d: %w", i, err)
+			}
+			elems[i] = elem
+		}
+		return typ + "{" + strings.Join(elems, ", ") + "}", nil
+
+	case ref.Map != nil:
+		object, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s isn't a %s", describe(v), typ)
+		}
+		return g.mapLiteral(typ, ref.Map, object)
+
+	case ref.Builtin == ir.IRBuiltinString:
+		s, ok := v.(string)
+		if !ok {
+			return "", fmt.Errorf("%s isn't a string", describe(v))
+		}
+		return strconv.Quote(s), nil
+
+	case ref.Builtin == ir.IRBuiltinInt:
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("%s isn't an integer", describe(v))
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s isn't an int64 integer", n)
+		}
+		return strconv.FormatInt(i, 10), nil
+
+	case ref.Builtin == ir.IRBuiltinFloat:
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("%s isn't a number", describe(v))
+		}
+		return floatLiteral(n)
+
+	case ref.Builtin == ir.IRBuiltinBool:
+		b, ok := v.(bool)
+		if !ok {
+			return "", fmt.Errorf("%s isn't a boolean", describe(v))
+		}
+		return strconv.FormatBool(b), nil
+
+	case ref.Name != "":
+		t, ok := g.types[ref.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown type %s", ref.Name)
+		}
+		switch t.Kind {
+		case ir.IRKindEnum:
+			return g.enumLiteral(t, v)
+		case ir.IRKindAlias:
+			if t.Element != nil {
+				return g.value(t.Element, v)
+			}
+			return anyLiteral(v)
+		case ir.IRKindStruct:
+			return g.structLiteral(g.qualify(t.Name), t, v)
+		case ir.IRKindDiscriminatedUnion:
+			return g.unionLiteral(t, v)
+		}
+		return "", fmt.Errorf("defaults of %s, a union without a discriminator, aren't supported", t.Name)
+	}
+
+	return anyLiteral(v)
+}
+
+// element renders a value nested in a default, such as an array item or a
+// struct field, wrapped the way goType wraps it
+func (g *defaultsGen) element(ref *ir.IRTypeRef, required bool, v any) (string, error) {
+	typ := g.goType(ref, required)
+	if v == nil {
+		switch {
+		case !ref.Nullable && typ != "interface{}":
+			return "", fmt.Errorf("null isn't a %s", typ)
+		case strings.HasPrefix(typ, "Field["):
+			return "Null" + typ + "()", nil
+		case strings.HasPrefix(typ, "opt.Optional["):
+			return typ + "{}", nil
+		}
+		return "nil", nil
+	}
+
+	value, err := g.value(ref, v)
+	if err != nil {
+		return "", err
+	}
+	if g.wrapped(ref, required) {
+		value = g.wrap(value)
+	}
+	return value, nil
+}
+
+// mapLiteral renders the entries of an object in key order
+func (g *defaultsGen) mapLiteral(typ string, values *ir.IRTypeRef, object map[string]any) (string, error) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		value, err := g.element(values, true, object[k])
+		if err != nil {
+			return "", fmt.Errorf("property %q: %w", k, err)
+		}
+		entries[i] = strconv.Quote(k) + ": " + value
+	}
+	return typ + "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+// structLiteral renders an object as a composite literal of a struct, in the
+// order of its fields. Properties the struct doesn't declare go to its
+// AdditionalProperties, and are an error when it has none.
+func (g *defaultsGen) structLiteral(typ string, t ir.IRType, v any) (string, error) {
+	object, ok := v.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%s isn't a %s", describe(v), typ)
+	}
+
+	var fields []string
+	known := make(map[string]bool, len(t.Fields))
+	for i := range t.Fields {
+		field := t.Fields[i]
+		known[field.JSONName] = true
+		fv, ok := object[field.JSONName]
+		if !ok {
+			continue
+		}
+		value, err := g.element(&field.Type, field.Required, fv)
+		if err != nil {
+			return "", fmt.Errorf("property %q: %w", field.JSONName, err)
+		}
+		fields = append(fields, field.Name+": "+value)
+	}
+
+	additional := make(map[string]any)
+	for k, value := range object {
+		if !known[k] {
+			additional[k] = value
+		}
+	}
+	if len(additional) > 0 {
+		if t.Additional == nil {
+			keys := make([]string, 0, len(additional))
+			for k := range additional {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return "", fmt.Errorf("property %q isn't a field of %s", keys[0], t.Name)
+		}
+		value, err := g.mapLiteral("map[string]"+g.goType(t.Additional, true), t.Additional, additional)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, additionalField+": "+value)
+	}
+	return typ + "{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// unionLiteral renders an object as a discriminated union holding the
+// variant its discriminator selects
+func (g *defaultsGen) unionLiteral(t ir.IRType, v any) (string, error) {
+	object, ok := v.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%s isn't a %s", describe(v), t.Name)
+	}
+	discriminator, ok := object[t.Union.DiscriminatorJSON].(string)
+	if !ok {
+		return "", fmt.Errorf("the %s discriminator %q is missing", t.Name, t.Union.DiscriminatorJSON)
+	}
+
+	typ := g.qualify(t.Name)
+	prefix := strings.TrimSuffix(typ, t.Name)
+	for _, variant := range t.Union.Variants {
+		if variant.ConstValue != discriminator {
+			continue
+		}
+		value, err := g.structLiteral(prefix+variant.Name, variant.Type, object)
+		if err != nil {
+			return "", err
+		}
+		return typ + "{&" + value + "}", nil
+	}
+	return "", fmt.Errorf("%q isn't a variant of %s", discriminator, t.Name)
+}
+
+// qualify returns the name a type is referenced by, importing its package
+// when it is another one
+func (g *defaultsGen) qualify(name string) string {
+	if pkg := g.packages.of(name); pkg.ImportPath != g.packages.current {
+		g.imports[pkg.ImportPath] = true
+	}
+	return g.packages.qualify(name)
+}
+
+// formatLiteral renders a default of a format mapped to a type of the
+// standard library or uuid as a Go expression, parsing it now so an invalid
+// default is an error rather than failing when the code runs. Defaults of
+// other mapped types can't be rendered.
+func formatLiteral(typ string, format ir.IRFormat, v any) (string, error) {
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s isn't a string of format %s", describe(v), format)
+	}
+
+	switch typ {
+	case "time.Time":
+		layout := time.RFC3339Nano
+		if format == ir.IRFormatDate {
+			layout = time.DateOnly
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't a %s: %w", s, format, err)
+		}
+		return timeLiteral(t), nil
+
+	case "mail.Address":
+		a, err := mail.ParseAddress(s)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't an email address: %w", s, err)
+		}
+		if a.Name == "" {
+			return fmt.Sprintf("mail.Address{Address: %q}", a.Address), nil
+		}
+		return fmt.Sprintf("mail.Address{Name: %q, Address: %q}", a.Name, a.Address), nil
+
+	case "url.URL":
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't a URI: %w", s, err)
+		}
+		return urlLiteral(u), nil
+
+	case "uuid.UUID":
+		if _, err := uuid.Parse(s); err != nil {
+			return "", fmt.Errorf("%q isn't a UUID: %w", s, err)
+		}
+		return fmt.Sprintf("uuid.MustParse(%q)", s), nil
+
+	case "[]byte":
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", fmt.Errorf("%q isn't base64: %w", s, err)
+		}
+		return fmt.Sprintf("[]byte(%q)", b), nil
+	}
+	return "", fmt.Errorf("defaults of format %s, mapped to %s, aren't supported", format, typ)
+}
+
+// timeLiteral renders a time as a time.Date call
+func timeLiteral(t time.Time) string {
+	loc := "time.UTC"
+	if _, offset := t.Zone(); offset != 0 {
+		loc = fmt.Sprintf("time.FixedZone(\"\", %d)", offset)
+	}
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// urlLiteral renders the fields of a URL that are set as a url.URL literal
+func urlLiteral(u *url.URL) string {
+	var fields []string
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %q", name, value))
+		}
+	}
+	add("Scheme", u.Scheme)
+	add("Opaque", u.Opaque)
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			fields = append(fields, fmt.Sprintf("User: url.UserPassword(%q, %q)", u.User.Username(), password))
+		} else {
+			fields = append(fields, fmt.Sprintf("User: url.User(%q)", u.User.Username()))
+		}
+	}
+	add("Host", u.Host)
+	add("Path", u.Path)
+	add("RawPath", u.RawPath)
+	if u.ForceQuery {
+		fields = append(fields, "ForceQuery: true")
+	}
+	add("RawQuery", u.RawQuery)
+	add("Fragment", u.Fragment)
+	add("RawFragment", u.RawFragment)
+	return "url.URL{" + strings.Join(fields, ", ") + "}"
+}
+
+// enumLiteral renders an enum default as the constant of its value
+func (g *defaultsGen) enumLiteral(t ir.IRType, v any) (string, error) {
+	for _, ev := range t.EnumValues {
+		if ev.IsNull {
+			continue
+		}
+		var match bool
+		if ev.IntValue != nil {
+			n, ok := v.(json.Number)
+			i, err := strconv.ParseInt(n.String(), 10, 64)
+			match = ok && err == nil && i == int64(*ev.IntValue)
+		} else {
+			s, ok := v.(string)
+			match = ok && s == ev.StringValue
+		}
+		if match {
+			key := toEnumKey(t.Name, ev)
+			if qualified := g.qualify(t.Name); qualified != t.Name {
+				key = strings.TrimSuffix(qualified, t.Name) + key
+			}
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("%s isn't a value of %s", describe(v), t.Name)
+}
+
+// floatLiteral renders a number as a float64 literal
+func floatLiteral(n json.Number) (string, error) {
+	f, err := strconv.ParseFloat(n.String(), 64)
+	if err != nil {
+		return "", fmt.Errorf("%s isn't a float64 number", n)
+	}
+	// A literal without a fraction would be an int
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s, nil
+}
+
+// anyLiteral renders a default of a field of any type as the value
+// encoding/json would decode it into
+func anyLiteral(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		return strconv.Quote(v), nil
+	case json.Number:
+		return floatLiteral(v)
+	case []any:
+		elems := make([]string, len(v))
+		for i, item := range v {
+			elem, err := anyLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = elem
+		}
+		return "[]interface{}{" + strings.Join(elems, ", ") + "}", nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			value, err := anyLiteral(v[k])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = strconv.Quote(k) + ": " + value
+		}
+		return "map[string]interface{}{" + strings.Join(entries, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unexpected JSON value %v", v)
+}
+
+// describe renders a decoded JSON value for an error message
+func describe(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// article returns the indefinite article for a word
+func article(word string) string {
+	if word != "" && strings.ContainsRune("AEIOU", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -2,7 +2,10 @@ package golang
 
 import (
 	"bytes"
+	"fmt"
 	"go/format"
+	"path"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -29,12 +32,73 @@ const (
 	OptionalStylePointer OptionalStyle = "pointer"
 	// OptionalStyleOpt uses Southclaws/opt library: opt.Optional[string]
 	OptionalStyleOpt OptionalStyle = "opt"
+	// OptionalStyleNullable uses a generated Field type that tells an absent
+	// field apart from an explicit null: Field[string]
+	OptionalStyleNullable OptionalStyle = "nullable"
+)
+
+// FileLayout determines how generated types are split across files
+type FileLayout string
+
+const (
+	// FileLayoutSingle writes every type to <package>.go (default)
+	FileLayoutSingle FileLayout = "single"
+	// FileLayoutSource writes types to one file per schema file, following the
+	// $ref boundaries of the input. Types from the root schema go to
+	// <package>.go and types from address.yaml go to address.go.
+	FileLayoutSource FileLayout = "source"
+	// FileLayoutType writes each top-level type to its own file, named after
+	// the type in snake_case.
+	FileLayoutType FileLayout = "type"
+)
+
+// JSONBackend determines which encoding/json package the generated code is
+// written for
+type JSONBackend string
+
+const (
+	// JSONBackendStd uses encoding/json (default)
+	JSONBackendStd JSONBackend = "std"
+	// JSONBackendV2 uses encoding/json/v2. Optional fields are tagged
+	// omitzero, and discriminated unions implement MarshalJSONTo and
+	// UnmarshalJSONFrom, which decode an object whose first property is the
+	// discriminator straight into its variant. Other objects are buffered and
+	// scanned for the discriminator with the jsontext tokenizer first.
+	JSONBackendV2 JSONBackend = "v2"
 )
 
+// helpersFilename holds code shared by the types when output is split
+const helpersFilename = "helpers.go"
+
+// Package is a separate Go package that the types defined in a schema file
+// are generated into.
+type Package struct {
+	// ImportPath is the import path of the package, such as
+	// "github.com/acme/shared/money"
+	ImportPath string
+
+	// Name is the package name, the last element of ImportPath when empty
+	Name string
+
+	// Dir is the directory the package is written to, relative to the output
+	// directory. No code is generated for the package when empty, since it
+	// is expected to be generated separately.
+	Dir string
+}
+
 // config holds Go-specific generator configuration
 type config struct {
-	packageName   string
-	optionalStyle OptionalStyle
+	packageName     string
+	optionalStyle   OptionalStyle
+	fileLayout      FileLayout
+	packages        map[string]Package
+	validation      bool
+	strictEnums     bool
+	unknownVariants bool
+	defaultsOnJSON  bool
+	additional      bool
+	jsonBackend     JSONBackend
+	structTags      map[string]TagCasing
 }
 
 // Option is a Go-specific generator option
@@ -59,6 +123,86 @@ func WithOptionalStyle(style OptionalStyle) Option {
 	}}
 }
 
+// WithFileLayout sets how generated types are split across files
+func WithFileLayout(layout FileLayout) Option {
+	return Option{apply: func(c *config) {
+		c.fileLayout = layout
+	}}
+}
+
+// WithPackages generates the types defined in other schema files into their
+// own packages, which the dependent package imports. Keys are schema files as
+// referenced from the root schema, such as "common.yaml", or the $id of the
+// file.
+func WithPackages(packages map[string]Package) Option {
+	return Option{apply: func(c *config) {
+		c.packages = packages
+	}}
+}
+
+// WithValidation generates a Validate method for every struct and union,
+// checking the constraints of the schema and reporting every failure with its
+// JSON pointer in a *ValidationError.
+func WithValidation(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.validation = enabled
+	}}
+}
+
+// WithStrictEnums generates an UnmarshalJSON method for every enum, rejecting
+// values that are not one of its values.
+func WithStrictEnums(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.strictEnums = enabled
+	}}
+}
+
+// WithUnknownVariants generates an Unknown<Wrapper> variant for every
+// discriminated union, which UnmarshalJSON decodes discriminator values it
+// doesn't know into instead of failing. The raw JSON is kept, so the value is
+// encoded unchanged by MarshalJSON.
+func WithUnknownVariants(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.unknownVariants = enabled
+	}}
+}
+
+// WithUnmarshalDefaults generates an UnmarshalJSON method for every struct
+// with optional fields that have a default, applying the defaults to the
+// fields missing from the JSON.
+func WithUnmarshalDefaults(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.defaultsOnJSON = enabled
+	}}
+}
+
+// WithAdditionalProperties keeps the properties a schema doesn't declare in
+// an AdditionalProperties map on structs whose schema allows them, with
+// MarshalJSON and UnmarshalJSON methods carrying the map through a round trip.
+// Without it, such properties are dropped when decoding.
+func WithAdditionalProperties(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.additional = enabled
+	}}
+}
+
+// WithJSONBackend sets the encoding/json package the generated code is written
+// for
+func WithJSONBackend(backend JSONBackend) Option {
+	return Option{apply: func(c *config) {
+		c.jsonBackend = backend
+	}}
+}
+
+// WithStructTags adds struct tags besides json to every field, such as yaml or
+// db, holding the JSON property name in the given casing. Fields can set
+// their own tags with the x-go-tag extension.
+func WithStructTags(tags map[string]TagCasing) Option {
+	return Option{apply: func(c *config) {
+		c.structTags = tags
+	}}
+}
+
 type Generator struct{}
 
 func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
@@ -78,6 +222,8 @@ func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genO
 	cfg := &config{
 		packageName:   "generated",
 		optionalStyle: OptionalStylePointer,
+		fileLayout:    FileLayoutSingle,
+		jsonBackend:   JSONBackendStd,
 	}
 	for _, opt := range genOpts {
 		if goOpt, ok := opt.(Option); ok {
@@ -85,8 +231,31 @@ func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genO
 		}
 	}
 
+	switch cfg.jsonBackend {
+	case JSONBackendStd, JSONBackendV2:
+	default:
+		return nil, fmt.Errorf("golang: unknown JSON backend %q, expected std or v2", cfg.jsonBackend)
+	}
+
+	if !cfg.additional {
+		data = &ir.IR{Schema: data.Schema, Types: withoutAdditional(data.Types)}
+	}
+
+	tags, err := newTagGen(cfg.structTags, makeJSONTagFunc(cfg.optionalStyle, cfg.jsonBackend))
+	if err != nil {
+		return nil, err
+	}
+
 	formatMappings := g.getFormatMappings(opts)
 
+	packages, err := assignPackages(cfg, data.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	goType := makeGoTypeFunc(formatMappings, cfg.optionalStyle, packages)
+	sums := newSumTypes(data.Types, formatMappings, goType)
+
 	funcs := template.FuncMap{
 		"pascal":     casing.ToPascalCase,
 		"camel":      casing.ToCamelCase,
@@ -94,13 +263,30 @@ func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genO
 		"kebab":      casing.ToKebabCase,
 		"lower":      strings.ToLower,
 		"upper":      strings.ToUpper,
-		"goType":     makeGoTypeFunc(formatMappings, cfg.optionalStyle),
-		"jsonTag":    jsonTag,
+		"goType":     goType,
+		"structTag":  tags.field,
+		"ignoredTag": tags.ignored,
 		"hasPrefix":  strings.HasPrefix,
 		"trimPrefix": strings.TrimPrefix,
 		"comment":    formatComment,
 		"isIntEnum":  isIntEnum,
 		"toEnumKey":  toEnumKey,
+		"sumType":    sums.of,
+		"strictEnums": func() bool {
+			return cfg.strictEnums
+		},
+		"unknownVariants": func() bool {
+			return cfg.unknownVariants
+		},
+		"jsonV2": func() bool {
+			return cfg.jsonBackend == JSONBackendV2
+		},
+		"rawJSON": func() string {
+			if cfg.jsonBackend == JSONBackendV2 {
+				return "jsontext.Value"
+			}
+			return "json.RawMessage"
+		},
 	}
 
 	tmpl, err := template.New("go").Funcs(funcs).Parse(goTemplate)
@@ -108,26 +294,351 @@ func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genO
 		return nil, err
 	}
 
-	tplData := prepareTemplateData(cfg.packageName, cfg.optionalStyle, data, formatMappings)
+	gen := &generation{
+		strictEnums:    cfg.strictEnums,
+		optStyle:       cfg.optionalStyle,
+		jsonBackend:    cfg.jsonBackend,
+		formatMappings: formatMappings,
+		packages:       packages,
+		goType:         goType,
+		defaults:       newDefaultsGen(data.Types, formatMappings, cfg.optionalStyle, packages, goType, cfg.defaultsOnJSON),
+	}
+	if cfg.validation {
+		gen.validation = newValidationGen(data.Types, formatMappings, cfg.optionalStyle, packages, sums)
+	}
+
+	var files []generators.GeneratedFile
+	for _, pkg := range packages.list {
+		packages.current = pkg.ImportPath
+
+		var types []ir.IRType
+		for _, t := range data.Types {
+			if packages.of(t.Name).ImportPath == pkg.ImportPath {
+				types = append(types, t)
+			}
+		}
+		pkgData := &ir.IR{Schema: data.Schema, Types: types}
+
+		pkgTplData, err := gen.templateData(pkg.Name, pkgData)
+		if err != nil {
+			return nil, err
+		}
+		var helpers bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&helpers, "helpers", pkgTplData); err != nil {
+			return nil, err
+		}
+		helperImports := jsonImports(cfg.jsonBackend, templateHelperImports(pkgTplData, cfg.jsonBackend))
+
+		groups, err := groupFiles(cfg.fileLayout, pkg.Name, types)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups {
+			tplData, err := gen.templateData(pkg.Name, &ir.IR{Schema: data.Schema, Types: group.types})
+			if err != nil {
+				return nil, err
+			}
+			if cfg.fileLayout == FileLayoutSingle {
+				tplData.Helpers = helpers.String()
+				tplData.Imports = mergeImports(tplData.Imports, helperImports)
+			}
+			tplData.Imports = jsonImports(cfg.jsonBackend, tplData.Imports)
+			tplData.Build = buildConstraint(cfg.jsonBackend)
+			file, err := render(tmpl, path.Join(pkg.Dir, group.filename), tplData)
+			if err != nil {
+				return []generators.GeneratedFile{file}, err
+			}
+			files = append(files, file)
+		}
+
+		if cfg.fileLayout != FileLayoutSingle && strings.TrimSpace(helpers.String()) != "" {
+			file, err := render(tmpl, path.Join(pkg.Dir, helpersFilename), templateData{
+				Package: pkg.Name,
+				Build:   buildConstraint(cfg.jsonBackend),
+				Imports: helperImports,
+				Helpers: helpers.String(),
+			})
+			if err != nil {
+				return []generators.GeneratedFile{file}, err
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// packageSet records which Go package each type is generated into. The main
+// package has an empty import path.
+type packageSet struct {
+	// list holds the packages code is generated for, main package first
+	list []Package
+
+	// byType holds the package of every type mapped to another package,
+	// including discriminated union variants
+	byType map[string]Package
+
+	// current is the import path of the package being generated
+	current string
+}
+
+func (p *packageSet) of(typeName string) Package {
+	return p.byType[typeName]
+}
+
+// qualify returns the name a type is referenced by from the current package
+func (p *packageSet) qualify(typeName string) string {
+	pkg := p.of(typeName)
+	if pkg.ImportPath == p.current {
+		return typeName
+	}
+	return pkg.Name + "." + typeName
+}
+
+// assignPackages maps each type to its package, from the schema file or $id
+// it was defined in. Mapped packages can import each other, but not the main
+// package, since that would be an import cycle.
+func assignPackages(cfg *config, types []ir.IRType) (*packageSet, error) {
+	main := Package{Name: cfg.packageName}
+	set := &packageSet{list: []Package{main}, byType: make(map[string]Package)}
+	if len(cfg.packages) == 0 {
+		return set, nil
+	}
+
+	mapped := make(map[string]Package, len(cfg.packages))
+	for key, pkg := range cfg.packages {
+		if pkg.ImportPath == "" {
+			return nil, fmt.Errorf("golang: package for %s has no import path", key)
+		}
+		if pkg.Name == "" {
+			pkg.Name = defaultPackageName(pkg.ImportPath)
+		}
+		if !strings.Contains(key, "://") {
+			key = path.Clean(key)
+		}
+		mapped[key] = pkg
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range types {
+		pkg, ok := mapped[t.Source]
+		if !ok && t.SourceID != "" {
+			pkg, ok = mapped[t.SourceID]
+		}
+		if !ok {
+			continue
+		}
+		set.byType[t.Name] = pkg
+		if t.Union != nil {
+			for _, v := range t.Union.Variants {
+				set.byType[v.Name] = pkg
+			}
+		}
+		if !seen[pkg.ImportPath] && pkg.Dir != "" {
+			seen[pkg.ImportPath] = true
+			set.list = append(set.list, pkg)
+		}
+	}
+	others := set.list[1:]
+	sort.SliceStable(others, func(i, j int) bool { return others[i].ImportPath < others[j].ImportPath })
+
+	typeNames := make(map[string]bool)
+	for _, t := range types {
+		typeNames[t.Name] = true
+	}
+	for _, t := range types {
+		pkg, ok := set.byType[t.Name]
+		if !ok {
+			continue
+		}
+		for _, dep := range typeRefs(t) {
+			if _, mapped := set.byType[dep]; !mapped && typeNames[dep] {
+				return nil, fmt.Errorf("golang: %s in package %s references %s from the main package, map the schema file defining %s to a package too", t.Name, pkg.ImportPath, dep, dep)
+			}
+		}
+	}
+
+	return set, nil
+}
+
+// defaultPackageName derives a package name from the last element of an
+// import path, such as money from "github.com/acme/go-money".
+func defaultPackageName(importPath string) string {
+	name := strings.TrimPrefix(path.Base(importPath), "go-")
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return -1
+	}, name)
+}
+
+// typeRefs returns the names of the types a type references, in a stable
+// order.
+func typeRefs(t ir.IRType) []string {
+	var names []string
+	var visit func(*ir.IRTypeRef)
+	visit = func(ref *ir.IRTypeRef) {
+		if ref == nil {
+			return
+		}
+		if ref.Name != "" {
+			names = append(names, ref.Name)
+		}
+		visit(ref.Array)
+		visit(ref.Map)
+	}
+	for i := range t.Fields {
+		visit(&t.Fields[i].Type)
+	}
+	visit(t.Element)
+	visit(t.Additional)
+	if t.Union != nil {
+		for _, v := range t.Union.Variants {
+			for i := range v.Type.Fields {
+				visit(&v.Type.Fields[i].Type)
+			}
+			visit(v.Type.Additional)
+		}
+	}
+	if t.SimpleUnion != nil {
+		for i := range t.SimpleUnion.Variants {
+			visit(&t.SimpleUnion.Variants[i])
+		}
+	}
+	return names
+}
 
+func render(tmpl *template.Template, filename string, tplData templateData) (generators.GeneratedFile, error) {
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, tplData); err != nil {
-		return nil, err
+		return generators.GeneratedFile{}, err
 	}
 
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
 		// Return unformatted if formatting fails
-		return []generators.GeneratedFile{{
-			Filename: cfg.packageName + ".go",
-			Content:  buf.Bytes(),
-		}}, err
+		return generators.GeneratedFile{Filename: filename, Content: buf.Bytes()}, err
+	}
+
+	return generators.GeneratedFile{Filename: filename, Content: formatted}, nil
+}
+
+type fileGroup struct {
+	filename string
+	types    []ir.IRType
+}
+
+// groupFiles assigns types to output files according to the layout, keeping
+// the order types are declared in.
+func groupFiles(layout FileLayout, packageName string, types []ir.IRType) ([]fileGroup, error) {
+	var keyOf func(ir.IRType) string
+	switch layout {
+	case FileLayoutSingle, "":
+		return []fileGroup{{filename: packageName + ".go", types: types}}, nil
+	case FileLayoutSource:
+		keyOf = func(t ir.IRType) string { return t.Source }
+	case FileLayoutType:
+		keyOf = func(t ir.IRType) string { return t.Name }
+	default:
+		return nil, fmt.Errorf("golang: unknown file layout %q, expected single, source or type", layout)
+	}
+
+	var groups []fileGroup
+	index := make(map[string]int)
+	for _, t := range types {
+		key := keyOf(t)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, fileGroup{})
+		}
+		groups[i].types = append(groups[i].types, t)
+	}
+
+	// Name the files once all groups are known, so the root schema's file
+	// always gets the package name.
+	taken := map[string]bool{helpersFilename: true}
+	for i := range groups {
+		var name string
+		switch {
+		case layout == FileLayoutType:
+			name = casing.ToSnakeCase(groups[i].types[0].Name)
+		case groups[i].types[0].Source == "":
+			name = packageName
+		default:
+			continue
+		}
+		groups[i].filename = uniqueFilename(name, taken)
+	}
+	for i := range groups {
+		if groups[i].filename != "" {
+			continue
+		}
+		source := groups[i].types[0].Source
+		if base := sourceStem(path.Base(source)); !taken[goFilename(base)] {
+			groups[i].filename = uniqueFilename(base, taken)
+		} else {
+			// Schema files sharing a name in different directories are told
+			// apart by their directory.
+			groups[i].filename = uniqueFilename(sourceStem(source), taken)
+		}
+	}
+
+	return groups, nil
+}
+
+// sourceStem converts a schema file path into a file name without extension,
+// such as common_address from "common/address.yaml".
+func sourceStem(source string) string {
+	if i := strings.Index(source, "://"); i >= 0 {
+		source = source[i+3:]
+	}
+	source = strings.TrimSuffix(source, path.Ext(source))
+	source = strings.TrimPrefix(strings.ReplaceAll(source, "../", ""), "./")
+	return casing.ToSnakeCase(strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(source))
+}
+
+// uniqueFilename returns a Go file name for name not already taken
+func uniqueFilename(name string, taken map[string]bool) string {
+	filename := goFilename(name)
+	for n := 2; taken[filename]; n++ {
+		filename = goFilename(fmt.Sprintf("%s_%d", name, n))
+	}
+	taken[filename] = true
+	return filename
+}
+
+// goFilename adds the .go extension, keeping the go tool from reading a name
+// ending in _test or a GOOS/GOARCH suffix as a test file or build constraint.
+func goFilename(name string) string {
+	parts := strings.Split(name, "_")
+	last := parts[len(parts)-1]
+	if len(parts) > 1 && (last == "test" || knownOS[last] || knownArch[last]) {
+		name += "_gen"
 	}
+	return name + ".go"
+}
+
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true,
+	"freebsd": true, "hurd": true, "illumos": true, "ios": true, "js": true,
+	"linux": true, "nacl": true, "netbsd": true, "openbsd": true, "plan9": true,
+	"solaris": true, "wasip1": true, "windows": true, "zos": true,
+}
 
-	return []generators.GeneratedFile{{
-		Filename: cfg.packageName + ".go",
-		Content:  formatted,
-	}}, nil
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true,
+	"arm64": true, "arm64be": true, "loong64": true, "mips": true,
+	"mipsle": true, "mips64": true, "mips64le": true, "mips64p32": true,
+	"mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true,
+	"riscv": true, "riscv64": true, "s390": true, "s390x": true, "sparc": true,
+	"sparc64": true, "wasm": true,
 }
 
 func formatComment(description string) string {
@@ -158,15 +669,189 @@ func toEnumKey(typeName string, v ir.IREnumValue) string {
 }
 
 type templateData struct {
-	Package  string
-	HasUnion bool
-	Imports  []string
-	Types    []ir.IRType
+	Package    string
+	Build      string // Build constraint of the file, if any
+	HasUnion   bool
+	Imports    []string
+	Types      []ir.IRType
+	Helpers    string            // Rendered code shared by all types
+	SumTypes   bool              // Whether there are non-discriminated unions
+	Fields     bool              // Whether the Field type is used
+	Validate   bool              // Whether Validate methods are generated
+	Validation map[string]string // Validate methods by type name
+	Defaults   map[string]string // Default constructors by type name
+	Additional map[string]string // Additional property methods by type name
+	PtrTo      bool              // Whether defaults are set through pointers
+}
+
+// generation holds the state shared by every file generated in one run
+type generation struct {
+	strictEnums    bool
+	optStyle       OptionalStyle
+	jsonBackend    JSONBackend
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	packages       *packageSet
+	goType         func(*ir.IRTypeRef, bool) string
+	defaults       *defaultsGen
+	validation     *validationGen
 }
 
-func prepareTemplateData(packageName string, optStyle OptionalStyle, data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) templateData {
+func (g *generation) templateData(packageName string, data *ir.IR) (templateData, error) {
+	tplData := prepareTemplateData(packageName, g.optStyle, g.jsonBackend, data, g.formatMappings, g.packages)
+
+	// Imports of the enum methods
+	for _, t := range data.Types {
+		if t.Kind != ir.IRKindEnum {
+			continue
+		}
+		imports := []string{"fmt"}
+		if isIntEnum(t) {
+			imports = append(imports, "strconv")
+		}
+		if g.strictEnums {
+			imports = append(imports, "encoding/json")
+		}
+		tplData.Imports = mergeImports(tplData.Imports, imports)
+	}
+
+	for _, t := range data.Types {
+		code, imports, err := g.defaults.render(t)
+		if err != nil {
+			return tplData, err
+		}
+		if code == "" {
+			continue
+		}
+		if tplData.Defaults == nil {
+			tplData.Defaults = make(map[string]string)
+		}
+		tplData.Defaults[t.Name] = code
+		tplData.Imports = mergeImports(tplData.Imports, imports)
+	}
+	tplData.PtrTo = tplData.Defaults != nil && g.optStyle == OptionalStylePointer
+
+	// Methods of structs keeping additional properties
+	for _, t := range data.Types {
+		var blocks []string
+		addStruct := func(name string, s ir.IRType) error {
+			if s.Additional == nil {
+				return nil
+			}
+			applyDefaults, err := g.defaults.onUnmarshal(name, s.Fields)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, additionalCode(name, s.Fields, g.goType(s.Additional, true), applyDefaults))
+			return nil
+		}
+		switch t.Kind {
+		case ir.IRKindStruct:
+			if err := addStruct(t.Name, t); err != nil {
+				return tplData, err
+			}
+		case ir.IRKindDiscriminatedUnion:
+			for _, v := range t.Union.Variants {
+				if err := addStruct(v.Name, v.Type); err != nil {
+					return tplData, err
+				}
+			}
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+		if tplData.Additional == nil {
+			tplData.Additional = make(map[string]string)
+		}
+		tplData.Additional[t.Name] = strings.Join(blocks, "\n\n")
+		tplData.Imports = mergeImports(tplData.Imports, []string{"encoding/json", "fmt"})
+	}
+
+	if g.validation == nil {
+		return tplData, nil
+	}
+
+	tplData.Validate = true
+	tplData.Validation = make(map[string]string)
+	var imports []string
+	for _, t := range data.Types {
+		code, codeImports := g.validation.render(t)
+		if code != "" {
+			tplData.Validation[t.Name] = code
+			imports = append(imports, codeImports...)
+		}
+	}
+	tplData.Imports = mergeImports(tplData.Imports, imports)
+	return tplData, nil
+}
+
+// templateHelperImports returns the imports of the code in the helpers template
+func templateHelperImports(tplData templateData, backend JSONBackend) []string {
+	var imports []string
+	if tplData.SumTypes || tplData.Fields || tplData.Additional != nil {
+		imports = append(imports, "encoding/json")
+	}
+	if tplData.Additional != nil {
+		imports = mergeImports(imports, []string{"fmt"})
+	}
+	if backend == JSONBackendV2 {
+		if tplData.SumTypes || tplData.Additional != nil {
+			imports = mergeImports(imports, []string{"encoding/json/jsontext"})
+		}
+		if tplData.HasUnion {
+			imports = mergeImports(imports, []string{"bytes", "encoding/json/jsontext", "fmt"})
+		}
+	}
+	if tplData.Validate {
+		imports = mergeImports(imports, validationHelperImports)
+	}
+	return imports
+}
+
+// jsonImports replaces encoding/json with the package of the JSON backend
+func jsonImports(backend JSONBackend, imports []string) []string {
+	if backend != JSONBackendV2 {
+		return imports
+	}
+	result := make([]string, len(imports))
+	for i, imp := range imports {
+		if imp == "encoding/json" {
+			imp = "encoding/json/v2"
+		}
+		result[i] = imp
+	}
+	return result
+}
+
+// buildConstraint returns the build constraint of the files generated for a
+// JSON backend. encoding/json/v2 needs Go 1.27, and the constraint also lets
+// modules declaring an older Go version use it in these files.
+func buildConstraint(backend JSONBackend) string {
+	if backend == JSONBackendV2 {
+		return "go1.27"
+	}
+	return ""
+}
+
+// mergeImports adds imports that aren't already present
+func mergeImports(imports, more []string) []string {
+	seen := make(map[string]bool, len(imports))
+	for _, imp := range imports {
+		seen[imp] = true
+	}
+	for _, imp := range more {
+		if !seen[imp] {
+			seen[imp] = true
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
+
+func prepareTemplateData(packageName string, optStyle OptionalStyle, backend JSONBackend, data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, packages *packageSet) templateData {
 	hasUnion := false
+	hasSumType := false
 	hasOptional := false
+	hasNullable := false
 	importSet := make(map[string]bool)
 
 	for _, t := range data.Types {
@@ -174,9 +859,44 @@ func prepareTemplateData(packageName string, optStyle OptionalStyle, data *ir.IR
 			hasUnion = true
 			collectImportsFromUnion(t, formatMappings, importSet)
 			hasOptional = hasOptional || hasOptionalFields(t.Union)
+			if t.Union != nil {
+				for _, v := range t.Union.Variants {
+					hasNullable = hasNullable || hasNullableRefs(v.Type)
+				}
+			}
+		} else if t.Kind == ir.IRKindUnion {
+			hasSumType = true
+			for i := range t.SimpleUnion.Variants {
+				collectImportsFromRef(&t.SimpleUnion.Variants[i], formatMappings, importSet)
+			}
 		} else {
 			collectImportsFromType(t, formatMappings, importSet)
 			hasOptional = hasOptional || hasOptionalFieldsInType(t)
+			hasNullable = hasNullable || hasNullableRefs(t)
+		}
+	}
+
+	// Import the packages of types referenced from other packages
+	for _, t := range data.Types {
+		for _, name := range typeRefs(t) {
+			if pkg := packages.of(name); pkg.ImportPath != packages.current {
+				importSet[pkg.ImportPath] = true
+			}
+		}
+	}
+
+	if hasUnion {
+		unionImports := []string{"bytes", "encoding/json", "errors", "fmt"}
+		if backend == JSONBackendV2 {
+			unionImports = []string{"encoding/json", "encoding/json/jsontext", "errors", "fmt"}
+		}
+		for _, imp := range unionImports {
+			importSet[imp] = true
+		}
+	}
+	if hasSumType {
+		for _, imp := range []string{"encoding/json", "fmt"} {
+			importSet[imp] = true
 		}
 	}
 
@@ -193,6 +913,8 @@ func prepareTemplateData(packageName string, optStyle OptionalStyle, data *ir.IR
 	return templateData{
 		Package:  packageName,
 		HasUnion: hasUnion,
+		SumTypes: hasSumType,
+		Fields:   optStyle == OptionalStyleNullable && (hasOptional || hasNullable),
 		Imports:  imports,
 		Types:    data.Types,
 	}
@@ -207,6 +929,24 @@ func hasOptionalFieldsInType(t ir.IRType) bool {
 	return false
 }
 
+// hasNullableRefs reports whether a type has nullable fields, array items or
+// map values
+func hasNullableRefs(t ir.IRType) bool {
+	var nullable func(*ir.IRTypeRef) bool
+	nullable = func(ref *ir.IRTypeRef) bool {
+		if ref == nil {
+			return false
+		}
+		return ref.Nullable || nullable(ref.Array) || nullable(ref.Map)
+	}
+	for i := range t.Fields {
+		if nullable(&t.Fields[i].Type) {
+			return true
+		}
+	}
+	return nullable(t.Element)
+}
+
 func hasOptionalFields(union *ir.IRDiscriminatedUnion) bool {
 	if union == nil {
 		return false
@@ -226,6 +966,9 @@ func collectImportsFromType(t ir.IRType, formatMappings map[ir.IRFormat]generato
 	if t.Element != nil {
 		collectImportsFromRef(t.Element, formatMappings, importSet)
 	}
+	if t.Additional != nil {
+		collectImportsFromRef(t.Additional, formatMappings, importSet)
+	}
 }
 
 func collectImportsFromUnion(t ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
@@ -251,7 +994,7 @@ func collectImportsFromRef(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]gen
 	}
 }
 
-func makeGoTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping, optStyle OptionalStyle) func(*ir.IRTypeRef, bool) string {
+func makeGoTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping, optStyle OptionalStyle, packages *packageSet) func(*ir.IRTypeRef, bool) string {
 	var goType func(*ir.IRTypeRef, bool) string
 	goType = func(ref *ir.IRTypeRef, required bool) string {
 		var baseType string
@@ -283,7 +1026,7 @@ func makeGoTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping,
 			} else if ref.Map != nil {
 				baseType = "map[string]" + goType(ref.Map, true)
 			} else if ref.Name != "" {
-				baseType = ref.Name
+				baseType = packages.qualify(ref.Name)
 			} else {
 				baseType = "interface{}"
 			}
@@ -291,7 +1034,11 @@ func makeGoTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping,
 
 		// A value is wrapped (pointer / opt.Optional) when it is an optional
 		// field or a nullable type (type: [T, "null"]). Slices, maps, and any
-		// already carry their own nil, so they are left bare.
+		// already carry their own nil, so they are left bare, except in a
+		// Field, where nil can't tell an absent field from a null one.
+		if (!required || ref.Nullable) && optStyle == OptionalStyleNullable {
+			return "Field[" + baseType + "]"
+		}
 		if (!required || ref.Nullable) && baseType != "interface{}" && !isSlice && !strings.HasPrefix(baseType, "map") {
 			switch optStyle {
 			case OptionalStyleOpt:
@@ -306,23 +1053,29 @@ func makeGoTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping,
 	return goType
 }
 
-func jsonTag(field ir.IRField) string {
-	tag := field.JSONName
-	if !field.Required {
-		tag += ",omitempty"
+// makeJSONTagFunc returns the json struct tag of a field. Optional fields in
+// a Field are left out with omitzero, since omitempty doesn't apply to
+// structs, and so are all optional fields for encoding/json/v2, where
+// omitempty checks the encoded JSON rather than the Go value.
+func makeJSONTagFunc(optStyle OptionalStyle, backend JSONBackend) func(ir.IRField) string {
+	return func(field ir.IRField) string {
+		tag := field.JSONName
+		if !field.Required {
+			if optStyle == OptionalStyleNullable || backend == JSONBackendV2 {
+				tag += ",omitzero"
+			} else {
+				tag += ",omitempty"
+			}
+		}
+		return tag
 	}
-	return tag
 }
 
+const goTemplate = `{{with .Build}}//go:build {{.}}
 
-const goTemplate = `package {{.Package}}
-{{if or .HasUnion .Imports}}
+{{end}}package {{.Package}}
+{{if .Imports}}
 import (
-{{- if .HasUnion}}
-	"bytes"
-	"encoding/json"
-	"fmt"
-{{- end}}
 {{- range .Imports}}
 	"{{.}}"
 {{- end}}
@@ -340,7 +1093,20 @@ import (
 {{- else if eq .Kind "union"}}
 {{template "simpleunion" .}}
 {{- end}}
+{{- with index $.Additional .Name}}
+
+{{.}}
+{{- end}}
+{{- with index $.Defaults .Name}}
+
+{{.}}
+{{- end}}
+{{- with index $.Validation .Name}}
+
+{{.}}
+{{- end}}
 {{end}}
+{{- .Helpers}}
 
 {{define "struct"}}
 {{- if .Description}}
@@ -351,7 +1117,11 @@ type {{.Name}} struct {
 {{- if .Description}}
 	{{comment .Description}}
 {{- end}}
-	{{.Name}} {{goType .Type .Required}} ` + "`" + `json:"{{jsonTag .}}"` + "`" + `
+	{{.Name}} {{goType .Type .Required}} ` + "`" + `{{structTag .}}` + "`" + `
+{{- end}}
+{{- with .Additional}}
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]{{goType . true}} ` + "`" + `{{ignoredTag}}` + "`" + `
 {{- end}}
 }
 {{end}}
@@ -389,6 +1159,7 @@ var {{.Name}}Values = []{{.Name}}{
 {{- end}}
 {{- end}}
 }
+{{template "enummethods" .}}
 {{- else}}
 type {{.Name}} string
 
@@ -407,9 +1178,78 @@ var {{.Name}}Values = []{{.Name}}{
 {{- end}}
 {{- end}}
 }
+{{template "enummethods" .}}
 {{- end}}
 {{end}}
 
+{{define "enummethods"}}
+// IsValid reports whether the value is one of {{.Name}}Values
+func (e {{.Name}}) IsValid() bool {
+	for _, v := range {{.Name}}Values {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e {{.Name}}) String() string {
+{{- if isIntEnum .}}
+	return strconv.Itoa(int(e))
+{{- else}}
+	return string(e)
+{{- end}}
+}
+
+// Parse{{.Name}} converts a string to a {{.Name}}, returning an error when it
+// is not one of {{.Name}}Values.
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+{{- if isIntEnum .}}
+	n, err := strconv.Atoi(s)
+	if err != nil || !{{.Name}}(n).IsValid() {
+		return 0, fmt.Errorf("invalid {{.Name}} %q", s)
+	}
+	return {{.Name}}(n), nil
+{{- else}}
+	e := {{.Name}}(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid {{.Name}} %q", s)
+	}
+	return e, nil
+{{- end}}
+}
+{{- if strictEnums}}
+
+// UnmarshalJSON rejects values that are not one of {{.Name}}Values
+func (e *{{.Name}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+{{- if isIntEnum .}}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("{{.Name}}: %w", err)
+	}
+	if !{{.Name}}(n).IsValid() {
+		return fmt.Errorf("invalid {{.Name}} %d", n)
+	}
+	*e = {{.Name}}(n)
+{{- else}}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{.Name}}: %w", err)
+	}
+	v, err := Parse{{.Name}}(s)
+	if err != nil {
+		return err
+	}
+	*e = v
+{{- end}}
+	return nil
+}
+{{- end}}
+{{- end}}
+
 {{define "union"}}
 {{- if .Description}}
 {{comment .Description}}
@@ -423,6 +1263,73 @@ type {{.Union.WrapperName}} struct {
 	{{.Union.InterfaceName}}
 }
 
+{{- if jsonV2}}
+func (w {{.Union.WrapperName}}) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if w.{{.Union.InterfaceName}} == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, w.{{.Union.InterfaceName}})
+}
+
+// UnmarshalJSONFrom decodes the object straight into the variant named by
+// {{.Union.DiscriminatorJSON}} when it's the first property. Otherwise the object is buffered
+// and scanned for {{.Union.DiscriminatorJSON}} with the tokenizer before it's decoded.
+func (w *{{.Union.WrapperName}}) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		w.{{.Union.InterfaceName}} = nil
+		_, err := dec.ReadToken()
+		return err
+	}
+
+	var data jsontext.Value
+	typ, ok := leadingDiscriminator(dec, "{{.Union.DiscriminatorJSON}}")
+	if !ok {
+		var err error
+		if data, err = dec.ReadValue(); err != nil {
+			return fmt.Errorf("{{.Union.WrapperName}}: invalid JSON: %w", err)
+		}
+		if typ, err = discriminator(data, "{{.Union.DiscriminatorJSON}}"); err != nil {
+			return fmt.Errorf("{{.Union.WrapperName}}: invalid JSON: %w", err)
+		}
+	}
+	if typ == "" {
+		return fmt.Errorf("{{.Union.WrapperName}}: missing discriminator field %q", "{{.Union.DiscriminatorJSON}}")
+	}
+
+	var v {{.Union.InterfaceName}}
+	switch typ {
+{{- range .Union.Variants}}
+	case "{{.ConstValue}}":
+		v = &{{.Name}}{}
+{{- end}}
+	default:
+{{- if unknownVariants}}
+		if data == nil {
+			var err error
+			if data, err = dec.ReadValue(); err != nil {
+				return fmt.Errorf("{{.Union.WrapperName}}: invalid JSON: %w", err)
+			}
+		}
+		w.{{.Union.InterfaceName}} = &Unknown{{.Union.WrapperName}}{Type: typ, Raw: append(jsontext.Value(nil), data...)}
+		return nil
+{{- else}}
+		return fmt.Errorf("{{.Union.WrapperName}}: unknown type %q", typ)
+{{- end}}
+	}
+
+	// A buffered object is decoded with the options of dec, so the caller's
+	// options apply either way.
+	if data != nil {
+		dec = jsontext.NewDecoder(bytes.NewReader(data), dec.Options())
+	}
+	if err := json.UnmarshalDecode(dec, v); err != nil {
+		return fmt.Errorf("{{.Union.WrapperName}}: invalid %q payload: %w", typ, err)
+	}
+
+	w.{{.Union.InterfaceName}} = v
+	return nil
+}
+{{- else}}
 func (w {{.Union.WrapperName}}) MarshalJSON() ([]byte, error) {
 	if w.{{.Union.InterfaceName}} == nil {
 		return []byte("null"), nil
@@ -454,7 +1361,12 @@ func (w *{{.Union.WrapperName}}) UnmarshalJSON(data []byte) error {
 		v = &{{.Name}}{}
 {{- end}}
 	default:
+{{- if unknownVariants}}
+		w.{{.Union.InterfaceName}} = &Unknown{{.Union.WrapperName}}{Type: peek.Type, Raw: append(json.RawMessage(nil), data...)}
+		return nil
+{{- else}}
 		return fmt.Errorf("{{.Union.WrapperName}}: unknown type %q", peek.Type)
+{{- end}}
 	}
 
 	if err := json.Unmarshal(data, v); err != nil {
@@ -464,7 +1376,38 @@ func (w *{{.Union.WrapperName}}) UnmarshalJSON(data []byte) error {
 	w.{{.Union.InterfaceName}} = v
 	return nil
 }
+{{- end}}
+
+// {{.Union.WrapperName}}Visitor has a method for every variant of {{.Union.WrapperName}}
+type {{.Union.WrapperName}}Visitor interface {
+{{- range .Union.Variants}}
+	Visit{{.Name}}(*{{.Name}}) error
+{{- end}}
+{{- if unknownVariants}}
+	VisitUnknown{{.Union.WrapperName}}(*Unknown{{.Union.WrapperName}}) error
+{{- end}}
+}
+
+// Visit calls the method of v for the variant held by w
+func (w {{.Union.WrapperName}}) Visit(v {{.Union.WrapperName}}Visitor) error {
+	switch u := w.{{.Union.InterfaceName}}.(type) {
+{{- range .Union.Variants}}
+	case *{{.Name}}:
+		return v.Visit{{.Name}}(u)
+	case {{.Name}}:
+		return v.Visit{{.Name}}(&u)
+{{- end}}
+{{- if unknownVariants}}
+	case *Unknown{{.Union.WrapperName}}:
+		return v.VisitUnknown{{.Union.WrapperName}}(u)
+	case Unknown{{.Union.WrapperName}}:
+		return v.VisitUnknown{{.Union.WrapperName}}(&u)
+{{- end}}
+	}
+	return errors.New("{{.Union.WrapperName}}: no variant is set")
+}
 {{range .Union.Variants}}
+{{- $variant := .}}
 {{- if .Type.Description}}
 {{comment .Type.Description}}
 {{- end}}
@@ -473,20 +1416,449 @@ type {{.Name}} struct {
 {{- if .Description}}
 	{{comment .Description}}
 {{- end}}
-	{{.Name}} {{goType .Type .Required}} ` + "`" + `json:"{{jsonTag .}}"` + "`" + `
+	{{.Name}} {{goType .Type .Required}} ` + "`" + `{{structTag .}}` + "`" + `
+{{- end}}
+{{- with .Type.Additional}}
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]{{goType . true}} ` + "`" + `{{ignoredTag}}` + "`" + `
 {{- end}}
 }
 
 func ({{.Name}}) is{{$.Union.WrapperName}}() {}
 
 func ({{.Name}}) {{$.Union.WrapperName}}Type() string { return "{{.ConstValue}}" }
+
+// New{{$.Union.WrapperName}}From{{.Name}} wraps v
+{{- range .Type.Fields}}
+{{- if and (eq .JSONName $.Union.DiscriminatorJSON) .Required (not .Type.Nullable)}}, setting its discriminator
+{{- end}}
+{{- end}}
+func New{{$.Union.WrapperName}}From{{.Name}}(v {{.Name}}) {{$.Union.WrapperName}} {
+{{- range .Type.Fields}}
+{{- if and (eq .JSONName $.Union.DiscriminatorJSON) .Required (not .Type.Nullable)}}
+	v.{{.Name}} = "{{$variant.ConstValue}}"
+{{- end}}
+{{- end}}
+	return {{$.Union.WrapperName}}{&v}
+}
 {{end}}
+{{- if unknownVariants}}
+// Unknown{{.Union.WrapperName}} is a variant of {{.Union.WrapperName}} whose {{.Union.DiscriminatorJSON}} isn't known,
+// keeping its JSON so it's encoded unchanged.
+type Unknown{{.Union.WrapperName}} struct {
+	Type string
+	Raw  {{rawJSON}}
+}
+
+func (Unknown{{.Union.WrapperName}}) is{{.Union.WrapperName}}() {}
+
+func (u Unknown{{.Union.WrapperName}}) {{.Union.WrapperName}}Type() string { return u.Type }
+{{if jsonV2}}
+func (u Unknown{{.Union.WrapperName}}) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if len(u.Raw) == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteValue(u.Raw)
+}
+{{- else}}
+func (u Unknown{{.Union.WrapperName}}) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+{{- end}}
+{{end}}
+{{end}}
+
+{{define "helpers"}}
+{{- if and .HasUnion jsonV2}}
+
+// leadingDiscriminator returns the string value of the first property of the
+// JSON object dec is about to read when that property is name, peeking at the
+// buffered input without reading from dec. It reports false when the object
+// starts with another property, or the property isn't buffered yet.
+func leadingDiscriminator(dec *jsontext.Decoder, name string) (string, bool) {
+	if dec.PeekKind() != '{' {
+		return "", false
+	}
+	buf := dec.UnreadBuffer()
+	peek := jsontext.NewDecoder(bytes.NewReader(buf[bytes.IndexByte(buf, '{'):]))
+	if _, err := peek.ReadToken(); err != nil {
+		return "", false
+	}
+	key, err := peek.ReadToken()
+	if err != nil || key.Kind() != '"' || key.String() != name || peek.PeekKind() != '"' {
+		return "", false
+	}
+	value, err := peek.ReadToken()
+	if err != nil {
+		return "", false
+	}
+	return value.String(), true
+}
+
+// discriminator returns the string value of a property of a JSON object,
+// reading its tokens without decoding the other properties. It returns an
+// empty string when the property is missing.
+func discriminator(data []byte, name string) (string, error) {
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return "", err
+	}
+	if tok.Kind() != '{' {
+		return "", fmt.Errorf("expected an object, got %v", tok.Kind())
+	}
+	for dec.PeekKind() == '"' {
+		key, err := dec.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		if key.String() != name {
+			if err := dec.SkipValue(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if dec.PeekKind() != '"' {
+			return "", fmt.Errorf("%s is not a string", name)
+		}
+		value, err := dec.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		return value.String(), nil
+	}
+	return "", nil
+}
+{{- end}}
+{{- if .Fields}}
+
+// Field is an optional value that tells a field absent from the JSON apart from
+// one set to null. The zero value is absent.
+type Field[T any] struct {
+	value T
+	state fieldState
+}
+
+type fieldState uint8
+
+const (
+	fieldAbsent fieldState = iota
+	fieldNull
+	fieldSet
+)
+
+// NewField returns a Field set to v
+func NewField[T any](v T) Field[T] {
+	return Field[T]{value: v, state: fieldSet}
+}
+
+// NullField returns a Field set to null
+func NullField[T any]() Field[T] {
+	return Field[T]{state: fieldNull}
+}
+
+// Get returns the value, and whether it is set
+func (f Field[T]) Get() (T, bool) {
+	return f.value, f.state == fieldSet
+}
+
+// IsSet reports whether the field holds a value
+func (f Field[T]) IsSet() bool { return f.state == fieldSet }
+
+// IsNull reports whether the field was set to null
+func (f Field[T]) IsNull() bool { return f.state == fieldNull }
+
+// IsZero reports whether the field is absent, so omitzero leaves it out
+func (f Field[T]) IsZero() bool { return f.state == fieldAbsent }
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if f.state != fieldSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.value)
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = NullField[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = NewField(v)
+	return nil
+}
+{{- end}}
+{{- if .Additional}}
+
+// marshalAdditional adds additional properties to the JSON object of the
+// declared ones, which take precedence over additional properties of the same
+// name.
+func marshalAdditional[T any](data []byte, additional map[string]T) ([]byte, error) {
+	var object map[string]{{rawJSON}}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for k, v := range additional {
+		if _, ok := object[k]; ok {
+			continue
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		object[k] = value
+	}
+{{- if jsonV2}}
+	return json.Marshal(object, json.Deterministic(true))
+{{- else}}
+	return json.Marshal(object)
+{{- end}}
+}
+
+// unmarshalAdditional decodes the properties of a JSON object besides the
+// known ones, returning nil when there are none.
+func unmarshalAdditional[T any](data []byte, known ...string) (map[string]T, error) {
+	var object map[string]{{rawJSON}}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		delete(object, k)
+	}
+	if len(object) == 0 {
+		return nil, nil
+	}
+	additional := make(map[string]T, len(object))
+	for k, raw := range object {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("additional property %q: %w", k, err)
+		}
+		additional[k] = v
+	}
+	return additional, nil
+}
+{{- end}}
+{{- if .PtrTo}}
+
+// ptrTo returns a pointer to a copy of v
+func ptrTo[T any](v T) *T {
+	return &v
+}
+{{- end}}
+{{- if .SumTypes}}
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
+
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]{{rawJSON}}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+{{- end}}
+{{- if .Validate}}
+
+// ValidationError is returned by Validate, listing every value that failed
+// validation.
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+// ValidationFailure is a value that failed validation
+type ValidationFailure struct {
+	// Path is the JSON pointer to the value, such as "/items/0/name"
+	Path string
+	// Message describes the constraint the value failed
+	Message string
+}
+
+func (f ValidationFailure) Error() string {
+	return f.Path + ": " + f.Message
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failures, so errors.As can match a ValidationFailure
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Each calls fn with every failure, so types in other packages can collect
+// them.
+func (e *ValidationError) Each(fn func(path, message string)) {
+	for _, f := range e.Failures {
+		fn(f.Path, f.Message)
+	}
+}
+
+type validator struct {
+	failures []ValidationFailure
+}
+
+func (v *validator) fail(path, message string) {
+	v.failures = append(v.failures, ValidationFailure{Path: path, Message: message})
+}
+
+// merge adds the failures of a type from another package under path
+func (v *validator) merge(path string, err error) {
+	if err == nil {
+		return
+	}
+	if e, ok := err.(interface{ Each(func(path, message string)) }); ok {
+		e.Each(func(p, message string) { v.fail(path+p, message) })
+		return
+	}
+	v.fail(path, err.Error())
+}
+
+func (v *validator) err() error {
+	if len(v.failures) == 0 {
+		return nil
+	}
+	return &ValidationError{Failures: v.failures}
+}
+
+// pointerToken escapes a map key for use in a JSON pointer
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// uniqueItems compares items by their JSON encoding, since they may not be
+// comparable
+func uniqueItems[T any](items []T) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return false
+		}
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+func multipleOf(value, divisor float64) bool {
+	q := value / divisor
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+{{- end}}
 {{end}}
 
 {{define "simpleunion"}}
+{{- $sum := sumType .}}
 {{- if .Description}}
 {{comment .Description}}
 {{- end}}
-type {{.Name}} = interface{}
+type {{.Name}} struct {
+{{- range $sum.Variants}}
+	{{.Field}} {{if not .Any}}*{{end}}{{.Type}}
+{{- end}}
+}
+
+func (u {{.Name}}) MarshalJSON() ([]byte, error) {
+{{- if $sum.Variants}}
+	switch {
+{{- range $sum.Variants}}
+	case u.{{.Field}} != nil:
+		return json.Marshal(u.{{.Field}})
+{{- end}}
+	}
+{{- end}}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *{{.Name}}) UnmarshalJSON(data []byte) error {
+	*u = {{.Name}}{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+{{- range $sum.Variants}}
+{{- if .Guard}}
+	if {{.Guard}} {
+		if v, err := decode[{{.Type}}](data); err == nil{{if .Enum}} && v.IsValid(){{end}} {
+			u.{{.Field}} = {{if not .Any}}&{{end}}v
+			return nil
+		}
+	}
+{{- else}}
+	if v, err := decode[{{.Type}}](data); err == nil{{if .Enum}} && v.IsValid(){{end}} {
+		u.{{.Field}} = {{if not .Any}}&{{end}}v
+		return nil
+	}
+{{- end}}
+{{- end}}
+	return fmt.Errorf("{{.Name}}: %s value matches none of the variants", kind)
+}
+{{- range $sum.Variants}}
+
+// As{{.Field}} returns the {{.Field}} variant, and whether it is set
+func (u {{$.Name}}) As{{.Field}}() (v {{.Type}}, ok bool) {
+{{- if .Any}}
+	return u.{{.Field}}, u.{{.Field}} != nil
+{{- else}}
+	if u.{{.Field}} != nil {
+		return *u.{{.Field}}, true
+	}
+	return v, false
+{{- end}}
+}
+{{- end}}
 {{end}}
 `
@@ -0,0 +1,184 @@
+package golang
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// sumType is a non-discriminated union, generated as a struct holding one
+// pointer field per variant, of which at most one is set.
+type sumType struct {
+	Name     string
+	Variants []sumVariant
+}
+
+type sumVariant struct {
+	// Field is the name of the struct field holding the variant
+	Field string
+	// Type is the Go type of the variant's value
+	Type string
+	// Ref is the variant as referenced by the union
+	Ref ir.IRTypeRef
+	// Any is set for a variant accepting any value, held as interface{}
+	// rather than a pointer.
+	Any bool
+	// Guard is the condition a JSON value must meet before decoding it as
+	// the variant is attempted, empty when any value is attempted.
+	Guard string
+	// Enum is set when decoded values are checked against the enum's values
+	Enum bool
+}
+
+// sumTypes builds the sumType of every IRKindUnion, matching variants by the
+// kind of JSON token and, for structs, the required properties.
+type sumTypes struct {
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	goType         func(*ir.IRTypeRef, bool) string
+	types          map[string]ir.IRType
+}
+
+func newSumTypes(types []ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, goType func(*ir.IRTypeRef, bool) string) *sumTypes {
+	s := &sumTypes{
+		formatMappings: formatMappings,
+		goType:         goType,
+		types:          make(map[string]ir.IRType, len(types)),
+	}
+	for _, t := range types {
+		s.types[t.Name] = t
+	}
+	return s
+}
+
+func (s *sumTypes) of(t ir.IRType) sumType {
+	sum := sumType{Name: t.Name}
+	if t.SimpleUnion == nil {
+		return sum
+	}
+
+	taken := make(map[string]bool)
+	for _, ref := range t.SimpleUnion.Variants {
+		// A null variant is the union with no variant set
+		if ref.Builtin == ir.IRBuiltinAny && ref.Nullable {
+			continue
+		}
+		ref.Nullable = false
+
+		field := s.fieldName(&ref)
+		name := field
+		for n := 2; taken[name]; n++ {
+			name = field + strconv.Itoa(n)
+		}
+		taken[name] = true
+
+		v := sumVariant{Field: name, Ref: ref, Type: s.goType(&ref, true)}
+		if v.Type == "interface{}" {
+			v.Any = true
+		} else {
+			v.Guard = s.guard(&ref)
+			v.Enum = s.types[ref.Name].Kind == ir.IRKindEnum
+		}
+		sum.Variants = append(sum.Variants, v)
+	}
+	return sum
+}
+
+// fieldName names the field of a variant after its type, such as String,
+// Float, Address or StringArray.
+func (s *sumTypes) fieldName(ref *ir.IRTypeRef) string {
+	if mapping, ok := s.formatMappings[ref.Format]; ok {
+		if mapping.Type == "[]byte" {
+			return "Bytes"
+		}
+		name := mapping.Type[strings.LastIndex(mapping.Type, ".")+1:]
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	switch {
+	case ref.Name != "":
+		return ref.Name
+	case ref.Array != nil:
+		return s.fieldName(ref.Array) + "Array"
+	case ref.Map != nil:
+		if elem := s.fieldName(ref.Map); elem != "Any" {
+			return elem + "Map"
+		}
+		return "Object"
+	}
+	switch ref.Builtin {
+	case ir.IRBuiltinString:
+		return "String"
+	case ir.IRBuiltinInt:
+		return "Int"
+	case ir.IRBuiltinFloat:
+		return "Float"
+	case ir.IRBuiltinBool:
+		return "Bool"
+	}
+	return "Any"
+}
+
+// guard returns the condition on the kind and keys of a JSON value that a
+// variant can decode from.
+func (s *sumTypes) guard(ref *ir.IRTypeRef) string {
+	if ref.Name != "" {
+		if t, ok := s.types[ref.Name]; ok && t.Kind == ir.IRKindStruct {
+			var keys []string
+			for _, f := range t.Fields {
+				if f.Required {
+					keys = append(keys, strconv.Quote(f.JSONName))
+				}
+			}
+			if len(keys) > 0 {
+				return `kind == "object" && hasKeys(data, ` + strings.Join(keys, ", ") + ")"
+			}
+		}
+	}
+	if kind := s.kind(ref, 0); kind != "" {
+		return "kind == " + strconv.Quote(kind)
+	}
+	return ""
+}
+
+// kind returns the kind of JSON value a type decodes from, as returned by
+// the jsonKind helper, or empty when it can decode from several.
+func (s *sumTypes) kind(ref *ir.IRTypeRef, depth int) string {
+	if _, ok := s.formatMappings[ref.Format]; ok {
+		// Formats are string encodings, including base64 for []byte
+		if ref.Builtin == ir.IRBuiltinString {
+			return "string"
+		}
+	}
+	switch {
+	case ref.Array != nil:
+		return "array"
+	case ref.Map != nil:
+		return "object"
+	case ref.Name != "":
+		t := s.types[ref.Name]
+		switch t.Kind {
+		case ir.IRKindStruct, ir.IRKindDiscriminatedUnion:
+			return "object"
+		case ir.IRKindEnum:
+			if isIntEnum(t) {
+				return "number"
+			}
+			return "string"
+		case ir.IRKindAlias:
+			if t.Element != nil && depth < 8 {
+				return s.kind(t.Element, depth+1)
+			}
+		}
+		return ""
+	}
+	switch ref.Builtin {
+	case ir.IRBuiltinString:
+		return "string"
+	case ir.IRBuiltinInt, ir.IRBuiltinFloat:
+		return "number"
+	case ir.IRBuiltinBool:
+		return "boolean"
+	}
+	return ""
+}
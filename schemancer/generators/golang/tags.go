@@ -0,0 +1,176 @@
+package golang
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// TagExtension is the schema extension holding struct tags of a field, such
+// as `db:"user_id" validate:"required"`. Its tags replace the generated ones
+// with the same key, except json, which always holds the property name.
+const TagExtension = "x-go-tag"
+
+// TagCasing determines how the name in a struct tag is derived from the JSON
+// property name
+type TagCasing string
+
+const (
+	// TagCasingJSON uses the JSON property name unchanged
+	TagCasingJSON TagCasing = "json"
+	// TagCasingSnake uses snake_case: user_id
+	TagCasingSnake TagCasing = "snake"
+	// TagCasingCamel uses camelCase: userId
+	TagCasingCamel TagCasing = "camel"
+	// TagCasingPascal uses PascalCase: UserID
+	TagCasingPascal TagCasing = "pascal"
+	// TagCasingKebab uses kebab-case: user-id
+	TagCasingKebab TagCasing = "kebab"
+)
+
+func (c TagCasing) apply(name string) (string, error) {
+	switch c {
+	case TagCasingJSON, "":
+		return name, nil
+	case TagCasingSnake:
+		return casing.ToSnakeCase(name), nil
+	case TagCasingCamel:
+		return casing.ToCamelCase(name), nil
+	case TagCasingPascal:
+		return casing.ToPascalCase(name), nil
+	case TagCasingKebab:
+		return casing.ToKebabCase(name), nil
+	}
+	return "", fmt.Errorf("unknown casing %q, expected json, snake, camel, pascal or kebab", c)
+}
+
+// structTag is a key and value pair of a struct tag
+type structTag struct {
+	key, value string
+}
+
+// tagGen renders the struct tags of fields: the json tag, then the tags
+// configured for every field in key order, then the x-go-tag of the field.
+type tagGen struct {
+	jsonTag func(ir.IRField) string
+	keys    []string
+	casings map[string]TagCasing
+}
+
+func newTagGen(tags map[string]TagCasing, jsonTag func(ir.IRField) string) (*tagGen, error) {
+	g := &tagGen{jsonTag: jsonTag, casings: tags}
+	for key, c := range tags {
+		if key == "json" {
+			return nil, fmt.Errorf("golang: the json tag can't be configured, set %s on fields instead", TagExtension)
+		}
+		if _, err := c.apply(""); err != nil {
+			return nil, fmt.Errorf("golang: %s tag: %w", key, err)
+		}
+		g.keys = append(g.keys, key)
+	}
+	sort.Strings(g.keys)
+	return g, nil
+}
+
+// field renders the struct tag of a field
+func (g *tagGen) field(field ir.IRField) (string, error) {
+	tags := []structTag{{key: "json", value: g.jsonTag(field)}}
+	for _, key := range g.keys {
+		name, err := g.casings[key].apply(field.JSONName)
+		if err != nil {
+			return "", err
+		}
+		tags = append(tags, structTag{key: key, value: name})
+	}
+
+	if raw, ok := field.Extensions[TagExtension]; ok {
+		custom, err := parseStructTag(raw)
+		if err != nil {
+			return "", fmt.Errorf("golang: %s of %s: %w", TagExtension, field.JSONName, err)
+		}
+		for _, tag := range custom {
+			if tag.key == "json" {
+				// The JSON name is used by the decoding, validation and
+				// additional properties code too, so it can't be renamed here
+				return "", fmt.Errorf("golang: %s of %s can't set the json tag", TagExtension, field.JSONName)
+			}
+			tags = setTag(tags, tag)
+		}
+	}
+	return formatTags(tags), nil
+}
+
+// ignored renders the struct tag of a field left out of every encoding
+func (g *tagGen) ignored() string {
+	tags := []structTag{{key: "json", value: "-"}}
+	for _, key := range g.keys {
+		tags = append(tags, structTag{key: key, value: "-"})
+	}
+	return formatTags(tags)
+}
+
+// setTag replaces the tag with the same key, or appends it
+func setTag(tags []structTag, tag structTag) []structTag {
+	for i := range tags {
+		if tags[i].key == tag.key {
+			tags[i].value = tag.value
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+func formatTags(tags []structTag) string {
+	parts := make([]string, len(tags))
+	for i, tag := range tags {
+		parts[i] = tag.key + ":" + strconv.Quote(tag.value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseStructTag splits a struct tag into its key and value pairs, following
+// the conventional format read by reflect.StructTag.
+func parseStructTag(tag string) ([]structTag, error) {
+	var tags []structTag
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return tags, nil
+		}
+
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return nil, fmt.Errorf("invalid struct tag %q, expected key:\"value\" pairs", tag)
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// Find the closing quote, skipping escaped characters
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return nil, fmt.Errorf("unterminated value of %s tag", key)
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s tag: %w", key, err)
+		}
+		if strings.Contains(value, "`") {
+			return nil, fmt.Errorf("value of %s tag contains a backtick", key)
+		}
+		tags = append(tags, structTag{key: key, value: value})
+		tag = tag[i+1:]
+	}
+}
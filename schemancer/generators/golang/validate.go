@@ -0,0 +1,475 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// validationHelperImports are the imports of the shared validation code in
+// the helpers template
+var validationHelperImports = []string{"encoding/json", "math", "strings"}
+
+// validationGen renders the Validate methods enforcing the IRConstraints of
+// each type. Every struct, union variant and union wrapper gets an exported
+// Validate, and an unexported validate that collects failures under a JSON
+// pointer, so nested types report the full path of each failure.
+type validationGen struct {
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	optStyle       OptionalStyle
+	packages       *packageSet
+	sums           *sumTypes
+
+	// types holds every type by name, including union variants
+	types map[string]ir.IRType
+
+	// aliases holds the aliases whose element is being checked, so an alias
+	// referencing itself isn't expanded forever
+	aliases map[string]bool
+}
+
+func newValidationGen(types []ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, optStyle OptionalStyle, packages *packageSet, sums *sumTypes) *validationGen {
+	g := &validationGen{
+		formatMappings: formatMappings,
+		optStyle:       optStyle,
+		packages:       packages,
+		sums:           sums,
+		types:          make(map[string]ir.IRType),
+	}
+	for _, t := range types {
+		g.types[t.Name] = t
+		if t.Union != nil {
+			for _, v := range t.Union.Variants {
+				variant := v.Type
+				variant.Name = v.Name
+				g.types[v.Name] = variant
+			}
+		}
+	}
+	return g
+}
+
+// validationCode is the code generated for one type
+type validationCode struct {
+	lines   []string
+	imports map[string]bool
+
+	// patterns holds the regexp variables the checks use
+	patterns []string
+	prefix   string
+}
+
+func (c *validationCode) add(format string, args ...any) {
+	c.lines = append(c.lines, fmt.Sprintf(format, args...))
+}
+
+// fail renders a check adding a failure when cond holds
+func (c *validationCode) fail(cond, path, message string) {
+	c.add("if %s {\n\tv.fail(%s, %s)\n}", cond, path, strconv.Quote(message))
+}
+
+// render returns the validation code of a type, and the imports it needs
+func (g *validationGen) render(t ir.IRType) (string, []string) {
+	var blocks []string
+	var imports []string
+	seen := make(map[string]bool)
+	addImports := func(c *validationCode) {
+		for imp := range c.imports {
+			if !seen[imp] {
+				seen[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+	}
+
+	switch t.Kind {
+	case ir.IRKindStruct:
+		c := g.structCode(t.Name, t.Fields, t.Additional, "")
+		blocks = append(blocks, c.methods(t.Name, "x"))
+		addImports(c)
+	case ir.IRKindDiscriminatedUnion:
+		u := t.Union
+		wrapper := &validationCode{}
+		wrapper.add("if u, ok := w.%s.(interface{ validate(*validator, string) }); ok {\n\tu.validate(v, path)\n}", u.InterfaceName)
+		blocks = append(blocks, wrapper.methods(u.WrapperName, "w"))
+		for _, variant := range u.Variants {
+			c := g.structCode(variant.Name, variant.Type.Fields, variant.Type.Additional, u.DiscriminatorJSON)
+			blocks = append(blocks, c.methods(variant.Name, "x"))
+			addImports(c)
+		}
+	case ir.IRKindUnion:
+		// Only the variant that is set is checked
+		c := &validationCode{imports: make(map[string]bool), prefix: casing.ToCamelCase(t.Name)}
+		for _, variant := range g.sums.of(t).Variants {
+			if variant.Any {
+				continue
+			}
+			inner := &validationCode{imports: c.imports, prefix: c.prefix + variant.Field}
+			g.valueChecks(inner, "*x."+variant.Field, &variant.Ref, "path", 1)
+			c.block(fmt.Sprintf("if x.%s != nil", variant.Field), inner.lines)
+			c.patterns = append(c.patterns, inner.patterns...)
+		}
+		blocks = append(blocks, c.methods(t.Name, "x"))
+		addImports(c)
+	default:
+		return "", nil
+	}
+
+	return strings.Join(blocks, "\n\n"), imports
+}
+
+// methods renders the Validate and validate methods holding the checks
+func (c *validationCode) methods(typeName, receiver string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Validate checks %s against the constraints of its schema, returning a\n", typeName)
+	b.WriteString("// *ValidationError with the JSON pointer of every value that failed.\n")
+	fmt.Fprintf(&b, "func (%s %s) Validate() error {\n\tv := &validator{}\n\t%s.validate(v, \"\")\n\treturn v.err()\n}\n\n", receiver, typeName, receiver)
+	fmt.Fprintf(&b, "func (%s %s) validate(v *validator, path string) {\n", receiver, typeName)
+	for _, line := range c.lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	if len(c.patterns) > 0 {
+		b.WriteString("\n\nvar (\n")
+		for _, p := range c.patterns {
+			b.WriteString(p)
+			b.WriteString("\n")
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// structCode renders the checks of a struct's fields, skipping the
+// discriminator of a union variant. The values in AdditionalProperties are
+// checked against the additionalProperties schema.
+func (g *validationGen) structCode(typeName string, fields []ir.IRField, additional *ir.IRTypeRef, discriminatorJSON string) *validationCode {
+	c := &validationCode{
+		imports: make(map[string]bool),
+		prefix:  casing.ToCamelCase(typeName),
+	}
+	for i := range fields {
+		field := fields[i]
+		if discriminatorJSON != "" && field.JSONName == discriminatorJSON {
+			continue
+		}
+		g.fieldChecks(c, field)
+	}
+	if additional != nil {
+		g.valueChecks(c, "x."+additionalField, &ir.IRTypeRef{Map: additional}, "path", 0)
+	}
+	return c
+}
+
+func (g *validationGen) fieldChecks(c *validationCode, field ir.IRField) {
+	path := joinPath("path", "/"+pointerToken(field.JSONName))
+	expr := "x." + field.Name
+	ref := &field.Type
+
+	body := &validationCode{imports: c.imports, prefix: c.prefix + field.Name}
+	g.wrappedChecks(body, expr, ref, field.Required, path, 0)
+	c.patterns = append(c.patterns, body.patterns...)
+
+	var required string
+	if field.Required && !ref.Nullable {
+		switch {
+		case g.nilable(ref):
+			required = expr + " == nil"
+		case g.kind(ref) == ir.IRKindDiscriminatedUnion:
+			required = expr + "." + g.types[ref.Name].Union.InterfaceName + " == nil"
+		}
+	}
+
+	switch {
+	case required != "" && hasChecks(body.lines):
+		c.add("if %s {\n\tv.fail(%s, %q)\n} else {\n%s\n}", required, path, "is required", strings.Join(body.lines, "\n"))
+	case required != "":
+		c.lines = append(c.lines, body.lines...)
+		c.fail(required, path, "is required")
+	case !field.Required && g.nilable(ref) && !g.wrapped(ref, false) && hasChecks(body.lines):
+		// Absent optional slices and maps are nil
+		c.add("if %s != nil {\n%s\n}", expr, strings.Join(body.lines, "\n"))
+	default:
+		c.lines = append(c.lines, body.lines...)
+	}
+}
+
+// block wraps checks in a statement such as an if or for, leaving out the
+// statement when there are only comments.
+func (c *validationCode) block(header string, lines []string) {
+	if !hasChecks(lines) {
+		c.lines = append(c.lines, lines...)
+		return
+	}
+	c.add("%s {\n%s\n}", header, strings.Join(lines, "\n"))
+}
+
+func hasChecks(lines []string) bool {
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "//") {
+			return true
+		}
+	}
+	return false
+}
+
+// wrappedChecks renders the checks of a value that may be wrapped in a
+// pointer, opt.Optional or Field, which are only checked when set.
+func (g *validationGen) wrappedChecks(c *validationCode, expr string, ref *ir.IRTypeRef, required bool, path string, depth int) {
+	if !g.wrapped(ref, required) {
+		g.valueChecks(c, expr, ref, path, depth)
+		return
+	}
+
+	inner := &validationCode{imports: c.imports, prefix: c.prefix}
+	switch g.optStyle {
+	case OptionalStyleOpt, OptionalStyleNullable:
+		val := suffixed("val", depth)
+		g.valueChecks(inner, val, ref, path, depth+1)
+		c.block(fmt.Sprintf("if %s, ok := %s.Get(); ok", val, expr), inner.lines)
+	default:
+		g.valueChecks(inner, "*"+expr, ref, path, depth+1)
+		c.block(fmt.Sprintf("if %s != nil", expr), inner.lines)
+	}
+	c.patterns = append(c.patterns, inner.patterns...)
+}
+
+// valueChecks renders the checks of a value that is set
+func (g *validationGen) valueChecks(c *validationCode, expr string, ref *ir.IRTypeRef, path string, depth int) {
+	cons := ref.Constraints
+	if cons == nil {
+		cons = &ir.IRConstraints{}
+	}
+
+	switch {
+	case ref.Builtin == ir.IRBuiltinString && g.isString(ref):
+		if cons.MinLength != nil {
+			c.imports["unicode/utf8"] = true
+			c.fail(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", expr, *cons.MinLength), path, fmt.Sprintf("must be at least %d characters", *cons.MinLength))
+		}
+		if cons.MaxLength != nil {
+			c.imports["unicode/utf8"] = true
+			c.fail(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", expr, *cons.MaxLength), path, fmt.Sprintf("must be at most %d characters", *cons.MaxLength))
+		}
+		if cons.Pattern != "" {
+			if _, err := regexp.Compile(cons.Pattern); err != nil {
+				// ECMA-262 features such as lookarounds have no RE2 equivalent
+				c.add("// pattern %s is not supported by Go's regexp package", strconv.Quote(cons.Pattern))
+			} else {
+				c.imports["regexp"] = true
+				name := fmt.Sprintf("%sPattern", c.prefix)
+				if len(c.patterns) > 0 {
+					name = fmt.Sprintf("%sPattern%d", c.prefix, len(c.patterns)+1)
+				}
+				c.patterns = append(c.patterns, fmt.Sprintf("%s = regexp.MustCompile(%s)", name, goRawString(cons.Pattern)))
+				c.fail(fmt.Sprintf("!%s.MatchString(%s)", name, expr), path, "must match pattern "+cons.Pattern)
+			}
+		}
+
+	case (ref.Builtin == ir.IRBuiltinInt || ref.Builtin == ir.IRBuiltinFloat) && g.isNumber(ref):
+		isInt := ref.Builtin == ir.IRBuiltinInt
+		compare := func(op string, bound float64, message string) {
+			if isInt && bound != float64(int64(bound)) {
+				c.fail(fmt.Sprintf("float64(%s) %s %s", expr, op, formatFloat(bound)), path, message)
+				return
+			}
+			c.fail(fmt.Sprintf("%s %s %s", expr, op, formatFloat(bound)), path, message)
+		}
+		if cons.Minimum != nil {
+			compare("<", *cons.Minimum, "must be >= "+formatFloat(*cons.Minimum))
+		}
+		if cons.Maximum != nil {
+			compare(">", *cons.Maximum, "must be <= "+formatFloat(*cons.Maximum))
+		}
+		if cons.ExclusiveMinimum != nil {
+			compare("<=", *cons.ExclusiveMinimum, "must be > "+formatFloat(*cons.ExclusiveMinimum))
+		}
+		if cons.ExclusiveMaximum != nil {
+			compare(">=", *cons.ExclusiveMaximum, "must be < "+formatFloat(*cons.ExclusiveMaximum))
+		}
+		if m := cons.MultipleOf; m != nil && *m > 0 {
+			message := "must be a multiple of " + formatFloat(*m)
+			switch {
+			case isInt && *m == float64(int64(*m)):
+				c.fail(fmt.Sprintf("%s%%%d != 0", expr, int64(*m)), path, message)
+			case isInt:
+				c.fail(fmt.Sprintf("!multipleOf(float64(%s), %s)", expr, formatFloat(*m)), path, message)
+			default:
+				c.fail(fmt.Sprintf("!multipleOf(%s, %s)", expr, formatFloat(*m)), path, message)
+			}
+		}
+
+	case ref.Array != nil && !g.isBytes(ref):
+		if cons.MinItems != nil {
+			c.fail(fmt.Sprintf("len(%s) < %d", expr, *cons.MinItems), path, fmt.Sprintf("must have at least %d items", *cons.MinItems))
+		}
+		if cons.MaxItems != nil {
+			c.fail(fmt.Sprintf("len(%s) > %d", expr, *cons.MaxItems), path, fmt.Sprintf("must have at most %d items", *cons.MaxItems))
+		}
+		if cons.UniqueItems {
+			c.fail(fmt.Sprintf("!uniqueItems(%s)", expr), path, "must have unique items")
+		}
+
+		i, item := suffixed("i", depth), suffixed("item", depth)
+		inner := &validationCode{imports: c.imports, prefix: c.prefix + "Item"}
+		g.wrappedChecks(inner, item, ref.Array, true, joinPath(path, "/")+"+strconv.Itoa("+i+")", depth+1)
+		if hasChecks(inner.lines) {
+			c.imports["strconv"] = true
+		}
+		c.block(fmt.Sprintf("for %s, %s := range %s", i, item, expr), inner.lines)
+		c.patterns = append(c.patterns, inner.patterns...)
+
+	case ref.Map != nil:
+		k, val := suffixed("k", depth), suffixed("value", depth)
+		inner := &validationCode{imports: c.imports, prefix: c.prefix + "Value"}
+		g.wrappedChecks(inner, val, ref.Map, true, joinPath(path, "/")+"+pointerToken("+k+")", depth+1)
+		c.block(fmt.Sprintf("for %s, %s := range %s", k, val, expr), inner.lines)
+		c.patterns = append(c.patterns, inner.patterns...)
+
+	case ref.Name != "":
+		g.namedChecks(c, expr, ref, path, depth)
+	}
+}
+
+// namedChecks renders the checks of a value of a named type
+func (g *validationGen) namedChecks(c *validationCode, expr string, ref *ir.IRTypeRef, path string, depth int) {
+	t, ok := g.types[ref.Name]
+	if !ok {
+		return
+	}
+	local := g.packages.of(ref.Name).ImportPath == g.packages.current
+	if strings.HasPrefix(expr, "*") {
+		expr = "(" + expr + ")"
+	}
+
+	switch t.Kind {
+	case ir.IRKindStruct, ir.IRKindDiscriminatedUnion, ir.IRKindUnion:
+		if local {
+			c.add("%s.validate(v, %s)", expr, path)
+		} else {
+			c.add("v.merge(%s, %s.Validate())", path, expr)
+		}
+	case ir.IRKindEnum:
+		var values []string
+		for _, ev := range t.EnumValues {
+			if ev.IsNull {
+				continue
+			}
+			if ev.IntValue != nil {
+				values = append(values, strconv.Itoa(*ev.IntValue))
+			} else {
+				values = append(values, strconv.Quote(ev.StringValue))
+			}
+		}
+		c.fail(fmt.Sprintf("!%s.IsValid()", expr), path, "must be one of "+strings.Join(values, ", "))
+	case ir.IRKindAlias:
+		if t.Element == nil {
+			return
+		}
+		if g.aliases[t.Name] {
+			c.add("// %s references itself, its nested values are not validated", t.Name)
+			return
+		}
+		if g.aliases == nil {
+			g.aliases = make(map[string]bool)
+		}
+		g.aliases[t.Name] = true
+		g.valueChecks(c, expr, t.Element, path, depth+1)
+		delete(g.aliases, t.Name)
+	}
+}
+
+// wrapped reports whether a value is represented by a pointer, opt.Optional
+// or Field, mirroring goType
+func (g *validationGen) wrapped(ref *ir.IRTypeRef, required bool) bool {
+	if required && !ref.Nullable {
+		return false
+	}
+	if g.optStyle == OptionalStyleNullable {
+		return true
+	}
+	return !g.nilable(ref) && !g.isAny(ref)
+}
+
+// nilable reports whether the Go type of a value is a slice or map
+func (g *validationGen) nilable(ref *ir.IRTypeRef) bool {
+	if mapping, ok := g.formatMappings[ref.Format]; ok {
+		return strings.HasPrefix(mapping.Type, "[]") || strings.HasPrefix(mapping.Type, "map")
+	}
+	if ref.Builtin != ir.IRBuiltinNone {
+		return false
+	}
+	return ref.Array != nil || ref.Map != nil
+}
+
+func (g *validationGen) isAny(ref *ir.IRTypeRef) bool {
+	if _, ok := g.formatMappings[ref.Format]; ok {
+		return false
+	}
+	return ref.Builtin == ir.IRBuiltinAny || (ref.Builtin == ir.IRBuiltinNone && ref.Array == nil && ref.Map == nil && ref.Name == "")
+}
+
+// isString reports whether a string value is a Go string, rather than a type
+// from a format mapping such as time.Time
+func (g *validationGen) isString(ref *ir.IRTypeRef) bool {
+	mapping, ok := g.formatMappings[ref.Format]
+	return !ok || mapping.Type == "string"
+}
+
+func (g *validationGen) isNumber(ref *ir.IRTypeRef) bool {
+	_, ok := g.formatMappings[ref.Format]
+	return !ok
+}
+
+func (g *validationGen) isBytes(ref *ir.IRTypeRef) bool {
+	_, ok := g.formatMappings[ref.Format]
+	return ok
+}
+
+func (g *validationGen) kind(ref *ir.IRTypeRef) ir.IRTypeKind {
+	if ref.Name == "" {
+		return ""
+	}
+	return g.types[ref.Name].Kind
+}
+
+// pointerToken escapes a JSON pointer reference token
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func suffixed(name string, depth int) string {
+	if depth == 0 {
+		return name
+	}
+	return name + strconv.Itoa(depth)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// joinPath appends a literal segment to a path expression, merging it into a
+// trailing string literal.
+func joinPath(path, segment string) string {
+	if i := strings.LastIndex(path, `+"`); i >= 0 {
+		if prev, err := strconv.Unquote(path[i+1:]); err == nil {
+			return path[:i+1] + strconv.Quote(prev+segment)
+		}
+	}
+	return path + "+" + strconv.Quote(segment)
+}
+
+// goRawString quotes a regular expression, as a raw string when possible
+func goRawString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
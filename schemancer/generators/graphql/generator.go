@@ -0,0 +1,635 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// NameExtension overrides the GraphQL field name of a property.
+const NameExtension = "x-graphql-name"
+
+// builtinScalars are the scalars every GraphQL schema provides. Any other
+// scalar referenced by the generated types is declared with "scalar".
+var builtinScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in
+// GraphQL. Types that are not built in are declared as custom scalars.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "String"}, // Base64 encoded
+	ir.IRFormatDateTime: {Type: "DateTime"},
+	ir.IRFormatDate:     {Type: "Date"},
+	ir.IRFormatUUID:     {Type: "UUID"},
+	ir.IRFormatEmail:    {Type: "String"},
+	ir.IRFormatURI:      {Type: "String"},
+}
+
+// oneOfDirective is declared explicitly for servers that predate its
+// addition to the GraphQL specification.
+const oneOfDirective = "directive @oneOf on INPUT_OBJECT"
+
+// jsonScalar represents untyped values, maps and non-discriminated unions,
+// which GraphQL's type system cannot express.
+const jsonScalar = "JSON"
+
+// config holds GraphQL-specific generator configuration
+type config struct {
+	// Output filename (default: "schema.graphql")
+	filename string
+
+	// Also emit an input type mirroring each object type
+	inputTypes bool
+}
+
+// Option is a GraphQL-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "graphql" }
+
+// WithFilename sets the output filename (default: "schema.graphql")
+func WithFilename(name string) Option {
+	return Option{apply: func(c *config) {
+		c.filename = name
+	}}
+}
+
+// WithInputTypes enables mirrored input types. Each object type X gets an
+// input XInput, and each discriminated union gets a @oneOf input with one
+// field per variant.
+func WithInputTypes(enabled bool) Option {
+	return Option{apply: func(c *config) {
+		c.inputTypes = enabled
+	}}
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		filename: "schema.graphql",
+	}
+	for _, opt := range genOpts {
+		if gqlOpt, ok := opt.(Option); ok {
+			gqlOpt.apply(cfg)
+		}
+	}
+
+	b := &builder{
+		formatMappings: g.getFormatMappings(opts),
+		typeIndex:      buildTypeIndex(data.Types),
+		scalars:        make(map[string]bool),
+	}
+	tplData, err := b.build(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{
+		"comment":      formatComment,
+		"fieldComment": formatFieldComment,
+	}
+
+	tmpl, err := template.New("graphql").Funcs(funcs).Parse(graphqlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	// Every declaration starts with a blank line separating it from the
+	// previous one, which isn't needed at the top of the file.
+	return []generators.GeneratedFile{{
+		Filename: cfg.filename,
+		Content:  bytes.TrimLeft(buf.Bytes(), "\n"),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatDescription(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatDescription(description, "  ")
+}
+
+// formatDescription renders a description as a GraphQL string placed before
+// a definition. Single lines inside a type use a plain string, everything
+// else uses a block string.
+func formatDescription(description, indent string) string {
+	description = strings.TrimRight(description, "\n")
+	if description == "" {
+		return ""
+	}
+	if indent != "" && !strings.Contains(description, "\n") {
+		return indent + strconv.Quote(description)
+	}
+	lines := []string{indent + `"""`}
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.ReplaceAll(line, `"""`, `\"""`)
+		lines = append(lines, strings.TrimRight(indent+line, " "))
+	}
+	lines = append(lines, indent+`"""`)
+	return strings.Join(lines, "\n")
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+// isName reports whether s is a valid GraphQL name. Names starting with two
+// underscores are reserved for introspection.
+func isName(s string) bool {
+	if s == "" || strings.HasPrefix(s, "__") {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// toCamel converts a word sequence to a lowerCamelCase GraphQL name, dropping
+// any characters that are not valid in names.
+func toCamel(s string) string {
+	var b strings.Builder
+	for _, w := range casing.SplitWords(s) {
+		var word []rune
+		for _, r := range w {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				word = append(word, unicode.ToLower(r))
+			}
+		}
+		if len(word) == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			word[0] = unicode.ToUpper(word[0])
+		}
+		b.WriteString(string(word))
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// fieldName returns the GraphQL name of a property. JSON names that are
+// already valid GraphQL names are kept so default resolvers can read the
+// decoded JSON directly.
+func fieldName(f ir.IRField) string {
+	if name := f.Extensions[NameExtension]; name != "" {
+		return name
+	}
+	if isName(f.JSONName) {
+		return f.JSONName
+	}
+	return toCamel(f.JSONName)
+}
+
+// enumValueName returns the GraphQL name of an enum value. Values that are
+// already valid names are kept as-is, others are converted to UPPER_SNAKE_CASE.
+func enumValueName(value string) string {
+	if isName(value) && value != "true" && value != "false" && value != "null" {
+		return value
+	}
+	var words []string
+	for _, w := range casing.SplitWords(value) {
+		var word []rune
+		for _, r := range w {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				word = append(word, unicode.ToUpper(r))
+			}
+		}
+		if len(word) > 0 {
+			words = append(words, string(word))
+		}
+	}
+	name := strings.Join(words, "_")
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// unique appends a numeric suffix to name if it has already been used
+func unique(name string, used map[string]int) string {
+	used[name]++
+	if n := used[name]; n > 1 {
+		return name + strconv.Itoa(n)
+	}
+	return name
+}
+
+type templateData struct {
+	Scalars    []string
+	Directives []string
+	Decls      []decl
+}
+
+// decl is a top-level type, input, enum or union definition
+type decl struct {
+	Keyword     string
+	Name        string
+	Description string
+	Directives  string
+	Fields      []field
+	Values      []string
+	Members     []string
+}
+
+type field struct {
+	Description string
+	Name        string
+	Type        string
+	Default     string
+}
+
+type builder struct {
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	typeIndex      map[string]ir.IRType
+	scalars        map[string]bool
+	oneOf          bool
+}
+
+func (b *builder) build(cfg *config, data *ir.IR) (templateData, error) {
+	var decls []decl
+	var inputs []decl
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindStruct:
+			d, err := b.object(t.Name, t.Description, t.Fields, "", false)
+			if err != nil {
+				return templateData{}, err
+			}
+			decls = append(decls, d)
+			if cfg.inputTypes {
+				in, err := b.object(t.Name, t.Description, t.Fields, "", true)
+				if err != nil {
+					return templateData{}, err
+				}
+				inputs = append(inputs, in)
+			}
+
+		case ir.IRKindEnum:
+			decls = append(decls, b.enum(t))
+
+		case ir.IRKindDiscriminatedUnion:
+			if t.Union == nil {
+				continue
+			}
+			u := decl{Keyword: "union", Name: t.Name, Description: t.Description}
+			for _, v := range t.Union.Variants {
+				u.Members = append(u.Members, v.Name)
+			}
+			decls = append(decls, u)
+
+			for _, v := range t.Union.Variants {
+				d, err := b.object(v.Name, v.Type.Description, v.Type.Fields, "", false)
+				if err != nil {
+					return templateData{}, err
+				}
+				decls = append(decls, d)
+			}
+
+			if cfg.inputTypes {
+				inputs = append(inputs, b.oneOfInput(t))
+				for _, v := range t.Union.Variants {
+					in, err := b.object(v.Name, v.Type.Description, v.Type.Fields, t.Union.DiscriminatorJSON, true)
+					if err != nil {
+						return templateData{}, err
+					}
+					inputs = append(inputs, in)
+				}
+			}
+
+		case ir.IRKindAlias, ir.IRKindUnion:
+			// GraphQL has no type aliases, references are resolved inline
+		}
+	}
+
+	var scalars []string
+	for s := range b.scalars {
+		scalars = append(scalars, s)
+	}
+	sort.Strings(scalars)
+
+	var directives []string
+	if b.oneOf {
+		directives = append(directives, oneOfDirective)
+	}
+
+	return templateData{
+		Scalars:    scalars,
+		Directives: directives,
+		Decls:      append(decls, inputs...),
+	}, nil
+}
+
+// object renders an object type, or its mirrored input type. The excluded
+// field is the discriminator, which a @oneOf input field already implies.
+func (b *builder) object(name, description string, irFields []ir.IRField, exclude string, input bool) (decl, error) {
+	d := decl{Keyword: "type", Name: name, Description: description}
+	if input {
+		d.Keyword = "input"
+		d.Name = inputName(name)
+	}
+
+	used := make(map[string]int)
+	for _, f := range irFields {
+		if exclude != "" && f.JSONName == exclude {
+			continue
+		}
+		typ := b.resolve(&f.Type, input)
+		if f.Required && !f.Type.Nullable {
+			typ += "!"
+		}
+		gf := field{
+			Description: f.Description,
+			Name:        unique(fieldName(f), used),
+			Type:        typ,
+		}
+		if input && f.Default != nil {
+			value, err := b.defaultValue(f.Default.RawValue, &f.Type)
+			if err != nil {
+				return decl{}, fmt.Errorf("%s.%s: invalid default: %w", name, f.JSONName, err)
+			}
+			gf.Default = value
+		}
+		d.Fields = append(d.Fields, gf)
+	}
+
+	// GraphQL object and input types must declare at least one field
+	if len(d.Fields) == 0 {
+		d.Fields = append(d.Fields, field{
+			Description: "Placeholder, GraphQL types must have at least one field.",
+			Name:        "_empty",
+			Type:        "Boolean",
+		})
+	}
+
+	return d, nil
+}
+
+// oneOfInput renders a discriminated union as an input where exactly one
+// field, named after the discriminator value, must be set.
+func (b *builder) oneOfInput(t ir.IRType) decl {
+	b.oneOf = true
+	d := decl{
+		Keyword:     "input",
+		Name:        inputName(t.Name),
+		Description: t.Description,
+		Directives:  " @oneOf",
+	}
+	used := make(map[string]int)
+	for _, v := range t.Union.Variants {
+		name := v.ConstValue
+		if !isName(name) {
+			name = toCamel(name)
+		}
+		d.Fields = append(d.Fields, field{
+			Name: unique(name, used),
+			Type: inputName(v.Name),
+		})
+	}
+	return d
+}
+
+func (b *builder) enum(t ir.IRType) decl {
+	d := decl{Keyword: "enum", Name: t.Name, Description: t.Description}
+	used := make(map[string]int)
+	for _, v := range t.EnumValues {
+		if v.IsNull {
+			continue
+		}
+		d.Values = append(d.Values, unique(enumValueName(v.StringValue), used))
+	}
+	return d
+}
+
+func inputName(name string) string {
+	return name + "Input"
+}
+
+// resolve maps an IR type reference to a GraphQL type, without the outer
+// non-null marker. Aliases are resolved inline, and collection elements are
+// non-null unless the schema allows null.
+func (b *builder) resolve(ref *ir.IRTypeRef, input bool) string {
+	return b.resolveVisited(ref, input, make(map[string]bool))
+}
+
+func (b *builder) resolveVisited(ref *ir.IRTypeRef, input bool, visited map[string]bool) string {
+	if mapping, ok := b.formatMappings[ref.Format]; ok {
+		return b.scalar(mapping.Type)
+	}
+
+	switch ref.Builtin {
+	case ir.IRBuiltinString:
+		return "String"
+	case ir.IRBuiltinInt:
+		return "Int"
+	case ir.IRBuiltinFloat:
+		return "Float"
+	case ir.IRBuiltinBool:
+		return "Boolean"
+	case ir.IRBuiltinAny:
+		return b.scalar(jsonScalar)
+	}
+
+	if ref.Array != nil {
+		elem := b.resolveVisited(ref.Array, input, visited)
+		if !ref.Array.Nullable {
+			elem += "!"
+		}
+		return "[" + elem + "]"
+	}
+	if ref.Map != nil {
+		return b.scalar(jsonScalar)
+	}
+	if ref.Name != "" {
+		t, ok := b.typeIndex[ref.Name]
+		if !ok {
+			return ref.Name
+		}
+		switch t.Kind {
+		case ir.IRKindAlias:
+			if t.Element == nil || visited[t.Name] {
+				return b.scalar(jsonScalar)
+			}
+			visited[t.Name] = true
+			return b.resolveVisited(t.Element, input, visited)
+		case ir.IRKindUnion:
+			return b.scalar(jsonScalar)
+		case ir.IRKindStruct, ir.IRKindDiscriminatedUnion:
+			if input {
+				return inputName(ref.Name)
+			}
+		}
+		return ref.Name
+	}
+	return b.scalar(jsonScalar)
+}
+
+// scalar records a reference to a custom scalar so it gets declared
+func (b *builder) scalar(name string) string {
+	if !builtinScalars[name] {
+		b.scalars[name] = true
+	}
+	return name
+}
+
+// enumOf returns the enum type a reference resolves to, looking through
+// aliases and arrays, so defaults can be rendered as enum values.
+func (b *builder) enumOf(ref *ir.IRTypeRef) *ir.IRType {
+	for depth := 0; ref != nil && depth < 16; depth++ {
+		if ref.Array != nil {
+			ref = ref.Array
+			continue
+		}
+		t, ok := b.typeIndex[ref.Name]
+		if !ok {
+			return nil
+		}
+		switch t.Kind {
+		case ir.IRKindEnum:
+			return &t
+		case ir.IRKindAlias:
+			ref = t.Element
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// defaultValue renders a JSON default as a GraphQL value literal
+func (b *builder) defaultValue(raw string, ref *ir.IRTypeRef) (string, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", err
+	}
+	return valueLiteral(v, b.enumOf(ref)), nil
+}
+
+func valueLiteral(v any, enum *ir.IRType) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		if enum != nil {
+			return enumLiteral(v.String(), enum)
+		}
+		return v.String()
+	case string:
+		if enum != nil {
+			return enumLiteral(v, enum)
+		}
+		return strconv.Quote(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = valueLiteral(e, enum)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + ": " + valueLiteral(v[k], nil)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+	return "null"
+}
+
+// enumLiteral returns the GraphQL name of an enum value, matching the names
+// used in the enum definition.
+func enumLiteral(value string, enum *ir.IRType) string {
+	used := make(map[string]int)
+	for _, v := range enum.EnumValues {
+		if v.IsNull {
+			continue
+		}
+		name := unique(enumValueName(v.StringValue), used)
+		if v.StringValue == value {
+			return name
+		}
+	}
+	return enumValueName(value)
+}
+
+const graphqlTemplate = `
+{{- range .Scalars}}scalar {{.}}
+{{end}}
+{{- if .Directives}}
+{{range .Directives}}{{.}}
+{{end}}
+{{- end}}
+{{- range $d := .Decls}}
+{{- if $d.Description}}
+{{comment $d.Description}}
+{{- end}}
+{{- if eq $d.Keyword "union"}}
+union {{$d.Name}} = {{range $j, $m := $d.Members}}{{if $j}} | {{end}}{{$m}}{{end}}
+{{- else if eq $d.Keyword "enum"}}
+enum {{$d.Name}} {
+{{- range $d.Values}}
+  {{.}}
+{{- end}}
+}
+{{- else}}
+{{$d.Keyword}} {{$d.Name}}{{$d.Directives}} {
+{{- range $d.Fields}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+  {{.Name}}: {{.Type}}{{if .Default}} = {{.Default}}{{end}}
+{{- end}}
+}
+{{- end}}
+{{end}}`
@@ -0,0 +1,669 @@
+package kotlin
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// kotlinReservedWords contains Kotlin hard keywords that must be escaped with
+// backticks when used as identifiers.
+var kotlinReservedWords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "false": true, "for": true, "fun": true, "if": true,
+	"in": true, "interface": true, "is": true, "null": true, "object": true,
+	"package": true, "return": true, "super": true, "this": true, "throw": true,
+	"true": true, "try": true, "typealias": true, "typeof": true, "val": true,
+	"var": true, "when": true, "while": true,
+}
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in Kotlin.
+// Only types with a serializer available out of the box are used; formats
+// without one fall back to String.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "String"}, // Base64 encoded
+	ir.IRFormatDateTime: {Type: "Instant", Import: "kotlinx.datetime.Instant"},
+	ir.IRFormatDate:     {Type: "LocalDate", Import: "kotlinx.datetime.LocalDate"},
+	ir.IRFormatUUID:     {Type: "String"},
+	ir.IRFormatEmail:    {Type: "String"},
+	ir.IRFormatURI:      {Type: "String"},
+}
+
+// config holds Kotlin-specific generator configuration
+type config struct {
+	packageName string
+}
+
+// Option is a Kotlin-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "kotlin" }
+
+// WithPackageName sets the Kotlin package name for generated code
+func WithPackageName(name string) Option {
+	return Option{apply: func(c *config) {
+		c.packageName = name
+	}}
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		packageName: "generated",
+	}
+	for _, opt := range genOpts {
+		if ktOpt, ok := opt.(Option); ok {
+			ktOpt.apply(cfg)
+		}
+	}
+
+	formatMappings := g.getFormatMappings(opts)
+	typeIndex := buildTypeIndex(data.Types)
+	kotlinType := makeKotlinTypeFunc(formatMappings)
+
+	funcs := template.FuncMap{
+		"camel":         casing.ToCamelCase,
+		"upper":         strings.ToUpper,
+		"kotlinType":    kotlinType,
+		"kotlinParam":   makeKotlinParamFunc(kotlinType, typeIndex),
+		"variantFields": variantFields,
+		"fieldName":     safeFieldName,
+		"serialName":    serialName,
+		"requireChecks": makeRequireChecksFunc(formatMappings),
+		"comment":       formatComment,
+		"fieldComment":  formatFieldComment,
+		"isIntEnum":     isIntEnum,
+		"toEnumKey":     toEnumKey,
+	}
+
+	tmpl, err := template.New("kotlin").Funcs(funcs).Parse(kotlinTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tplData := prepareTemplateData(cfg.packageName, data, formatMappings)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: "Models.kt",
+		Content:  buf.Bytes(),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "    ")
+}
+
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + lines[0] + " */"
+	}
+	var result []string
+	result = append(result, indent+"/**")
+	for _, line := range lines {
+		result = append(result, strings.TrimRight(indent+" * "+line, " "))
+	}
+	result = append(result, indent+" */")
+	return strings.Join(result, "\n")
+}
+
+// isIntEnum returns true if the enum has an integer type
+func isIntEnum(t ir.IRType) bool {
+	return t.EnumType == ir.IRBuiltinInt
+}
+
+// toEnumKey converts an enum value to a valid Kotlin enum constant name
+func toEnumKey(v ir.IREnumValue) string {
+	if v.IntValue != nil {
+		return "VALUE_" + strings.ReplaceAll(v.StringValue, "-", "NEG_")
+	}
+	key := strings.ToUpper(casing.ToSnakeCase(v.StringValue))
+	if key == "" {
+		return "EMPTY"
+	}
+	if key[0] >= '0' && key[0] <= '9' {
+		return "VALUE_" + key
+	}
+	return key
+}
+
+// safeFieldName resolves the Kotlin property name for a field.
+// Uses x-kotlin-name extension if present, otherwise falls back to camelCase
+// with backtick escaping for keywords.
+func safeFieldName(field ir.IRField) string {
+	if name, ok := field.Extensions["x-kotlin-name"]; ok {
+		return name
+	}
+	name := field.JSONName
+	if !isCamelIdentifier(name) {
+		name = casing.ToCamelCase(field.Name)
+	}
+	if kotlinReservedWords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// isCamelIdentifier reports whether s is already a lowerCamelCase identifier,
+// in which case it is used as the property name unchanged.
+func isCamelIdentifier(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// serialName returns the @SerialName annotation for a field when its property
+// name differs from the JSON name.
+func serialName(field ir.IRField) string {
+	if strings.Trim(safeFieldName(field), "`") == field.JSONName {
+		return ""
+	}
+	return `@SerialName("` + field.JSONName + `") `
+}
+
+type templateData struct {
+	Package string
+	Imports []string
+	Types   []ir.IRType
+}
+
+func prepareTemplateData(packageName string, data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) templateData {
+	importSet := make(map[string]bool)
+	importSet["kotlinx.serialization.Serializable"] = true
+
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindDiscriminatedUnion:
+			importSet["kotlinx.serialization.ExperimentalSerializationApi"] = true
+			importSet["kotlinx.serialization.SerialName"] = true
+			importSet["kotlinx.serialization.json.JsonClassDiscriminator"] = true
+			if t.Union != nil {
+				for _, v := range t.Union.Variants {
+					collectImportsFromType(v.Type, formatMappings, importSet)
+				}
+			}
+		case ir.IRKindEnum:
+			if isIntEnum(t) {
+				importSet["kotlinx.serialization.KSerializer"] = true
+				importSet["kotlinx.serialization.SerializationException"] = true
+				importSet["kotlinx.serialization.descriptors.PrimitiveKind"] = true
+				importSet["kotlinx.serialization.descriptors.PrimitiveSerialDescriptor"] = true
+				importSet["kotlinx.serialization.descriptors.SerialDescriptor"] = true
+				importSet["kotlinx.serialization.encoding.Decoder"] = true
+				importSet["kotlinx.serialization.encoding.Encoder"] = true
+			} else {
+				importSet["kotlinx.serialization.SerialName"] = true
+			}
+		case ir.IRKindUnion:
+			importSet["kotlinx.serialization.json.JsonElement"] = true
+		default:
+			collectImportsFromType(t, formatMappings, importSet)
+		}
+	}
+
+	var imports []string
+	for imp := range importSet {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	return templateData{
+		Package: packageName,
+		Imports: imports,
+		Types:   data.Types,
+	}
+}
+
+func collectImportsFromType(t ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
+	for _, field := range t.Fields {
+		if serialName(field) != "" {
+			importSet["kotlinx.serialization.SerialName"] = true
+		}
+		collectImportsFromRef(&field.Type, formatMappings, importSet)
+	}
+	if t.Element != nil {
+		collectImportsFromRef(t.Element, formatMappings, importSet)
+	}
+	if t.Kind == ir.IRKindAlias && t.Element == nil {
+		importSet["kotlinx.serialization.json.JsonElement"] = true
+	}
+}
+
+func collectImportsFromRef(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if mapping, ok := formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			importSet[mapping.Import] = true
+		}
+		return
+	}
+	if ref.Builtin == ir.IRBuiltinAny {
+		importSet["kotlinx.serialization.json.JsonElement"] = true
+	}
+	if ref.Builtin == ir.IRBuiltinNone && ref.Array == nil && ref.Map == nil && ref.Name == "" {
+		importSet["kotlinx.serialization.json.JsonElement"] = true
+	}
+	collectImportsFromRef(ref.Array, formatMappings, importSet)
+	collectImportsFromRef(ref.Map, formatMappings, importSet)
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+func makeKotlinTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping) func(*ir.IRTypeRef, bool) string {
+	var kotlinType func(*ir.IRTypeRef) string
+	kotlinType = func(ref *ir.IRTypeRef) string {
+		if mapping, ok := formatMappings[ref.Format]; ok {
+			return mapping.Type
+		}
+
+		if ref.Builtin != ir.IRBuiltinNone {
+			switch ref.Builtin {
+			case ir.IRBuiltinString:
+				return "String"
+			case ir.IRBuiltinInt:
+				return "Long"
+			case ir.IRBuiltinFloat:
+				return "Double"
+			case ir.IRBuiltinBool:
+				return "Boolean"
+			}
+			return "JsonElement"
+		}
+		if ref.Array != nil {
+			return "List<" + kotlinType(ref.Array) + ">"
+		}
+		if ref.Map != nil {
+			return "Map<String, " + kotlinType(ref.Map) + ">"
+		}
+		if ref.Name != "" {
+			return ref.Name
+		}
+		return "JsonElement"
+	}
+
+	return func(ref *ir.IRTypeRef, nullable bool) string {
+		baseType := kotlinType(ref)
+		if nullable || ref.Nullable {
+			return baseType + "?"
+		}
+		return baseType
+	}
+}
+
+// makeKotlinParamFunc returns a template function that renders the type and
+// initializer of a constructor parameter. Fields with a schema default use it
+// and stay non-null, other optional fields default to null, and required
+// fields have no initializer.
+func makeKotlinParamFunc(kotlinType func(*ir.IRTypeRef, bool) string, typeIndex map[string]ir.IRType) func(ir.IRField) string {
+	return func(field ir.IRField) string {
+		if literal := kotlinLiteral(field, typeIndex); literal != "" {
+			return kotlinType(&field.Type, false) + " = " + literal
+		}
+		if !field.Required {
+			return kotlinType(&field.Type, true) + " = null"
+		}
+		return kotlinType(&field.Type, false)
+	}
+}
+
+// variantFields returns the fields of a union variant without the
+// discriminator, which kotlinx.serialization writes itself.
+func variantFields(fields []ir.IRField, discriminatorJSON string) []ir.IRField {
+	var result []ir.IRField
+	for _, f := range fields {
+		if f.JSONName != discriminatorJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// kotlinLiteral renders a field's default value as a Kotlin literal, or an
+// empty string if the default cannot be represented.
+func kotlinLiteral(field ir.IRField, typeIndex map[string]ir.IRType) string {
+	if field.Default == nil {
+		return ""
+	}
+	raw := field.Default.RawValue
+
+	// Enum defaults reference the enum constant rather than the raw value
+	if t, ok := typeIndex[field.Type.Name]; ok && t.Kind == ir.IRKindEnum {
+		for _, v := range t.EnumValues {
+			if v.IsNull {
+				continue
+			}
+			if v.IntValue != nil && raw == v.StringValue {
+				return t.Name + "." + toEnumKey(v)
+			}
+			if v.IntValue == nil && raw == strconv.Quote(v.StringValue) {
+				return t.Name + "." + toEnumKey(v)
+			}
+		}
+		return ""
+	}
+	if field.Type.Name != "" || field.Type.Array != nil || field.Type.Map != nil {
+		return ""
+	}
+
+	switch field.Default.Builtin {
+	case ir.IRBuiltinInt:
+		return raw + "L"
+	case ir.IRBuiltinFloat:
+		if !strings.ContainsAny(raw, ".eE") {
+			return raw + ".0"
+		}
+		return raw
+	case ir.IRBuiltinBool:
+		return raw
+	case ir.IRBuiltinString:
+		// raw is already JSON-quoted, only string templates need escaping
+		return strings.ReplaceAll(raw, "$", `\$`)
+	}
+	return ""
+}
+
+// makeRequireChecksFunc returns a template function that renders the require()
+// calls enforcing a type's IRConstraints, one per line.
+func makeRequireChecksFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping) func(ir.IRType, string) []string {
+	return func(t ir.IRType, discriminatorJSON string) []string {
+		var checks []string
+		for _, field := range t.Fields {
+			if field.JSONName == discriminatorJSON || field.Type.Constraints == nil {
+				continue
+			}
+			checks = append(checks, fieldRequireChecks(field, formatMappings)...)
+		}
+		return checks
+	}
+}
+
+func fieldRequireChecks(field ir.IRField, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) []string {
+	c := field.Type.Constraints
+	name := safeFieldName(field)
+	optional := !field.Required || field.Type.Nullable
+	subject := name
+	if optional {
+		subject = "it"
+	}
+
+	var conds []string
+	add := func(cond, message string) {
+		message = strings.ReplaceAll(strconv.Quote(field.JSONName+" "+message), "$", `\$`)
+		conds = append(conds, fmt.Sprintf("require(%s) { %s }", cond, message))
+	}
+
+	isString := field.Type.Builtin == ir.IRBuiltinString
+	if mapping, ok := formatMappings[field.Type.Format]; ok && mapping.Type != "String" {
+		isString = false
+	}
+
+	switch {
+	case isString:
+		if c.MinLength != nil {
+			add(fmt.Sprintf("%s.length >= %d", subject, *c.MinLength), fmt.Sprintf("must be at least %d characters", *c.MinLength))
+		}
+		if c.MaxLength != nil {
+			add(fmt.Sprintf("%s.length <= %d", subject, *c.MaxLength), fmt.Sprintf("must be at most %d characters", *c.MaxLength))
+		}
+		if c.Pattern != "" {
+			add(fmt.Sprintf("Regex(%s).containsMatchIn(%s)", kotlinRawString(c.Pattern), subject), "must match pattern "+c.Pattern)
+		}
+	case field.Type.Builtin == ir.IRBuiltinInt || field.Type.Builtin == ir.IRBuiltinFloat:
+		num := func(v float64) string {
+			if field.Type.Builtin == ir.IRBuiltinInt && v == float64(int64(v)) {
+				return strconv.FormatInt(int64(v), 10) + "L"
+			}
+			s := strconv.FormatFloat(v, 'f', -1, 64)
+			if !strings.Contains(s, ".") {
+				s += ".0"
+			}
+			return s
+		}
+		if c.Minimum != nil {
+			add(fmt.Sprintf("%s >= %s", subject, num(*c.Minimum)), fmt.Sprintf("must be >= %v", *c.Minimum))
+		}
+		if c.Maximum != nil {
+			add(fmt.Sprintf("%s <= %s", subject, num(*c.Maximum)), fmt.Sprintf("must be <= %v", *c.Maximum))
+		}
+		if c.ExclusiveMinimum != nil {
+			add(fmt.Sprintf("%s > %s", subject, num(*c.ExclusiveMinimum)), fmt.Sprintf("must be > %v", *c.ExclusiveMinimum))
+		}
+		if c.ExclusiveMaximum != nil {
+			add(fmt.Sprintf("%s < %s", subject, num(*c.ExclusiveMaximum)), fmt.Sprintf("must be < %v", *c.ExclusiveMaximum))
+		}
+		if c.MultipleOf != nil {
+			if field.Type.Builtin == ir.IRBuiltinInt && *c.MultipleOf == float64(int64(*c.MultipleOf)) {
+				add(fmt.Sprintf("%s %% %s == 0L", subject, num(*c.MultipleOf)), fmt.Sprintf("must be a multiple of %v", *c.MultipleOf))
+			} else {
+				add(fmt.Sprintf("(%s / %s).let { q -> q == kotlin.math.floor(q) }", subject, num(*c.MultipleOf)), fmt.Sprintf("must be a multiple of %v", *c.MultipleOf))
+			}
+		}
+	case field.Type.Array != nil:
+		if c.MinItems != nil {
+			add(fmt.Sprintf("%s.size >= %d", subject, *c.MinItems), fmt.Sprintf("must have at least %d items", *c.MinItems))
+		}
+		if c.MaxItems != nil {
+			add(fmt.Sprintf("%s.size <= %d", subject, *c.MaxItems), fmt.Sprintf("must have at most %d items", *c.MaxItems))
+		}
+		if c.UniqueItems {
+			add(fmt.Sprintf("%s.size == %s.toSet().size", subject, subject), "must have unique items")
+		}
+	}
+
+	if len(conds) == 0 {
+		return nil
+	}
+
+	if !optional {
+		return conds
+	}
+	var lines []string
+	for _, cond := range conds {
+		lines = append(lines, name+"?.let { "+cond+" }")
+	}
+	return lines
+}
+
+// kotlinRawString renders s as a Kotlin raw string literal so regex
+// backslashes need no escaping. Dollar signs are escaped via a template.
+func kotlinRawString(s string) string {
+	return `"""` + strings.ReplaceAll(s, "$", "${'$'}") + `"""`
+}
+
+const kotlinTemplate = `package {{.Package}}
+{{range .Imports}}
+import {{.}}
+{{- end}}
+{{- range .Types}}
+{{- if eq .Kind "struct"}}
+{{template "class" .}}
+{{- else if eq .Kind "alias"}}
+{{template "alias" .}}
+{{- else if eq .Kind "enum"}}
+{{template "enum" .}}
+{{- else if eq .Kind "discriminated_union"}}
+{{template "union" .}}
+{{- else if eq .Kind "union"}}
+{{template "simpleunion" .}}
+{{- end}}
+{{- end}}
+
+{{- define "class"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+@Serializable
+{{- if .Fields}}
+data class {{.Name}}(
+{{- template "params" .Fields}}
+){{template "init" (requireChecks . "")}}
+{{- else}}
+class {{.Name}}
+{{- end}}
+{{- end}}
+
+{{- define "params"}}
+{{- range .}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+    {{serialName .}}val {{fieldName .}}: {{kotlinParam .}},
+{{- end}}
+{{- end}}
+
+{{- define "init"}}
+{{- if .}} {
+    init {
+{{- range .}}
+        {{.}}
+{{- end}}
+    }
+}
+{{- end}}
+{{- end}}
+
+{{- define "alias"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if .Element}}
+typealias {{.Name}} = {{kotlinType .Element false}}
+{{- else}}
+typealias {{.Name}} = JsonElement
+{{- end}}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if isIntEnum .}}
+@Serializable(with = {{.Name}}.Serializer::class)
+enum class {{.Name}}(val value: Long) {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+    {{toEnumKey .}}({{.IntValue}}),
+{{- end}}
+{{- end}}
+    ;
+
+    object Serializer : KSerializer<{{.Name}}> {
+        override val descriptor: SerialDescriptor = PrimitiveSerialDescriptor("{{.Name}}", PrimitiveKind.LONG)
+
+        override fun serialize(encoder: Encoder, value: {{.Name}}) = encoder.encodeLong(value.value)
+
+        override fun deserialize(decoder: Decoder): {{.Name}} {
+            val value = decoder.decodeLong()
+            return entries.firstOrNull { it.value == value }
+                ?: throw SerializationException("invalid {{.Name}} value: $value")
+        }
+    }
+}
+{{- else}}
+@Serializable
+enum class {{.Name}}(val value: String) {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+    @SerialName("{{.StringValue}}")
+    {{toEnumKey .}}("{{.StringValue}}"),
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "union"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+@OptIn(ExperimentalSerializationApi::class)
+@Serializable
+@JsonClassDiscriminator("{{.Union.DiscriminatorJSON}}")
+sealed interface {{.Name}} {
+    val {{camel .Union.DiscriminatorField}}: String
+}
+{{- range .Union.Variants}}
+{{if .Type.Description}}
+{{comment .Type.Description}}
+{{- end}}
+@Serializable
+@SerialName("{{.ConstValue}}")
+{{- $fields := variantFields .Type.Fields $.Union.DiscriminatorJSON}}
+{{- if $fields}}
+data class {{.Name}}(
+{{- template "params" $fields}}
+) : {{$.Name}} {
+    override val {{camel $.Union.DiscriminatorField}}: String get() = "{{.ConstValue}}"
+{{- with requireChecks .Type $.Union.DiscriminatorJSON}}
+
+    init {
+{{- range .}}
+        {{.}}
+{{- end}}
+    }
+{{- end}}
+}
+{{- else}}
+class {{.Name}} : {{$.Name}} {
+    override val {{camel $.Union.DiscriminatorField}}: String get() = "{{.ConstValue}}"
+}
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- define "simpleunion"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+typealias {{.Name}} = JsonElement
+{{- end}}
+`
@@ -0,0 +1,419 @@
+// Package openapi exports the dereferenced schema definitions as the
+// components of an OpenAPI 3.1 document. Unlike the code generators it works
+// from the source schema rather than the IR, since OpenAPI 3.1 schemas are
+// JSON Schema and can be copied over mostly unchanged.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Southclaws/schemancer/schemancer/detect"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+	"github.com/goccy/go-yaml"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// Version is the OpenAPI version of the generated document
+const Version = "3.1.0"
+
+const componentsPrefix = "#/components/schemas/"
+
+// config holds OpenAPI-specific generator configuration
+type config struct {
+	// Output filename (default: "openapi.yaml"). A .json extension writes
+	// JSON, anything else writes YAML.
+	filename string
+
+	// info.title, defaults to the schema title
+	title string
+
+	// info.version (default: "1.0.0")
+	version string
+}
+
+// Option is an OpenAPI-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "openapi" }
+
+// WithFilename sets the output filename (default: "openapi.yaml"). A .json
+// extension produces a JSON document, anything else produces YAML.
+func WithFilename(name string) Option {
+	return Option{apply: func(c *config) {
+		c.filename = name
+	}}
+}
+
+// WithTitle sets info.title (default: the schema title)
+func WithTitle(title string) Option {
+	return Option{apply: func(c *config) {
+		c.title = title
+	}}
+}
+
+// WithVersion sets info.version (default: "1.0.0")
+func WithVersion(version string) Option {
+	return Option{apply: func(c *config) {
+		c.version = version
+	}}
+}
+
+type document struct {
+	OpenAPI    string     `json:"openapi"`
+	Info       info       `json:"info"`
+	Components components `json:"components"`
+}
+
+type info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// discriminator is the OpenAPI discriminator object
+type discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+type Generator struct{}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		filename: "openapi.yaml",
+		version:  "1.0.0",
+	}
+	for _, opt := range genOpts {
+		if oaOpt, ok := opt.(Option); ok {
+			oaOpt.apply(cfg)
+		}
+	}
+
+	if data.Schema == nil {
+		return nil, fmt.Errorf("openapi: no source schema")
+	}
+	root := data.Schema
+
+	title := cfg.title
+	if title == "" {
+		title = root.Title
+	}
+	if title == "" {
+		title = "Schemas"
+	}
+
+	schemas, err := buildComponents(root)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := document{
+		OpenAPI: Version,
+		Info: info{
+			Title:       title,
+			Description: root.Description,
+			Version:     cfg.version,
+		},
+		Components: components{Schemas: schemas},
+	}
+
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("openapi: %w", err)
+	}
+	if filepath.Ext(cfg.filename) == ".json" {
+		content = append(content, '\n')
+	} else {
+		content, err = toYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("openapi: %w", err)
+		}
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: cfg.filename,
+		Content:  content,
+	}}, nil
+}
+
+// toYAML converts JSON to YAML, preserving key order
+func toYAML(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.UnmarshalWithOptions(data, &v, yaml.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+	return yaml.MarshalWithOptions(v, yaml.IndentSequence(true))
+}
+
+// namedSchema is a definition that becomes a component
+type namedSchema struct {
+	name   string
+	schema *jsonschema.Schema
+}
+
+// buildComponents collects the hoisted $defs, root-level named schemas and
+// the root schema itself, and rewrites them as OpenAPI components.
+func buildComponents(root *jsonschema.Schema) (map[string]*jsonschema.Schema, error) {
+	var named []namedSchema
+	rootNamed := make(map[string]bool)
+	for name, def := range root.Definitions {
+		named = append(named, namedSchema{name, def})
+	}
+	for name, def := range root.Defs {
+		named = append(named, namedSchema{name, def})
+	}
+	for name, v := range root.Extra {
+		if strings.HasPrefix(name, "x-") {
+			continue
+		}
+		if _, ok := v.(map[string]any); !ok {
+			continue
+		}
+		if def := parseExtraSchema(v); def != nil {
+			named = append(named, namedSchema{name, def})
+			rootNamed[name] = true
+		}
+	}
+	if root.Title != "" && (root.Type == "object" || len(root.OneOf) > 0) {
+		named = append(named, namedSchema{root.Title, root})
+	}
+	sort.SliceStable(named, func(i, j int) bool { return named[i].name < named[j].name })
+
+	result := make(map[string]*jsonschema.Schema, len(named))
+	add := func(name string, s *jsonschema.Schema) error {
+		if _, exists := result[name]; exists {
+			return fmt.Errorf("openapi: duplicate component schema %q", name)
+		}
+		result[name] = s
+		return nil
+	}
+
+	rw := refRewriter{rootNamed: rootNamed}
+	if root.Title != "" {
+		rw.rootName = componentName(root.Title)
+	}
+
+	for _, n := range named {
+		name := componentName(n.name)
+		component := n.schema.CloneSchemas()
+
+		// Definitions are already hoisted to the top level by deref, and
+		// the root schema's keywords don't belong on a component.
+		component.Schema = ""
+		component.ID = ""
+		component.Defs = nil
+		component.Definitions = nil
+		if n.schema == root {
+			component.Extra = nil
+			for k, v := range root.Extra {
+				if !rootNamed[k] {
+					if component.Extra == nil {
+						component.Extra = make(map[string]any)
+					}
+					component.Extra[k] = v
+				}
+			}
+		}
+
+		delete(component.Extra, ir.SourceExtension)
+		delete(component.Extra, ir.SourceIDExtension)
+
+		rw.rewrite(component)
+
+		hoisted, err := discriminate(root, name, n.schema, component)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hoisted {
+			if err := add(h.name, h.schema); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := add(name, component); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// discriminate adds an OpenAPI discriminator to component when the original
+// schema is a discriminated union. OpenAPI mappings can only point at
+// references, so inline variants are hoisted to components of their own,
+// named after the union and discriminator value.
+func discriminate(root *jsonschema.Schema, name string, original, component *jsonschema.Schema) ([]namedSchema, error) {
+	if len(original.OneOf) == 0 {
+		return nil, nil
+	}
+
+	wrapperSchema := &jsonschema.Schema{
+		OneOf: original.OneOf,
+		Defs:  root.Defs,
+		Extra: root.Extra,
+	}
+	union, err := detect.DiscriminatedUnion(wrapperSchema)
+	if err != nil || union == nil {
+		return nil, err
+	}
+
+	var inline []detect.Variant
+	byName := make(map[string]detect.Variant)
+	for _, v := range union.Variants {
+		if v.Name == "" {
+			inline = append(inline, v)
+		} else {
+			byName[v.Name] = v
+		}
+	}
+
+	var hoisted []namedSchema
+	mapping := make(map[string]string)
+	for i, variant := range component.OneOf {
+		if variant.Ref != "" {
+			refName := variant.Ref[strings.LastIndex(variant.Ref, "/")+1:]
+			if v, ok := byName[refName]; ok {
+				mapping[v.ConstValue] = componentsPrefix + componentName(refName)
+			}
+			continue
+		}
+		if len(inline) == 0 {
+			continue
+		}
+		v := inline[0]
+		inline = inline[1:]
+
+		variantName := name + casing.ToPascalCase(v.ConstValue)
+		hoisted = append(hoisted, namedSchema{variantName, variant})
+		component.OneOf[i] = &jsonschema.Schema{Ref: componentsPrefix + variantName}
+		mapping[v.ConstValue] = componentsPrefix + variantName
+	}
+
+	if component.Extra == nil {
+		component.Extra = make(map[string]any)
+	}
+	component.Extra["discriminator"] = discriminator{
+		PropertyName: union.DiscriminatorField,
+		Mapping:      mapping,
+	}
+
+	return hoisted, nil
+}
+
+// refRewriter points local references at the component schemas
+type refRewriter struct {
+	// rootName is the component holding the root schema, if any
+	rootName string
+
+	// rootNamed holds the named schemas stored at the root of the document
+	rootNamed map[string]bool
+}
+
+func (rw refRewriter) rewrite(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+
+	schema.Ref = rw.ref(schema.Ref)
+	schema.DynamicRef = rw.ref(schema.DynamicRef)
+
+	for _, s := range []*jsonschema.Schema{
+		schema.Items, schema.AdditionalItems, schema.AdditionalProperties,
+		schema.Contains, schema.PropertyNames, schema.UnevaluatedItems,
+		schema.UnevaluatedProperties, schema.If, schema.Then, schema.Else,
+		schema.Not, schema.ContentSchema,
+	} {
+		rw.rewrite(s)
+	}
+	for _, list := range [][]*jsonschema.Schema{
+		schema.PrefixItems, schema.ItemsArray, schema.AllOf, schema.AnyOf, schema.OneOf,
+	} {
+		for _, s := range list {
+			rw.rewrite(s)
+		}
+	}
+	for _, m := range []map[string]*jsonschema.Schema{
+		schema.Properties, schema.PatternProperties, schema.DependentSchemas,
+		schema.DependencySchemas,
+	} {
+		for _, s := range m {
+			rw.rewrite(s)
+		}
+	}
+}
+
+// ref maps "#/$defs/X", "#/definitions/X" and root-level "#/X" references
+// to "#/components/schemas/X", keeping any remaining pointer. Other pointers
+// into the root schema are rebased onto the root component.
+func (rw refRewriter) ref(ref string) string {
+	if ref == "" || !strings.HasPrefix(ref, "#") {
+		return ref
+	}
+	pointer := strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/")
+
+	segment, rest, _ := strings.Cut(pointer, "/")
+	if segment == "$defs" || segment == "definitions" {
+		segment, rest, _ = strings.Cut(rest, "/")
+	} else if !rw.rootNamed[unescapePointer(segment)] {
+		if rw.rootName == "" {
+			return ref
+		}
+		if pointer == "" {
+			return componentsPrefix + rw.rootName
+		}
+		return componentsPrefix + rw.rootName + "/" + pointer
+	}
+
+	if rest != "" {
+		rest = "/" + rest
+	}
+	return componentsPrefix + componentName(unescapePointer(segment)) + rest
+}
+
+func unescapePointer(segment string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+}
+
+// componentName makes name a valid component key, which may only contain
+// letters, digits, ".", "-" and "_".
+func componentName(name string) string {
+	valid := name != ""
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '.' && r != '-' && r != '_' {
+			valid = false
+			break
+		}
+	}
+	if valid {
+		return name
+	}
+	return casing.ToPascalCase(name)
+}
+
+// parseExtraSchema converts a value from Schema.Extra into a jsonschema.Schema.
+func parseExtraSchema(v any) *jsonschema.Schema {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(jsonBytes, &schema); err != nil {
+		return nil
+	}
+
+	return &schema
+}
@@ -0,0 +1,784 @@
+package protobuf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+const (
+	// Filename is the name of the generated .proto file
+	Filename = "models.proto"
+
+	// LockFilename is the name of the lock file recording assigned field and
+	// enum value numbers, written next to the .proto file.
+	LockFilename = "models.lock.json"
+
+	// FieldExtension pins the field number of a property in the schema.
+	FieldExtension = "x-proto-field"
+)
+
+const (
+	maxFieldNumber      = 536870911
+	firstReservedNumber = 19000 // 19000-19999 are reserved for the protobuf implementation
+	lastReservedNumber  = 19999
+)
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in
+// Protocol Buffers. The import is the .proto file that defines the type.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "bytes"},
+	ir.IRFormatDateTime: {Type: "google.protobuf.Timestamp", Import: "google/protobuf/timestamp.proto"},
+	ir.IRFormatDate:     {Type: "string"},
+	ir.IRFormatUUID:     {Type: "string"},
+	ir.IRFormatEmail:    {Type: "string"},
+	ir.IRFormatURI:      {Type: "string"},
+}
+
+// scalarTypes are the protobuf types that have no field presence unless
+// marked optional.
+var scalarTypes = map[string]bool{
+	"double": true, "float": true, "int32": true, "int64": true, "uint32": true,
+	"uint64": true, "sint32": true, "sint64": true, "fixed32": true,
+	"fixed64": true, "sfixed32": true, "sfixed64": true, "bool": true,
+	"string": true, "bytes": true,
+}
+
+// Lock records the numbers assigned to message fields and enum values so they
+// stay stable across regenerations. Message fields are keyed by JSON name,
+// oneof members by discriminator value and enum values by their string value.
+// Entries are never removed: numbers that no longer appear in the schema are
+// emitted as reserved so they cannot be reused.
+type Lock struct {
+	Messages map[string]map[string]int `json:"messages"`
+	Enums    map[string]map[string]int `json:"enums"`
+}
+
+// config holds Protobuf-specific generator configuration
+type config struct {
+	packageName string
+	goPackage   string
+	lock        *Lock
+}
+
+// Option is a Protobuf-specific generator option
+type Option struct {
+	apply func(*config) error
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "protobuf" }
+
+// WithPackageName sets the protobuf package name for generated code
+func WithPackageName(name string) Option {
+	return Option{apply: func(c *config) error {
+		c.packageName = name
+		return nil
+	}}
+}
+
+// WithGoPackage sets the go_package file option
+func WithGoPackage(pkg string) Option {
+	return Option{apply: func(c *config) error {
+		c.goPackage = pkg
+		return nil
+	}}
+}
+
+// WithLock enables the lock file. The existing lock file contents are used to
+// keep numbers stable, and may be empty when no lock exists yet. The updated
+// lock is returned as a second generated file named LockFilename.
+func WithLock(existing []byte) Option {
+	return Option{apply: func(c *config) error {
+		lock := &Lock{}
+		if len(bytes.TrimSpace(existing)) > 0 {
+			if err := json.Unmarshal(existing, lock); err != nil {
+				return fmt.Errorf("invalid protobuf lock file: %w", err)
+			}
+		}
+		if lock.Messages == nil {
+			lock.Messages = make(map[string]map[string]int)
+		}
+		if lock.Enums == nil {
+			lock.Enums = make(map[string]map[string]int)
+		}
+		c.lock = lock
+		return nil
+	}}
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{
+		packageName: "generated",
+	}
+	for _, opt := range genOpts {
+		if protoOpt, ok := opt.(Option); ok {
+			if err := protoOpt.apply(cfg); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Without a lock file, numbers are still derived from x-proto-field and
+	// declaration order, the lock just isn't persisted.
+	lock := cfg.lock
+	if lock == nil {
+		lock = &Lock{
+			Messages: make(map[string]map[string]int),
+			Enums:    make(map[string]map[string]int),
+		}
+	}
+
+	b := &builder{
+		formatMappings: g.getFormatMappings(opts),
+		typeIndex:      buildTypeIndex(data.Types),
+		lock:           lock,
+		imports:        make(map[string]bool),
+	}
+	tplData, err := b.build(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := template.FuncMap{
+		"comment":      formatComment,
+		"fieldComment": formatFieldComment,
+		"joinInts":     joinInts,
+		"joinNames":    joinNames,
+	}
+
+	tmpl, err := template.New("protobuf").Funcs(funcs).Parse(protoTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	files := []generators.GeneratedFile{{
+		Filename: Filename,
+		Content:  buf.Bytes(),
+	}}
+
+	if cfg.lock != nil {
+		lockData, err := json.MarshalIndent(cfg.lock, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, generators.GeneratedFile{
+			Filename: LockFilename,
+			Content:  append(lockData, '\n'),
+		})
+	}
+
+	return files, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "  ")
+}
+
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	lines := strings.Split(description, "\n")
+	var result []string
+	for _, line := range lines {
+		result = append(result, strings.TrimRight(indent+"// "+line, " "))
+	}
+	return strings.Join(result, "\n")
+}
+
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func joinNames(names []string) string {
+	parts := make([]string, len(names))
+	for i, n := range names {
+		parts[i] = strconv.Quote(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// toSnake converts a name to a snake_case protobuf identifier, dropping any
+// characters that are not valid in identifiers.
+func toSnake(s string) string {
+	var words []string
+	for _, w := range casing.SplitWords(s) {
+		var word []rune
+		for _, r := range w {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				word = append(word, unicode.ToLower(r))
+			}
+		}
+		if len(word) > 0 {
+			words = append(words, string(word))
+		}
+	}
+	name := strings.Join(words, "_")
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "field_" + name
+	}
+	return name
+}
+
+// protoJSONName returns the JSON name protobuf derives from a field name by
+// default, which is the lowerCamelCase form of the snake_case name.
+func protoJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+type templateData struct {
+	Package   string
+	GoPackage string
+	Imports   []string
+	Decls     []decl
+}
+
+// decl is a top-level message or enum declaration
+type decl struct {
+	Message *message
+	Enum    *enum
+}
+
+type message struct {
+	Name          string
+	Description   string
+	Fields        []field
+	Oneof         *oneof
+	Reserved      []int
+	ReservedNames []string
+}
+
+type oneof struct {
+	Name   string
+	Fields []field
+}
+
+type field struct {
+	Description string
+	Label       string
+	Type        string
+	Name        string
+	Number      int
+	Options     string
+}
+
+type enum struct {
+	Name          string
+	Description   string
+	Values        []enumValue
+	Reserved      []int
+	ReservedNames []string
+}
+
+type enumValue struct {
+	Name   string
+	Number int
+}
+
+type builder struct {
+	formatMappings map[ir.IRFormat]generators.FormatTypeMapping
+	typeIndex      map[string]ir.IRType
+	lock           *Lock
+	imports        map[string]bool
+}
+
+func (b *builder) build(cfg *config, data *ir.IR) (templateData, error) {
+	var decls []decl
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindStruct:
+			m, err := b.message(t.Name, t.Description, t.Fields, "")
+			if err != nil {
+				return templateData{}, err
+			}
+			decls = append(decls, decl{Message: m})
+
+		case ir.IRKindEnum:
+			decls = append(decls, decl{Enum: b.enum(t)})
+
+		case ir.IRKindDiscriminatedUnion:
+			if t.Union == nil {
+				continue
+			}
+			m, err := b.unionMessage(t)
+			if err != nil {
+				return templateData{}, err
+			}
+			decls = append(decls, decl{Message: m})
+			for _, v := range t.Union.Variants {
+				vm, err := b.message(v.Name, v.Type.Description, v.Type.Fields, t.Union.DiscriminatorJSON)
+				if err != nil {
+					return templateData{}, err
+				}
+				decls = append(decls, decl{Message: vm})
+			}
+
+		case ir.IRKindAlias, ir.IRKindUnion:
+			// Protobuf has no type aliases, references are resolved inline
+		}
+	}
+
+	var imports []string
+	for imp := range b.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	return templateData{
+		Package:   cfg.packageName,
+		GoPackage: cfg.goPackage,
+		Imports:   imports,
+		Decls:     decls,
+	}, nil
+}
+
+// fieldType describes how a property maps onto a protobuf field
+type fieldType struct {
+	Type     string
+	Repeated bool
+	IsMap    bool
+}
+
+// resolve maps an IR type reference to a protobuf field type. Aliases and
+// non-discriminated unions are resolved inline. Protobuf cannot nest repeated
+// or map fields directly, so nested collections fall back to the well-known
+// ListValue and Struct types.
+func (b *builder) resolve(ref *ir.IRTypeRef) fieldType {
+	return b.resolveVisited(ref, make(map[string]bool))
+}
+
+func (b *builder) resolveVisited(ref *ir.IRTypeRef, visited map[string]bool) fieldType {
+	if mapping, ok := b.formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			b.imports[mapping.Import] = true
+		}
+		return fieldType{Type: mapping.Type}
+	}
+
+	switch ref.Builtin {
+	case ir.IRBuiltinString:
+		return fieldType{Type: "string"}
+	case ir.IRBuiltinInt:
+		return fieldType{Type: "int64"}
+	case ir.IRBuiltinFloat:
+		return fieldType{Type: "double"}
+	case ir.IRBuiltinBool:
+		return fieldType{Type: "bool"}
+	case ir.IRBuiltinAny:
+		return b.wellKnown("Value")
+	}
+
+	if ref.Array != nil {
+		elem := b.resolveVisited(ref.Array, visited)
+		return fieldType{Type: b.element(elem), Repeated: true}
+	}
+	if ref.Map != nil {
+		elem := b.resolveVisited(ref.Map, visited)
+		return fieldType{Type: "map<string, " + b.element(elem) + ">", IsMap: true}
+	}
+	if ref.Name != "" {
+		t, ok := b.typeIndex[ref.Name]
+		if !ok {
+			return fieldType{Type: ref.Name}
+		}
+		switch t.Kind {
+		case ir.IRKindAlias:
+			if t.Element == nil || visited[t.Name] {
+				return b.wellKnown("Value")
+			}
+			visited[t.Name] = true
+			return b.resolveVisited(t.Element, visited)
+		case ir.IRKindUnion:
+			return b.wellKnown("Value")
+		}
+		return fieldType{Type: ref.Name}
+	}
+	return b.wellKnown("Value")
+}
+
+// element returns the type to use for a collection element
+func (b *builder) element(elem fieldType) string {
+	switch {
+	case elem.Repeated:
+		return b.wellKnown("ListValue").Type
+	case elem.IsMap:
+		return b.wellKnown("Struct").Type
+	}
+	return elem.Type
+}
+
+func (b *builder) wellKnown(name string) fieldType {
+	b.imports["google/protobuf/struct.proto"] = true
+	return fieldType{Type: "google.protobuf." + name}
+}
+
+// hasPresence reports whether a field of this type tracks presence without
+// the optional label.
+func (b *builder) hasPresence(ft fieldType) bool {
+	if ft.Repeated || ft.IsMap {
+		return true
+	}
+	if scalarTypes[ft.Type] {
+		return false
+	}
+	t, ok := b.typeIndex[ft.Type]
+	return !ok || t.Kind != ir.IRKindEnum
+}
+
+// numberer assigns stable numbers within one message or enum
+type numberer struct {
+	owner  string
+	locked map[string]int
+	used   map[int]string
+}
+
+func newNumberer(owner string, locked map[string]int) *numberer {
+	used := make(map[int]string, len(locked))
+	for key, n := range locked {
+		used[n] = key
+	}
+	return &numberer{owner: owner, locked: locked, used: used}
+}
+
+// pin records an explicit number for key, failing if another key already
+// holds it in this run or in the lock file.
+func (n *numberer) pin(key string, number int) error {
+	if number < 1 || number > maxFieldNumber || (number >= firstReservedNumber && number <= lastReservedNumber) {
+		return fmt.Errorf("%s.%s: %s %d is not a valid field number", n.owner, key, FieldExtension, number)
+	}
+	if holder, ok := n.used[number]; ok && holder != key {
+		return fmt.Errorf("%s.%s: field number %d is already assigned to %q", n.owner, key, number, holder)
+	}
+	if locked, ok := n.locked[key]; ok && locked != number {
+		return fmt.Errorf("%s.%s: %s %d conflicts with locked field number %d, remove the lock entry to renumber the field", n.owner, key, FieldExtension, number, locked)
+	}
+	n.used[number] = key
+	n.locked[key] = number
+	return nil
+}
+
+// next returns the locked number for key, or assigns the lowest free one.
+func (n *numberer) next(key string) int {
+	if number, ok := n.locked[key]; ok {
+		return number
+	}
+	number := 1
+	for {
+		if number == firstReservedNumber {
+			number = lastReservedNumber + 1
+		}
+		if _, taken := n.used[number]; !taken {
+			break
+		}
+		number++
+	}
+	n.used[number] = key
+	n.locked[key] = number
+	return number
+}
+
+// reserved returns the locked numbers and keys not in current, sorted.
+func (n *numberer) reserved(current map[string]bool) ([]int, []string) {
+	var numbers []int
+	var keys []string
+	for key, number := range n.locked {
+		if !current[key] {
+			numbers = append(numbers, number)
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(numbers)
+	sort.Strings(keys)
+	return numbers, keys
+}
+
+func (b *builder) lockedMessage(name string) map[string]int {
+	if b.lock.Messages[name] == nil {
+		b.lock.Messages[name] = make(map[string]int)
+	}
+	return b.lock.Messages[name]
+}
+
+func (b *builder) message(name, description string, irFields []ir.IRField, discriminatorJSON string) (*message, error) {
+	num := newNumberer(name, b.lockedMessage(name))
+
+	var fields []ir.IRField
+	for _, f := range irFields {
+		if f.JSONName != discriminatorJSON {
+			fields = append(fields, f)
+		}
+	}
+
+	// Explicit numbers take precedence over the lock file and are pinned
+	// before any new numbers are handed out.
+	for _, f := range fields {
+		raw, ok := f.Extensions[FieldExtension]
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s must be an integer, got %q", name, f.JSONName, FieldExtension, raw)
+		}
+		if err := num.pin(f.JSONName, number); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &message{Name: name, Description: description}
+	current := make(map[string]bool)
+	names := make(map[string]int)
+	for _, f := range fields {
+		current[f.JSONName] = true
+
+		fieldName := toSnake(f.JSONName)
+		names[fieldName]++
+		if n := names[fieldName]; n > 1 {
+			fieldName += "_" + strconv.Itoa(n)
+		}
+
+		ft := b.resolve(&f.Type)
+		label := ""
+		switch {
+		case ft.Repeated:
+			label = "repeated"
+		case (!f.Required || f.Type.Nullable) && !b.hasPresence(ft):
+			label = "optional"
+		}
+
+		options := ""
+		if protoJSONName(fieldName) != f.JSONName {
+			options = fmt.Sprintf(" [json_name = %s]", strconv.Quote(f.JSONName))
+		}
+
+		m.Fields = append(m.Fields, field{
+			Description: f.Description,
+			Label:       label,
+			Type:        ft.Type,
+			Name:        fieldName,
+			Number:      num.next(f.JSONName),
+			Options:     options,
+		})
+	}
+
+	// A stale key may map to the same field name as a current property, for
+	// example when a property is renamed from snake_case to camelCase.
+	var staleKeys []string
+	m.Reserved, staleKeys = num.reserved(current)
+	for _, key := range staleKeys {
+		if fieldName := toSnake(key); names[fieldName] == 0 {
+			m.ReservedNames = append(m.ReservedNames, fieldName)
+		}
+	}
+	return m, nil
+}
+
+// unionMessage renders a discriminated union as a message holding a oneof of
+// its variants, keyed by discriminator value.
+func (b *builder) unionMessage(t ir.IRType) (*message, error) {
+	num := newNumberer(t.Name, b.lockedMessage(t.Name))
+
+	o := &oneof{Name: toSnake(t.Name)}
+	current := make(map[string]bool)
+	for _, v := range t.Union.Variants {
+		current[v.ConstValue] = true
+		o.Fields = append(o.Fields, field{
+			Description: v.Type.Description,
+			Type:        v.Name,
+			Name:        toSnake(v.ConstValue),
+			Number:      num.next(v.ConstValue),
+		})
+	}
+
+	m := &message{Name: t.Name, Description: t.Description, Oneof: o}
+	m.Reserved, _ = num.reserved(current)
+	return m, nil
+}
+
+func (b *builder) enum(t ir.IRType) *enum {
+	prefix := strings.ToUpper(toSnake(t.Name)) + "_"
+	e := &enum{Name: t.Name, Description: t.Description}
+
+	// Integer enums use their own values as numbers, which are already
+	// stable. A zero value must come first in proto3.
+	if t.EnumType == ir.IRBuiltinInt {
+		var values []enumValue
+		hasZero := false
+		for _, v := range t.EnumValues {
+			if v.IsNull || v.IntValue == nil {
+				continue
+			}
+			if *v.IntValue == 0 {
+				hasZero = true
+			}
+			values = append(values, enumValue{
+				Name:   prefix + strings.ReplaceAll(v.StringValue, "-", "NEG_"),
+				Number: *v.IntValue,
+			})
+		}
+		sort.SliceStable(values, func(i, j int) bool {
+			return values[i].Number == 0 && values[j].Number != 0
+		})
+		if !hasZero {
+			values = append([]enumValue{{Name: prefix + "UNSPECIFIED", Number: 0}}, values...)
+		}
+		e.Values = values
+		return e
+	}
+
+	if b.lock.Enums[t.Name] == nil {
+		b.lock.Enums[t.Name] = make(map[string]int)
+	}
+	num := newNumberer(t.Name, b.lock.Enums[t.Name])
+
+	e.Values = append(e.Values, enumValue{Name: prefix + "UNSPECIFIED", Number: 0})
+	current := make(map[string]bool)
+	for _, v := range t.EnumValues {
+		if v.IsNull {
+			continue
+		}
+		current[v.StringValue] = true
+		e.Values = append(e.Values, enumValue{
+			Name:   enumValueName(prefix, v.StringValue),
+			Number: num.next(v.StringValue),
+		})
+	}
+
+	var staleKeys []string
+	e.Reserved, staleKeys = num.reserved(current)
+	for _, key := range staleKeys {
+		e.ReservedNames = append(e.ReservedNames, enumValueName(prefix, key))
+	}
+	return e
+}
+
+func enumValueName(prefix, value string) string {
+	name := strings.ToUpper(toSnake(value))
+	if strings.HasPrefix(name, "FIELD_") {
+		name = "VALUE_" + strings.TrimPrefix(name, "FIELD_")
+	}
+	return prefix + name
+}
+
+const protoTemplate = `syntax = "proto3";
+
+package {{.Package}};
+{{- if .Imports}}
+{{range .Imports}}
+import "{{.}}";
+{{- end}}
+{{- end}}
+{{- if .GoPackage}}
+
+option go_package = "{{.GoPackage}}";
+{{- end}}
+{{- range .Decls}}
+{{- if .Message}}
+{{template "message" .Message}}
+{{- else if .Enum}}
+{{template "enum" .Enum}}
+{{- end}}
+{{- end}}
+
+{{- define "message"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+message {{.Name}} {
+{{- if .Reserved}}
+  reserved {{joinInts .Reserved}};
+{{- end}}
+{{- if .ReservedNames}}
+  reserved {{joinNames .ReservedNames}};
+{{- end}}
+{{- if .Oneof}}
+  oneof {{.Oneof.Name}} {
+{{- range .Oneof.Fields}}
+    {{.Type}} {{.Name}} = {{.Number}};
+{{- end}}
+  }
+{{- end}}
+{{- range .Fields}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+  {{if .Label}}{{.Label}} {{end}}{{.Type}} {{.Name}} = {{.Number}}{{.Options}};
+{{- end}}
+}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+enum {{.Name}} {
+{{- if .Reserved}}
+  reserved {{joinInts .Reserved}};
+{{- end}}
+{{- if .ReservedNames}}
+  reserved {{joinNames .ReservedNames}};
+{{- end}}
+{{- range .Values}}
+  {{.Name}} = {{.Number}};
+{{- end}}
+}
+{{- end}}
+`
@@ -0,0 +1,597 @@
+package rust
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// rustReservedWords contains Rust keywords. Most can be used as identifiers
+// via the raw identifier syntax (r#type), see rustRawForbidden for the rest.
+var rustReservedWords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true,
+	"continue": true, "crate": true, "dyn": true, "else": true, "enum": true,
+	"extern": true, "false": true, "fn": true, "for": true, "if": true,
+	"impl": true, "in": true, "let": true, "loop": true, "match": true,
+	"mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "self": true, "static": true, "struct": true, "super": true,
+	"trait": true, "true": true, "type": true, "unsafe": true, "use": true,
+	"where": true, "while": true, "abstract": true, "become": true, "box": true,
+	"do": true, "final": true, "gen": true, "macro": true, "override": true,
+	"priv": true, "try": true, "typeof": true, "unsized": true, "virtual": true,
+	"yield": true,
+}
+
+// rustRawForbidden contains keywords that cannot be used as raw identifiers.
+// These get a trailing underscore instead.
+var rustRawForbidden = map[string]bool{
+	"crate": true, "self": true, "super": true,
+}
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in Rust.
+// Types are fully qualified so no use declarations are needed.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "Vec<u8>"},
+	ir.IRFormatDateTime: {Type: "chrono::DateTime<chrono::Utc>"},
+	ir.IRFormatDate:     {Type: "chrono::NaiveDate"},
+	ir.IRFormatUUID:     {Type: "uuid::Uuid"},
+	ir.IRFormatEmail:    {Type: "String"},
+	ir.IRFormatURI:      {Type: "String"},
+}
+
+// config holds Rust-specific generator configuration
+type config struct {
+	// No specific options yet, but structure is ready for future options
+}
+
+// Option is a Rust-specific generator option
+type Option struct {
+	apply func(*config)
+}
+
+// OptionValue implements generators.GeneratorOption
+func (Option) OptionValue() string { return "rust" }
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	cfg := &config{}
+	for _, opt := range genOpts {
+		if rsOpt, ok := opt.(Option); ok {
+			rsOpt.apply(cfg)
+		}
+	}
+
+	formatMappings := g.getFormatMappings(opts)
+	recursive := computeRecursiveRefs(data.Types)
+
+	funcs := template.FuncMap{
+		"pascal":        casing.ToPascalCase,
+		"snake":         casing.ToSnakeCase,
+		"rustType":      makeRustTypeFunc(formatMappings, recursive),
+		"fieldName":     safeFieldName,
+		"fieldAttr":     fieldAttr,
+		"comment":       formatComment,
+		"fieldComment":  formatFieldComment,
+		"isIntEnum":     isIntEnum,
+		"toEnumKey":     toEnumKey,
+		"unionVariants": makeUnionVariantsFunc(formatMappings),
+	}
+
+	tmpl, err := template.New("rust").Funcs(funcs).Parse(rustTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tplData := prepareTemplateData(data, formatMappings)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: "models.rs",
+		Content:  buf.Bytes(),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "    ")
+}
+
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	lines := strings.Split(description, "\n")
+	var result []string
+	for _, line := range lines {
+		if line == "" {
+			result = append(result, indent+"///")
+		} else {
+			result = append(result, indent+"/// "+line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// isIntEnum returns true if the enum has an integer type
+func isIntEnum(t ir.IRType) bool {
+	return t.EnumType == ir.IRBuiltinInt
+}
+
+// toEnumKey converts an enum value to a valid Rust enum variant name
+func toEnumKey(v ir.IREnumValue) string {
+	if v.IntValue != nil {
+		return "Value" + strings.ReplaceAll(v.StringValue, "-", "Neg")
+	}
+	key := casing.ToPascalCase(v.StringValue)
+	if key == "" {
+		return "Empty"
+	}
+	if key[0] >= '0' && key[0] <= '9' {
+		return "Value" + key
+	}
+	return key
+}
+
+// safeFieldName converts a field's JSON name to snake_case, using raw
+// identifier syntax for Rust keywords so the serde field name is unchanged.
+// The JSON name is used rather than the Go-style symbol name so acronyms such
+// as "httpStatus" become http_status rather than httpstatus.
+func safeFieldName(field ir.IRField) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, casing.ToSnakeCase(field.JSONName))
+	name = strings.Trim(name, "_")
+	if name == "" {
+		name = casing.ToSnakeCase(field.Name)
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "n" + name
+	}
+	if rustRawForbidden[name] {
+		return name + "_"
+	}
+	if rustReservedWords[name] {
+		return "r#" + name
+	}
+	return name
+}
+
+// fieldAttr renders the #[serde(...)] attribute for a field, if any is needed.
+// Fields are renamed when the Rust name differs from the JSON name, and
+// optional fields are omitted from the output when None.
+func fieldAttr(field ir.IRField) string {
+	var parts []string
+	if strings.TrimPrefix(safeFieldName(field), "r#") != field.JSONName {
+		parts = append(parts, `rename = "`+field.JSONName+`"`)
+	}
+	if !field.Required {
+		parts = append(parts, `skip_serializing_if = "Option::is_none"`)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "#[serde(" + strings.Join(parts, ", ") + ")]"
+}
+
+type templateData struct {
+	Uses  []string
+	Types []ir.IRType
+}
+
+func prepareTemplateData(data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) templateData {
+	useSet := make(map[string]bool)
+	useSet["serde::{Deserialize, Serialize}"] = true
+
+	for _, t := range data.Types {
+		switch t.Kind {
+		case ir.IRKindDiscriminatedUnion:
+			if t.Union != nil {
+				for _, v := range t.Union.Variants {
+					collectUsesFromType(v.Type, formatMappings, useSet)
+				}
+			}
+		case ir.IRKindUnion:
+			if t.SimpleUnion != nil {
+				for i := range t.SimpleUnion.Variants {
+					collectUsesFromRef(&t.SimpleUnion.Variants[i], formatMappings, useSet)
+				}
+			}
+		default:
+			collectUsesFromType(t, formatMappings, useSet)
+		}
+	}
+
+	var uses []string
+	for u := range useSet {
+		uses = append(uses, u)
+	}
+	sort.Strings(uses)
+
+	return templateData{
+		Uses:  uses,
+		Types: data.Types,
+	}
+}
+
+func collectUsesFromType(t ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, useSet map[string]bool) {
+	for _, field := range t.Fields {
+		collectUsesFromRef(&field.Type, formatMappings, useSet)
+	}
+	if t.Element != nil {
+		collectUsesFromRef(t.Element, formatMappings, useSet)
+	}
+}
+
+func collectUsesFromRef(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, useSet map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if mapping, ok := formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			useSet[mapping.Import] = true
+		}
+		return
+	}
+	if ref.Map != nil {
+		useSet["std::collections::HashMap"] = true
+		collectUsesFromRef(ref.Map, formatMappings, useSet)
+	}
+	if ref.Array != nil {
+		collectUsesFromRef(ref.Array, formatMappings, useSet)
+	}
+}
+
+// computeRecursiveRefs finds, for each type, the named types it holds by value
+// that eventually hold the type itself by value. Such fields must be boxed or
+// the type would have infinite size. Vec and HashMap already provide
+// indirection, so only direct named references are followed.
+func computeRecursiveRefs(types []ir.IRType) map[string]map[string]bool {
+	edges := make(map[string]map[string]bool)
+	addEdge := func(from string, ref *ir.IRTypeRef) {
+		if ref == nil || ref.Name == "" || ref.Array != nil || ref.Map != nil {
+			return
+		}
+		if edges[from] == nil {
+			edges[from] = make(map[string]bool)
+		}
+		edges[from][ref.Name] = true
+	}
+
+	for _, t := range types {
+		for i := range t.Fields {
+			addEdge(t.Name, &t.Fields[i].Type)
+		}
+		addEdge(t.Name, t.Element)
+		if t.Union != nil {
+			for _, v := range t.Union.Variants {
+				addEdge(t.Name, &ir.IRTypeRef{Name: v.Name})
+				for i := range v.Type.Fields {
+					addEdge(v.Name, &v.Type.Fields[i].Type)
+				}
+			}
+		}
+		if t.SimpleUnion != nil {
+			for i := range t.SimpleUnion.Variants {
+				addEdge(t.Name, &t.SimpleUnion.Variants[i])
+			}
+		}
+	}
+
+	reaches := func(from, target string) bool {
+		visited := make(map[string]bool)
+		var walk func(string) bool
+		walk = func(name string) bool {
+			if name == target {
+				return true
+			}
+			if visited[name] {
+				return false
+			}
+			visited[name] = true
+			for next := range edges[name] {
+				if walk(next) {
+					return true
+				}
+			}
+			return false
+		}
+		return walk(from)
+	}
+
+	result := make(map[string]map[string]bool)
+	for owner, targets := range edges {
+		for target := range targets {
+			if reaches(target, owner) {
+				if result[owner] == nil {
+					result[owner] = make(map[string]bool)
+				}
+				result[owner][target] = true
+			}
+		}
+	}
+	return result
+}
+
+func makeRustTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping, recursive map[string]map[string]bool) func(string, *ir.IRTypeRef, bool) string {
+	var rustType func(*ir.IRTypeRef) string
+	rustType = func(ref *ir.IRTypeRef) string {
+		if mapping, ok := formatMappings[ref.Format]; ok {
+			return mapping.Type
+		}
+
+		if ref.Builtin != ir.IRBuiltinNone {
+			switch ref.Builtin {
+			case ir.IRBuiltinString:
+				return "String"
+			case ir.IRBuiltinInt:
+				return "i64"
+			case ir.IRBuiltinFloat:
+				return "f64"
+			case ir.IRBuiltinBool:
+				return "bool"
+			}
+			return "serde_json::Value"
+		}
+		if ref.Array != nil {
+			return "Vec<" + rustType(ref.Array) + ">"
+		}
+		if ref.Map != nil {
+			return "HashMap<String, " + rustType(ref.Map) + ">"
+		}
+		if ref.Name != "" {
+			return ref.Name
+		}
+		return "serde_json::Value"
+	}
+
+	return func(owner string, ref *ir.IRTypeRef, required bool) string {
+		baseType := rustType(ref)
+
+		if ref.Name != "" && ref.Array == nil && ref.Map == nil && recursive[owner][ref.Name] {
+			baseType = "Box<" + baseType + ">"
+		}
+
+		// serde_json::Value already represents null, so a nullable value is
+		// left bare. Optional fields are always wrapped so they can be skipped.
+		if !required || (ref.Nullable && baseType != "serde_json::Value") {
+			return "Option<" + baseType + ">"
+		}
+		return baseType
+	}
+}
+
+// unionVariant is a single arm of an untagged enum for a non-discriminated union.
+type unionVariant struct {
+	Name string
+	Type *ir.IRTypeRef
+	Null bool
+}
+
+// makeUnionVariantsFunc returns a template function that names each variant of
+// a non-discriminated union after its type, deduplicating collisions.
+func makeUnionVariantsFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping) func(*ir.IRUnion) []unionVariant {
+	var variantName func(*ir.IRTypeRef) string
+	variantName = func(ref *ir.IRTypeRef) string {
+		if ref.Format != ir.IRFormatNone {
+			if _, ok := formatMappings[ref.Format]; ok {
+				return casing.ToPascalCase(string(ref.Format))
+			}
+		}
+		switch {
+		case ref.Builtin == ir.IRBuiltinString:
+			return "String"
+		case ref.Builtin == ir.IRBuiltinInt:
+			return "Integer"
+		case ref.Builtin == ir.IRBuiltinFloat:
+			return "Number"
+		case ref.Builtin == ir.IRBuiltinBool:
+			return "Boolean"
+		case ref.Array != nil:
+			return variantName(ref.Array) + "List"
+		case ref.Map != nil:
+			return "Object"
+		case ref.Name != "":
+			return ref.Name
+		}
+		return "Value"
+	}
+
+	return func(u *ir.IRUnion) []unionVariant {
+		if u == nil {
+			return nil
+		}
+		seen := make(map[string]int)
+		var result []unionVariant
+		for i := range u.Variants {
+			ref := &u.Variants[i]
+			if ref.Builtin == ir.IRBuiltinAny && ref.Nullable {
+				result = append(result, unionVariant{Name: "Null", Null: true})
+				continue
+			}
+			name := variantName(ref)
+			seen[name]++
+			if seen[name] > 1 {
+				name = fmt.Sprintf("%s%d", name, seen[name])
+			}
+			result = append(result, unionVariant{Name: name, Type: ref})
+		}
+		return result
+	}
+}
+
+const rustTemplate = `{{range $i, $u := .Uses}}{{if $i}}
+{{end}}use {{$u}};{{end}}
+{{- range .Types}}
+{{- if eq .Kind "struct"}}
+{{template "struct" .}}
+{{- else if eq .Kind "alias"}}
+{{template "alias" .}}
+{{- else if eq .Kind "enum"}}
+{{template "enum" .}}
+{{- else if eq .Kind "discriminated_union"}}
+{{template "union" .}}
+{{- else if eq .Kind "union"}}
+{{template "simpleunion" .}}
+{{- end}}
+{{- end}}
+
+{{- define "struct"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct {{.Name}} {
+{{- $owner := .Name}}
+{{- range .Fields}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+{{- with fieldAttr .}}
+    {{.}}
+{{- end}}
+    pub {{fieldName .}}: {{rustType $owner .Type .Required}},
+{{- end}}
+}
+{{- end}}
+
+{{- define "alias"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if .Element}}
+pub type {{.Name}} = {{rustType .Name .Element true}};
+{{- else}}
+pub type {{.Name}} = serde_json::Value;
+{{- end}}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if isIntEnum .}}
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+#[serde(into = "i64", try_from = "i64")]
+#[repr(i64)]
+pub enum {{.Name}} {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+    {{toEnumKey .}} = {{.IntValue}},
+{{- end}}
+{{- end}}
+}
+
+impl From<{{.Name}}> for i64 {
+    fn from(value: {{.Name}}) -> Self {
+        value as i64
+    }
+}
+
+impl TryFrom<i64> for {{.Name}} {
+    type Error = String;
+
+    fn try_from(value: i64) -> std::result::Result<Self, Self::Error> {
+        match value {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+            {{.IntValue}} => Ok(Self::{{toEnumKey .}}),
+{{- end}}
+{{- end}}
+            _ => Err(format!("invalid {{.Name}} value: {}", value)),
+        }
+    }
+}
+{{- else}}
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum {{.Name}} {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+    #[serde(rename = "{{.StringValue}}")]
+    {{toEnumKey .}},
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "union"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(tag = "{{.Union.DiscriminatorJSON}}")]
+pub enum {{.Name}} {
+{{- range .Union.Variants}}
+    #[serde(rename = "{{.ConstValue}}")]
+    {{.Name}}({{.Name}}),
+{{- end}}
+}
+{{- range .Union.Variants}}
+{{$variant := .Name}}
+{{- if .Type.Description}}
+{{comment .Type.Description}}
+{{- end}}
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct {{.Name}} {
+{{- range .Type.Fields}}
+{{- if ne .JSONName $.Union.DiscriminatorJSON}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+{{- with fieldAttr .}}
+    {{.}}
+{{- end}}
+    pub {{fieldName .}}: {{rustType $variant .Type .Required}},
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "simpleunion"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(untagged)]
+pub enum {{.Name}} {
+{{- $owner := .Name}}
+{{- range unionVariants .SimpleUnion}}
+{{- if .Null}}
+    {{.Name}},
+{{- else}}
+    {{.Name}}({{rustType $owner .Type true}}),
+{{- end}}
+{{- end}}
+}
+{{- end}}
+`
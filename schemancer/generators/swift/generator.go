@@ -0,0 +1,839 @@
+package swift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/ir"
+)
+
+// swiftReservedWords contains Swift keywords that must be escaped with
+// backticks when used as identifiers.
+var swiftReservedWords = map[string]bool{
+	"associatedtype": true, "class": true, "deinit": true, "enum": true,
+	"extension": true, "fileprivate": true, "func": true, "import": true,
+	"init": true, "inout": true, "internal": true, "let": true, "open": true,
+	"operator": true, "private": true, "protocol": true, "public": true,
+	"rethrows": true, "static": true, "struct": true, "subscript": true,
+	"typealias": true, "var": true, "break": true, "case": true, "catch": true,
+	"continue": true, "default": true, "defer": true, "do": true, "else": true,
+	"fallthrough": true, "for": true, "guard": true, "if": true, "in": true,
+	"repeat": true, "return": true, "switch": true, "throw": true, "where": true,
+	"while": true, "as": true, "false": true, "is": true, "nil": true,
+	"self": true, "super": true, "throws": true, "true": true, "try": true,
+}
+
+// DefaultFormatMappings provides sensible defaults for JSON Schema formats in Swift.
+// Date fields require the decoder's dateDecodingStrategy to be set to .iso8601.
+var DefaultFormatMappings = map[ir.IRFormat]generators.FormatTypeMapping{
+	ir.IRFormatByte:     {Type: "Data"}, // Base64 encoded by JSONEncoder
+	ir.IRFormatDateTime: {Type: "Date"},
+	ir.IRFormatDate:     {Type: "String"},
+	ir.IRFormatUUID:     {Type: "UUID"},
+	ir.IRFormatEmail:    {Type: "String"},
+	ir.IRFormatURI:      {Type: "String"},
+}
+
+type Generator struct{}
+
+func (g *Generator) getFormatMappings(opts generators.GeneratorOptions) map[ir.IRFormat]generators.FormatTypeMapping {
+	result := make(map[ir.IRFormat]generators.FormatTypeMapping)
+	for k, v := range DefaultFormatMappings {
+		result[k] = v
+	}
+	for k, v := range opts.FormatMappings {
+		result[k] = v
+	}
+	return result
+}
+
+func (g *Generator) Generate(data *ir.IR, opts generators.GeneratorOptions, genOpts ...generators.GeneratorOption) ([]generators.GeneratedFile, error) {
+	formatMappings := g.getFormatMappings(opts)
+	typeIndex := buildTypeIndex(data.Types)
+	recursive := computeRecursiveRefs(data.Types)
+	swiftType := makeSwiftTypeFunc(formatMappings, recursive)
+
+	funcs := template.FuncMap{
+		"swiftType":     swiftType,
+		"swiftParam":    makeSwiftParamFunc(swiftType, typeIndex),
+		"propertyType":  makePropertyTypeFunc(swiftType, typeIndex),
+		"decodeExpr":    makeDecodeExprFunc(swiftType, typeIndex),
+		"hasDefaults":   makeHasDefaultsFunc(typeIndex),
+		"variantFields": variantFields,
+		"fieldName":     safeFieldName,
+		"codingKey":     codingKey,
+		"caseName":      caseName,
+		"str":           swiftString,
+		"comment":       formatComment,
+		"fieldComment":  formatFieldComment,
+		"isIntEnum":     isIntEnum,
+		"toEnumCase":    toEnumCase,
+		"structBody":    newStructBody,
+	}
+
+	tmpl, err := template.New("swift").Funcs(funcs).Parse(swiftTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	tplData := prepareTemplateData(data, formatMappings, recursive)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tplData); err != nil {
+		return nil, err
+	}
+
+	return []generators.GeneratedFile{{
+		Filename: "Models.swift",
+		Content:  buf.Bytes(),
+	}}, nil
+}
+
+func formatComment(description string) string {
+	return formatCommentWithIndent(description, "")
+}
+
+func formatFieldComment(description string) string {
+	return formatCommentWithIndent(description, "    ")
+}
+
+func formatCommentWithIndent(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	description = strings.TrimRight(description, "\n")
+	lines := strings.Split(description, "\n")
+	var result []string
+	for _, line := range lines {
+		if line == "" {
+			result = append(result, indent+"///")
+		} else {
+			result = append(result, indent+"/// "+line)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// isIntEnum returns true if the enum has an integer type
+func isIntEnum(t ir.IRType) bool {
+	return t.EnumType == ir.IRBuiltinInt
+}
+
+// toCamel converts a word sequence to lowerCamelCase following the Swift API
+// guidelines, where acronyms are uniformly cased (e.g. "user_id" becomes
+// userID and "http_status" becomes httpStatus).
+func toCamel(s string) string {
+	var b strings.Builder
+	for _, w := range casing.SplitWords(s) {
+		var word []rune
+		for _, r := range w {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				word = append(word, unicode.ToLower(r))
+			}
+		}
+		if len(word) == 0 {
+			continue
+		}
+		switch acronym, ok := casing.CommonAcronyms[string(word)]; {
+		case b.Len() == 0:
+			// The first word is always lowercase
+		case ok:
+			word = []rune(acronym)
+		default:
+			word[0] = unicode.ToUpper(word[0])
+		}
+		b.WriteString(string(word))
+	}
+	return b.String()
+}
+
+// escapeIdentifier wraps Swift keywords in backticks and prefixes identifiers
+// that would start with a digit.
+func escapeIdentifier(name string) string {
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		return "value" + name
+	}
+	if swiftReservedWords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// toEnumCase converts an enum value to a Swift enum case name
+func toEnumCase(v ir.IREnumValue) string {
+	if v.IntValue != nil {
+		return "value" + strings.ReplaceAll(v.StringValue, "-", "Neg")
+	}
+	name := toCamel(v.StringValue)
+	if name == "" {
+		return "empty"
+	}
+	return escapeIdentifier(name)
+}
+
+// caseName converts a discriminator value to the name of its union case
+func caseName(v ir.IRVariant) string {
+	name := toCamel(v.ConstValue)
+	if name == "" {
+		name = toCamel(v.Name)
+	}
+	return escapeIdentifier(name)
+}
+
+// safeFieldName resolves the Swift property name for a field.
+// Uses x-swift-name extension if present, otherwise the JSON name when it is
+// already lowerCamelCase, falling back to a camelCase conversion.
+func safeFieldName(field ir.IRField) string {
+	if name, ok := field.Extensions["x-swift-name"]; ok {
+		return name
+	}
+	name := field.JSONName
+	if !isCamelIdentifier(name) {
+		name = toCamel(field.JSONName)
+	}
+	if name == "" {
+		name = toCamel(field.Name)
+	}
+	return escapeIdentifier(name)
+}
+
+// isCamelIdentifier reports whether s is already a lowerCamelCase identifier,
+// in which case it is used as the property name unchanged.
+func isCamelIdentifier(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// codingKey renders the CodingKeys case for a field, with an explicit raw
+// value when the property name differs from the JSON name.
+func codingKey(field ir.IRField) string {
+	name := safeFieldName(field)
+	if strings.Trim(name, "`") == field.JSONName {
+		return "case " + name
+	}
+	return "case " + name + " = " + swiftString(field.JSONName)
+}
+
+// swiftString renders s as a Swift string literal.
+func swiftString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// variantFields returns the fields of a union variant without the
+// discriminator, which the union enum encodes and decodes itself.
+func variantFields(fields []ir.IRField, discriminatorJSON string) []ir.IRField {
+	var result []ir.IRField
+	for _, f := range fields {
+		if f.JSONName != discriminatorJSON {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func buildTypeIndex(types []ir.IRType) map[string]ir.IRType {
+	index := make(map[string]ir.IRType, len(types))
+	for _, t := range types {
+		index[t.Name] = t
+	}
+	return index
+}
+
+// computeRecursiveRefs finds, for each type, the named types it holds by value
+// that eventually hold the type itself by value. Such fields must be wrapped in
+// Indirect or the struct would have infinite size. Arrays and dictionaries
+// already provide indirection, so only direct named references are followed.
+func computeRecursiveRefs(types []ir.IRType) map[string]map[string]bool {
+	edges := make(map[string]map[string]bool)
+	addEdge := func(from string, ref *ir.IRTypeRef) {
+		if ref == nil || ref.Name == "" || ref.Array != nil || ref.Map != nil {
+			return
+		}
+		if edges[from] == nil {
+			edges[from] = make(map[string]bool)
+		}
+		edges[from][ref.Name] = true
+	}
+
+	for _, t := range types {
+		for i := range t.Fields {
+			addEdge(t.Name, &t.Fields[i].Type)
+		}
+		addEdge(t.Name, t.Element)
+		if t.Union != nil {
+			for _, v := range t.Union.Variants {
+				addEdge(t.Name, &ir.IRTypeRef{Name: v.Name})
+				for i := range v.Type.Fields {
+					addEdge(v.Name, &v.Type.Fields[i].Type)
+				}
+			}
+		}
+	}
+
+	reaches := func(from, target string) bool {
+		visited := make(map[string]bool)
+		var walk func(string) bool
+		walk = func(name string) bool {
+			if name == target {
+				return true
+			}
+			if visited[name] {
+				return false
+			}
+			visited[name] = true
+			for next := range edges[name] {
+				if walk(next) {
+					return true
+				}
+			}
+			return false
+		}
+		return walk(from)
+	}
+
+	result := make(map[string]map[string]bool)
+	for owner, targets := range edges {
+		for target := range targets {
+			if reaches(target, owner) {
+				if result[owner] == nil {
+					result[owner] = make(map[string]bool)
+				}
+				result[owner][target] = true
+			}
+		}
+	}
+	return result
+}
+
+// makeSwiftTypeFunc returns a template function that renders the Swift type
+// of a field held by owner, wrapping recursive references in Indirect.
+func makeSwiftTypeFunc(formatMappings map[ir.IRFormat]generators.FormatTypeMapping, recursive map[string]map[string]bool) func(string, *ir.IRTypeRef, bool) string {
+	var swiftType func(*ir.IRTypeRef) string
+	swiftType = func(ref *ir.IRTypeRef) string {
+		if mapping, ok := formatMappings[ref.Format]; ok {
+			return mapping.Type
+		}
+
+		if ref.Builtin != ir.IRBuiltinNone {
+			switch ref.Builtin {
+			case ir.IRBuiltinString:
+				return "String"
+			case ir.IRBuiltinInt:
+				return "Int"
+			case ir.IRBuiltinFloat:
+				return "Double"
+			case ir.IRBuiltinBool:
+				return "Bool"
+			}
+			return "JSONValue"
+		}
+		if ref.Array != nil {
+			return "[" + swiftType(ref.Array) + "]"
+		}
+		if ref.Map != nil {
+			return "[String: " + swiftType(ref.Map) + "]"
+		}
+		if ref.Name != "" {
+			return ref.Name
+		}
+		return "JSONValue"
+	}
+
+	return func(owner string, ref *ir.IRTypeRef, optional bool) string {
+		baseType := swiftType(ref)
+		if ref.Name != "" && ref.Array == nil && ref.Map == nil && recursive[owner][ref.Name] {
+			baseType = "Indirect<" + baseType + ">"
+		}
+		if optional || ref.Nullable {
+			return baseType + "?"
+		}
+		return baseType
+	}
+}
+
+// makeSwiftParamFunc returns a template function that renders the type and
+// default argument of a memberwise initializer parameter. Fields with a schema
+// default use it, other optional fields default to nil, and required fields
+// have no default.
+func makeSwiftParamFunc(swiftType func(string, *ir.IRTypeRef, bool) string, typeIndex map[string]ir.IRType) func(string, ir.IRField) string {
+	return func(owner string, field ir.IRField) string {
+		if literal := swiftLiteral(field, typeIndex); literal != "" {
+			return swiftType(owner, &field.Type, false) + " = " + literal
+		}
+		if !field.Required {
+			return swiftType(owner, &field.Type, true) + " = nil"
+		}
+		return swiftType(owner, &field.Type, false)
+	}
+}
+
+// makePropertyTypeFunc returns a template function that renders the declared
+// type of a stored property. Fields with a schema default are never optional.
+func makePropertyTypeFunc(swiftType func(string, *ir.IRTypeRef, bool) string, typeIndex map[string]ir.IRType) func(string, ir.IRField) string {
+	return func(owner string, field ir.IRField) string {
+		optional := !field.Required && swiftLiteral(field, typeIndex) == ""
+		return swiftType(owner, &field.Type, optional)
+	}
+}
+
+// makeDecodeExprFunc returns a template function that renders the expression
+// decoding a field in a custom init(from:). Only structs with schema defaults
+// need one, since synthesized decoding cannot fall back to a default.
+func makeDecodeExprFunc(swiftType func(string, *ir.IRTypeRef, bool) string, typeIndex map[string]ir.IRType) func(string, ir.IRField) string {
+	return func(owner string, field ir.IRField) string {
+		key := "." + strings.Trim(safeFieldName(field), "`")
+		base := strings.TrimSuffix(swiftType(owner, &field.Type, false), "?")
+		if literal := swiftLiteral(field, typeIndex); literal != "" {
+			return fmt.Sprintf("try container.decodeIfPresent(%s.self, forKey: %s) ?? %s", base, key, literal)
+		}
+		if !field.Required || field.Type.Nullable {
+			return fmt.Sprintf("try container.decodeIfPresent(%s.self, forKey: %s)", base, key)
+		}
+		return fmt.Sprintf("try container.decode(%s.self, forKey: %s)", base, key)
+	}
+}
+
+func makeHasDefaultsFunc(typeIndex map[string]ir.IRType) func([]ir.IRField) bool {
+	return func(fields []ir.IRField) bool {
+		for _, f := range fields {
+			if swiftLiteral(f, typeIndex) != "" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// swiftLiteral renders a field's default value as a Swift literal, or an
+// empty string if the default cannot be represented.
+func swiftLiteral(field ir.IRField, typeIndex map[string]ir.IRType) string {
+	if field.Default == nil {
+		return ""
+	}
+	raw := field.Default.RawValue
+
+	// Enum defaults reference the enum case rather than the raw value
+	if t, ok := typeIndex[field.Type.Name]; ok && t.Kind == ir.IRKindEnum {
+		for _, v := range t.EnumValues {
+			if v.IsNull {
+				continue
+			}
+			if v.IntValue != nil && raw == v.StringValue {
+				return "." + toEnumCase(v)
+			}
+			if v.IntValue == nil && raw == strconv.Quote(v.StringValue) {
+				return "." + toEnumCase(v)
+			}
+		}
+		return ""
+	}
+	if field.Type.Name != "" || field.Type.Array != nil || field.Type.Map != nil || field.Type.Format != ir.IRFormatNone {
+		return ""
+	}
+
+	switch field.Default.Builtin {
+	case ir.IRBuiltinInt, ir.IRBuiltinFloat, ir.IRBuiltinBool:
+		return raw
+	case ir.IRBuiltinString:
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return ""
+		}
+		return swiftString(s)
+	}
+	return ""
+}
+
+// structBody is the data for the struct template, shared by plain structs and
+// union variants.
+type structBody struct {
+	Name   string
+	Fields []ir.IRField
+}
+
+func newStructBody(name string, fields []ir.IRField) structBody {
+	return structBody{Name: name, Fields: fields}
+}
+
+type templateData struct {
+	Imports       []string
+	Types         []ir.IRType
+	NeedsJSON     bool
+	NeedsIndirect bool
+}
+
+func prepareTemplateData(data *ir.IR, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, recursive map[string]map[string]bool) templateData {
+	needsJSON := false
+	for _, t := range data.Types {
+		if typeUsesJSONValue(t, formatMappings) {
+			needsJSON = true
+			break
+		}
+	}
+
+	importSet := map[string]bool{"Foundation": true}
+	for _, t := range data.Types {
+		collectImportsFromType(t, formatMappings, importSet)
+	}
+	var imports []string
+	for imp := range importSet {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+
+	needsIndirect := false
+	for _, targets := range recursive {
+		if len(targets) > 0 {
+			needsIndirect = true
+			break
+		}
+	}
+
+	return templateData{
+		Imports:       imports,
+		Types:         data.Types,
+		NeedsJSON:     needsJSON,
+		NeedsIndirect: needsIndirect,
+	}
+}
+
+// collectImportsFromType adds the modules required by format mappings used in t.
+func collectImportsFromType(t ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
+	for i := range t.Fields {
+		collectImportsFromRef(&t.Fields[i].Type, formatMappings, importSet)
+	}
+	collectImportsFromRef(t.Element, formatMappings, importSet)
+	if t.Union != nil {
+		for _, v := range t.Union.Variants {
+			collectImportsFromType(v.Type, formatMappings, importSet)
+		}
+	}
+}
+
+func collectImportsFromRef(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]generators.FormatTypeMapping, importSet map[string]bool) {
+	if ref == nil {
+		return
+	}
+	if mapping, ok := formatMappings[ref.Format]; ok {
+		if mapping.Import != "" {
+			importSet[mapping.Import] = true
+		}
+		return
+	}
+	collectImportsFromRef(ref.Array, formatMappings, importSet)
+	collectImportsFromRef(ref.Map, formatMappings, importSet)
+}
+
+// typeUsesJSONValue reports whether the generated code for t refers to the
+// JSONValue helper type.
+func typeUsesJSONValue(t ir.IRType, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) bool {
+	switch t.Kind {
+	case ir.IRKindUnion:
+		return true
+	case ir.IRKindAlias:
+		if t.Element == nil {
+			return true
+		}
+		return refUsesJSONValue(t.Element, formatMappings)
+	case ir.IRKindDiscriminatedUnion:
+		if t.Union == nil {
+			return false
+		}
+		for _, v := range t.Union.Variants {
+			if typeUsesJSONValue(v.Type, formatMappings) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range t.Fields {
+		if refUsesJSONValue(&t.Fields[i].Type, formatMappings) {
+			return true
+		}
+	}
+	return false
+}
+
+func refUsesJSONValue(ref *ir.IRTypeRef, formatMappings map[ir.IRFormat]generators.FormatTypeMapping) bool {
+	if ref == nil {
+		return false
+	}
+	if _, ok := formatMappings[ref.Format]; ok {
+		return false
+	}
+	if ref.Array != nil {
+		return refUsesJSONValue(ref.Array, formatMappings)
+	}
+	if ref.Map != nil {
+		return refUsesJSONValue(ref.Map, formatMappings)
+	}
+	return ref.Name == "" && (ref.Builtin == ir.IRBuiltinAny || ref.Builtin == ir.IRBuiltinNone)
+}
+
+const swiftTemplate = `
+{{- range $i, $imp := .Imports}}{{if $i}}
+{{end}}import {{$imp}}{{end}}
+{{- range .Types}}
+{{- if eq .Kind "struct"}}
+{{template "struct" .}}
+{{- else if eq .Kind "alias"}}
+{{template "alias" .}}
+{{- else if eq .Kind "enum"}}
+{{template "enum" .}}
+{{- else if eq .Kind "discriminated_union"}}
+{{template "union" .}}
+{{- else if eq .Kind "union"}}
+{{template "simpleunion" .}}
+{{- end}}
+{{- end}}
+{{- if .NeedsIndirect}}
+
+/// Indirect boxes a value so that recursive structs have a finite size.
+public final class Indirect<Value: Codable & Equatable>: Codable, Equatable {
+    public let value: Value
+
+    public init(_ value: Value) {
+        self.value = value
+    }
+
+    public init(from decoder: Decoder) throws {
+        value = try Value(from: decoder)
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        try value.encode(to: encoder)
+    }
+
+    public static func == (lhs: Indirect, rhs: Indirect) -> Bool {
+        lhs.value == rhs.value
+    }
+}
+{{- end}}
+{{- if .NeedsJSON}}
+
+/// JSONValue holds an arbitrary JSON value.
+public enum JSONValue: Codable, Equatable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .null:
+            try container.encodeNil()
+        case .bool(let value):
+            try container.encode(value)
+        case .number(let value):
+            try container.encode(value)
+        case .string(let value):
+            try container.encode(value)
+        case .array(let value):
+            try container.encode(value)
+        case .object(let value):
+            try container.encode(value)
+        }
+    }
+}
+{{- end}}
+
+{{- define "struct"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- template "body" (structBody .Name .Fields)}}
+{{- end}}
+
+{{- define "body"}}
+public struct {{.Name}}: Codable, Equatable {
+{{- $owner := .Name}}
+{{- if .Fields}}
+{{- range .Fields}}
+{{- if .Description}}
+{{fieldComment .Description}}
+{{- end}}
+    public var {{fieldName .}}: {{propertyType $owner .}}
+{{- end}}
+
+    public init(
+{{- range $i, $f := .Fields}}{{if $i}},{{end}}
+        {{fieldName $f}}: {{swiftParam $owner $f}}
+{{- end}}
+    ) {
+{{- range .Fields}}
+        self.{{fieldName .}} = {{fieldName .}}
+{{- end}}
+    }
+
+    enum CodingKeys: String, CodingKey {
+{{- range .Fields}}
+        {{codingKey .}}
+{{- end}}
+    }
+{{- if hasDefaults .Fields}}
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+{{- range .Fields}}
+        {{fieldName .}} = {{decodeExpr $owner .}}
+{{- end}}
+    }
+{{- end}}
+{{- else}}
+    public init() {}
+{{- end}}
+}
+{{- end}}
+
+{{- define "alias"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if .Element}}
+public typealias {{.Name}} = {{swiftType .Name .Element false}}
+{{- else}}
+public typealias {{.Name}} = JSONValue
+{{- end}}
+{{- end}}
+
+{{- define "enum"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+{{- if isIntEnum .}}
+public enum {{.Name}}: Int, Codable, CaseIterable {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+    case {{toEnumCase .}} = {{.IntValue}}
+{{- end}}
+{{- end}}
+}
+{{- else}}
+public enum {{.Name}}: String, Codable, CaseIterable {
+{{- range .EnumValues}}
+{{- if not .IsNull}}
+{{- if eq (toEnumCase .) .StringValue}}
+    case {{toEnumCase .}}
+{{- else}}
+    case {{toEnumCase .}} = {{str .StringValue}}
+{{- end}}
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- end}}
+
+{{- define "union"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+public enum {{.Name}}: Codable, Equatable {
+{{- range .Union.Variants}}
+    case {{caseName .}}({{.Name}})
+{{- end}}
+
+    private enum DiscriminatorCodingKeys: String, CodingKey {
+        case discriminator = {{str .Union.DiscriminatorJSON}}
+    }
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: DiscriminatorCodingKeys.self)
+        let discriminator = try container.decode(String.self, forKey: .discriminator)
+        switch discriminator {
+{{- range .Union.Variants}}
+        case {{str .ConstValue}}:
+            self = .{{caseName .}}(try {{.Name}}(from: decoder))
+{{- end}}
+        default:
+            throw DecodingError.dataCorruptedError(
+                forKey: .discriminator,
+                in: container,
+                debugDescription: "unknown {{.Name}} type \"\(discriminator)\""
+            )
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.container(keyedBy: DiscriminatorCodingKeys.self)
+        switch self {
+{{- range .Union.Variants}}
+        case .{{caseName .}}(let value):
+            try container.encode({{str .ConstValue}}, forKey: .discriminator)
+            try value.encode(to: encoder)
+{{- end}}
+        }
+    }
+}
+{{- range .Union.Variants}}
+{{if .Type.Description}}
+{{comment .Type.Description}}
+{{- end}}
+{{- template "body" (structBody .Name (variantFields .Type.Fields $.Union.DiscriminatorJSON))}}
+{{- end}}
+{{- end}}
+
+{{- define "simpleunion"}}
+{{- if .Description}}
+{{comment .Description}}
+{{- end}}
+public typealias {{.Name}} = JSONValue
+{{- end}}
+`
@@ -2,24 +2,36 @@ package ir
 
 import "github.com/google/jsonschema-go/jsonschema"
 
+// SourceExtension is set by deref on definitions hoisted from another file,
+// recording the file they were defined in relative to the root schema.
+const SourceExtension = "x-schemancer-source"
+
+// SourceIDExtension is set alongside SourceExtension when the file declares
+// an $id, recording the absolute $id URI.
+const SourceIDExtension = "x-schemancer-source-id"
+
 type IR struct {
 	Schema *jsonschema.Schema
 	Types  []IRType
 }
 
 type IRType struct {
-	Name        string
-	Description string
-	Kind        IRTypeKind
-	BaseType    string // Name of the base type this extends (from allOf $ref composition)
-	Fields      []IRField
-	Element     *IRTypeRef
-	KeyType     *IRTypeRef
-	Enum        []string              // String enum values (backwards compatible)
-	EnumValues  []IREnumValue         // Typed enum values (supports int, string, null)
-	EnumType    IRBuiltin             // The underlying type of the enum (string, int)
-	Union       *IRDiscriminatedUnion // For discriminated unions (oneOf with discriminator)
-	SimpleUnion *IRUnion              // For non-discriminated unions (oneOf/anyOf without discriminator)
+	Name            string
+	Description     string
+	Kind            IRTypeKind
+	BaseType        string // Name of the base type this extends (from allOf $ref composition)
+	Fields          []IRField
+	Element         *IRTypeRef
+	KeyType         *IRTypeRef
+	Enum            []string              // String enum values (backwards compatible)
+	EnumValues      []IREnumValue         // Typed enum values (supports int, string, null)
+	EnumType        IRBuiltin             // The underlying type of the enum (string, int)
+	Union           *IRDiscriminatedUnion // For discriminated unions (oneOf with discriminator)
+	SimpleUnion     *IRUnion              // For non-discriminated unions (oneOf/anyOf without discriminator)
+	Additional      *IRTypeRef            // Type of properties not in Fields, for structs allowing additionalProperties
+	AdditionalTypes []string              // Inline types declared only for the Additional values
+	Source          string                // Schema file the type was defined in, empty for the root schema
+	SourceID        string                // $id of the schema file the type was defined in, if any
 }
 
 // IREnumValue represents a single enum value with type information
@@ -0,0 +1,385 @@
+// Package asyncapi translates AsyncAPI 2.x and 3.x documents into JSON Schema
+// documents that the rest of schemancer understands. Message payloads and
+// component schemas become $defs, and each channel carrying several messages
+// that share a discriminator gets a synthesized discriminated union of their
+// payloads.
+package asyncapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/Southclaws/schemancer/schemancer/detect"
+	"github.com/Southclaws/schemancer/schemancer/generators/casing"
+	"github.com/Southclaws/schemancer/schemancer/loader/openapi"
+)
+
+const (
+	messagesPrefix = "#/components/messages/"
+	defsPrefix     = "#/$defs/"
+)
+
+// UnionSuffix is appended to the channel name to name its message union
+const UnionSuffix = "Message"
+
+// IsDocument reports whether a decoded document is an AsyncAPI 2.x or 3.x
+// document
+func IsDocument(doc any) bool {
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	version, ok := m["asyncapi"].(string)
+	return ok && (strings.HasPrefix(version, "2.") || strings.HasPrefix(version, "3."))
+}
+
+// ToSchema converts a decoded AsyncAPI document into a JSON Schema document.
+// The $defs hold components.schemas, one definition per message payload, and
+// a <Channel>Message union for every channel whose messages are
+// discriminated.
+func ToSchema(doc map[string]any) (map[string]any, error) {
+	components, _ := doc["components"].(map[string]any)
+
+	defs := map[string]any{}
+	if schemas, ok := components["schemas"].(map[string]any); ok {
+		for name, schema := range schemas {
+			defs[name] = rewriteRefs(schema)
+		}
+	}
+
+	c := &converter{
+		defs:     defs,
+		messages: make(map[string]string),
+	}
+	if messages, ok := components["messages"].(map[string]any); ok {
+		c.componentMessages = messages
+		for _, name := range sortedKeys(messages) {
+			if _, err := c.messageRef(messagesPrefix + name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	channels, _ := doc["channels"].(map[string]any)
+	version, _ := doc["asyncapi"].(string)
+	for _, name := range sortedKeys(channels) {
+		channel, ok := channels[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var payloads []string
+		var err error
+		if strings.HasPrefix(version, "2.") {
+			payloads, err = c.channelV2(name, channel)
+		} else {
+			payloads, err = c.channelV3(name, channel)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.union(name, channel, payloads); err != nil {
+			return nil, err
+		}
+	}
+
+	result := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   defs,
+	}
+	if info, ok := doc["info"].(map[string]any); ok {
+		if title, ok := info["title"].(string); ok && title != "" {
+			result["title"] = title
+		}
+	}
+
+	return result, nil
+}
+
+type converter struct {
+	defs              map[string]any
+	componentMessages map[string]any
+
+	// messages maps component message names to their payload definition
+	messages map[string]string
+}
+
+// message registers the payload of a message and returns the name of its
+// definition. A payload referencing a component schema uses that schema,
+// otherwise the payload is added under the message name.
+func (c *converter) message(name string, v any) (string, error) {
+	msg, ok := v.(map[string]any)
+	if !ok {
+		return "", nil
+	}
+
+	if ref, ok := msg["$ref"].(string); ok {
+		return c.messageRef(ref)
+	}
+
+	payload, ok := msg["payload"]
+	if !ok {
+		return "", nil
+	}
+
+	// AsyncAPI 3 wraps payloads in a multi format schema object when the
+	// schema format is given explicitly. Only JSON Schema is supported.
+	if m, ok := payload.(map[string]any); ok {
+		if format, ok := m["schemaFormat"].(string); ok {
+			if !isJSONSchemaFormat(format) {
+				return "", fmt.Errorf("message %s: unsupported payload schemaFormat %q", name, format)
+			}
+			payload = m["schema"]
+		}
+	}
+
+	payload = rewriteRefs(payload)
+	if m, ok := payload.(map[string]any); ok && len(m) == 1 {
+		if ref, ok := m["$ref"].(string); ok && strings.HasPrefix(ref, defsPrefix) {
+			return strings.TrimPrefix(ref, defsPrefix), nil
+		}
+	}
+
+	if m, ok := payload.(map[string]any); ok {
+		if _, has := m["description"]; !has {
+			if description := messageDescription(msg); description != "" {
+				m["description"] = description
+			}
+		}
+	}
+
+	defName := typeName(name)
+	if _, exists := c.defs[defName]; exists {
+		defName += "Payload"
+	}
+	if _, exists := c.defs[defName]; exists {
+		return "", fmt.Errorf("message %s: definition %q already exists", name, defName)
+	}
+	c.defs[defName] = payload
+	return defName, nil
+}
+
+// messageRef resolves a reference to a component message
+func (c *converter) messageRef(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, messagesPrefix)
+	if !ok || strings.Contains(name, "/") {
+		return "", fmt.Errorf("unsupported message reference %q, only #/components/messages/ references are supported", ref)
+	}
+	if defName, ok := c.messages[name]; ok {
+		return defName, nil
+	}
+
+	msg, ok := c.componentMessages[name]
+	if !ok {
+		return "", fmt.Errorf("message reference %q not found", ref)
+	}
+
+	// Mark the message before resolving it, so a message referencing
+	// itself doesn't recurse forever.
+	c.messages[name] = ""
+	defName, err := c.message(name, msg)
+	if err != nil {
+		return "", err
+	}
+	c.messages[name] = defName
+	return defName, nil
+}
+
+// channelV2 collects the payloads of the messages published and subscribed to
+// on an AsyncAPI 2.x channel.
+func (c *converter) channelV2(channelName string, channel map[string]any) ([]string, error) {
+	var payloads []string
+	for _, op := range []string{"publish", "subscribe"} {
+		operation, ok := channel[op].(map[string]any)
+		if !ok {
+			continue
+		}
+		msg, ok := operation["message"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		msgs := []any{msg}
+		if oneOf, ok := msg["oneOf"].([]any); ok {
+			msgs = oneOf
+		}
+		for i, m := range msgs {
+			defName, err := c.message(inlineMessageName(channelName, op, i, m), m)
+			if err != nil {
+				return nil, err
+			}
+			payloads = appendUnique(payloads, defName)
+		}
+	}
+	return payloads, nil
+}
+
+// channelV3 collects the payloads of the messages on an AsyncAPI 3.x channel
+func (c *converter) channelV3(channelName string, channel map[string]any) ([]string, error) {
+	messages, ok := channel["messages"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	var payloads []string
+	for _, key := range sortedKeys(messages) {
+		name := key
+		if m, ok := messages[key].(map[string]any); ok {
+			if n, ok := m["name"].(string); ok && n != "" {
+				name = n
+			}
+		}
+		defName, err := c.message(name, messages[key])
+		if err != nil {
+			return nil, err
+		}
+		payloads = appendUnique(payloads, defName)
+	}
+	return payloads, nil
+}
+
+// union synthesizes a discriminated union of a channel's payloads. Channels
+// with a single message, or whose messages share no discriminator, don't get
+// a union.
+func (c *converter) union(channelName string, channel map[string]any, payloads []string) error {
+	var oneOf []any
+	var refs []*jsonschema.Schema
+	for _, p := range payloads {
+		if p == "" {
+			continue
+		}
+		oneOf = append(oneOf, map[string]any{"$ref": defsPrefix + p})
+		refs = append(refs, &jsonschema.Schema{Ref: defsPrefix + p})
+	}
+	if len(refs) < 2 {
+		return nil
+	}
+
+	defs, err := toSchemas(c.defs)
+	if err != nil {
+		return fmt.Errorf("channel %s: %w", channelName, err)
+	}
+	union, _ := detect.DiscriminatedUnion(&jsonschema.Schema{OneOf: refs, Defs: defs})
+	if union == nil || len(union.Variants) != len(refs) {
+		return nil
+	}
+
+	name := channelName
+	if title, ok := channel["title"].(string); ok && title != "" {
+		name = title
+	}
+	name = typeName(name) + UnionSuffix
+	if _, exists := c.defs[name]; exists {
+		return fmt.Errorf("channel %s: definition %q already exists", channelName, name)
+	}
+
+	def := map[string]any{"oneOf": oneOf}
+	if description, ok := channel["description"].(string); ok && description != "" {
+		def["description"] = description
+	}
+	c.defs[name] = def
+	return nil
+}
+
+// inlineMessageName names a message defined inline on a 2.x operation
+func inlineMessageName(channelName, op string, index int, v any) string {
+	if m, ok := v.(map[string]any); ok {
+		for _, key := range []string{"name", "messageId"} {
+			if name, ok := m[key].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	name := channelName + " " + op
+	if index > 0 {
+		name += fmt.Sprintf(" %d", index+1)
+	}
+	return name
+}
+
+// messageDescription returns the description of a message, falling back to
+// its summary.
+func messageDescription(msg map[string]any) string {
+	for _, key := range []string{"description", "summary"} {
+		if s, ok := msg[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func isJSONSchemaFormat(format string) bool {
+	return strings.HasPrefix(format, "application/vnd.aai.asyncapi") ||
+		strings.HasPrefix(format, "application/schema+json") ||
+		strings.HasPrefix(format, "application/schema+yaml")
+}
+
+// typeName converts a message or channel name, which may be a channel
+// address such as "user/{userId}/events", into a PascalCase definition name.
+func typeName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = casing.ToPascalCase(w)
+	}
+	return strings.Join(words, "")
+}
+
+// rewriteRefs points component schema references at $defs
+func rewriteRefs(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, sub := range v {
+			if ref, ok := sub.(string); ok && k == "$ref" {
+				if path, fragment, ok := strings.Cut(ref, "#"); ok {
+					v[k] = path + "#" + openapi.RewritePointer(fragment)
+				}
+				continue
+			}
+			v[k] = rewriteRefs(sub)
+		}
+	case []any:
+		for i := range v {
+			v[i] = rewriteRefs(v[i])
+		}
+	}
+	return v
+}
+
+// toSchemas decodes the definitions so union detection can run on them
+func toSchemas(defs map[string]any) (map[string]*jsonschema.Schema, error) {
+	data, err := json.Marshal(defs)
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]*jsonschema.Schema
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+package asyncapi_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer/loader/asyncapi"
+)
+
+func translate(t *testing.T, doc string) (map[string]any, error) {
+	t.Helper()
+
+	var v any
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &v))
+	require.True(t, asyncapi.IsDocument(v))
+
+	schema, err := asyncapi.ToSchema(v.(map[string]any))
+	if err != nil {
+		return nil, err
+	}
+	return schema["$defs"].(map[string]any), nil
+}
+
+func TestIsDocument(t *testing.T) {
+	assert.True(t, asyncapi.IsDocument(map[string]any{"asyncapi": "2.6.0"}))
+	assert.True(t, asyncapi.IsDocument(map[string]any{"asyncapi": "3.0.0"}))
+	assert.False(t, asyncapi.IsDocument(map[string]any{"asyncapi": "1.2.0"}))
+	assert.False(t, asyncapi.IsDocument(map[string]any{"openapi": "3.1.0"}))
+}
+
+func TestToSchema_ChannelUnion(t *testing.T) {
+	defs, err := translate(t, `
+asyncapi: 3.0.0
+channels:
+  users/{userId}:
+    messages:
+      created:
+        payload:
+          type: object
+          properties:
+            kind: {type: string, const: created}
+      removed:
+        payload:
+          type: object
+          properties:
+            kind: {type: string, const: removed}
+`)
+	require.NoError(t, err)
+
+	assert.Contains(t, defs, "Created")
+	assert.Contains(t, defs, "Removed")
+	assert.Equal(t, map[string]any{
+		"oneOf": []any{
+			map[string]any{"$ref": "#/$defs/Created"},
+			map[string]any{"$ref": "#/$defs/Removed"},
+		},
+	}, defs["UsersUserIDMessage"])
+}
+
+func TestToSchema_NoDiscriminator(t *testing.T) {
+	defs, err := translate(t, `
+asyncapi: 2.6.0
+channels:
+  metrics:
+    publish:
+      message:
+        oneOf:
+          - name: disk
+            payload: {type: object, properties: {load: {type: number}}}
+          - name: memory
+            payload: {type: object, properties: {used: {type: integer}}}
+`)
+	require.NoError(t, err)
+
+	assert.Contains(t, defs, "Disk")
+	assert.Contains(t, defs, "Memory")
+	assert.NotContains(t, defs, "MetricsMessage")
+}
+
+func TestToSchema_ExternalMessageRef(t *testing.T) {
+	_, err := translate(t, `
+asyncapi: 2.6.0
+channels:
+  metrics:
+    publish:
+      message:
+        $ref: "./messages.yaml#/Metric"
+`)
+	assert.ErrorContains(t, err, "unsupported message reference")
+}
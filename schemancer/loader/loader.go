@@ -8,11 +8,16 @@ import (
 	"path/filepath"
 
 	"github.com/Southclaws/schemancer/schemancer/deref"
+	"github.com/Southclaws/schemancer/schemancer/loader/asyncapi"
+	"github.com/Southclaws/schemancer/schemancer/loader/openapi"
 	"github.com/goccy/go-yaml"
 	"github.com/google/jsonschema-go/jsonschema"
 )
 
-func FromFile(filename string) (*jsonschema.Schema, error) {
+// FromFile loads a schema file and resolves its external references. The
+// options configure how references are resolved, such as fetching remote
+// references.
+func FromFile(filename string, opts ...deref.Option) (*jsonschema.Schema, error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
@@ -26,7 +31,7 @@ func FromFile(filename string) (*jsonschema.Schema, error) {
 
 	// Resolve external $ref references
 	baseDir := filepath.Dir(filename)
-	if err := deref.Schema(schema, baseDir); err != nil {
+	if err := deref.Schema(schema, baseDir, opts...); err != nil {
 		return nil, fmt.Errorf("failed to dereference schema: %w", err)
 	}
 
@@ -44,6 +49,21 @@ func FromReader(r io.Reader) (*jsonschema.Schema, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	// OpenAPI documents are translated so their components.schemas become $defs
+	if openapi.IsDocument(yamlData) {
+		yamlData, err = openapi.ToSchema(yamlData.(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate OpenAPI document: %w", err)
+		}
+	} else if asyncapi.IsDocument(yamlData) {
+		// AsyncAPI documents are translated so their message payloads become
+		// $defs, with a union per channel
+		yamlData, err = asyncapi.ToSchema(yamlData.(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate AsyncAPI document: %w", err)
+		}
+	}
+
 	jsonData, err := json.Marshal(yamlData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert to JSON: %w", err)
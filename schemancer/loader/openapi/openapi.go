@@ -0,0 +1,435 @@
+// Package openapi translates OpenAPI 3.0 and 3.1 documents into JSON Schema
+// documents that the rest of schemancer understands. The component schemas
+// become $defs, and OpenAPI-specific keywords are rewritten into their JSON
+// Schema equivalents.
+//
+// The translation works on the generic decoded document (maps and slices)
+// before it is unmarshalled into a jsonschema.Schema, since some OpenAPI 3.0
+// keywords (such as boolean exclusiveMinimum) are not valid JSON Schema.
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	componentsPrefix = "#/components/schemas/"
+	defsPrefix       = "#/$defs/"
+)
+
+// IsDocument reports whether a decoded document is an OpenAPI 3.x document
+func IsDocument(doc any) bool {
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	version, ok := m["openapi"].(string)
+	return ok && strings.HasPrefix(version, "3.")
+}
+
+// ToSchema converts a decoded OpenAPI document into a JSON Schema document
+// whose $defs are the document's components.schemas. Schemas inlined in
+// paths are not included.
+func ToSchema(doc map[string]any) (map[string]any, error) {
+	defs := map[string]any{}
+	if components, ok := doc["components"].(map[string]any); ok {
+		if schemas, ok := components["schemas"].(map[string]any); ok {
+			defs = schemas
+		}
+	}
+
+	for _, name := range sortedKeys(defs) {
+		defs[name] = translate(defs[name])
+	}
+
+	t := &translator{defs: defs, owners: make(map[string]string)}
+	for _, name := range sortedKeys(defs) {
+		if err := t.discriminators(name, defs[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	result := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   defs,
+	}
+	if info, ok := doc["info"].(map[string]any); ok {
+		if title, ok := info["title"].(string); ok && title != "" {
+			result["title"] = title
+		}
+	}
+
+	return result, nil
+}
+
+// RewritePointer maps a JSON Pointer into an OpenAPI or AsyncAPI document's
+// component schemas onto the corresponding $defs pointer. Other pointers are
+// returned unchanged.
+func RewritePointer(pointer string) string {
+	if rest, ok := strings.CutPrefix(pointer, "/components/schemas/"); ok {
+		return "/$defs/" + rest
+	}
+	return pointer
+}
+
+// schemaKeywords hold a single subschema
+var schemaKeywords = []string{
+	"additionalItems", "additionalProperties", "contains", "contentSchema",
+	"else", "if", "not", "propertyNames", "then", "unevaluatedItems",
+	"unevaluatedProperties",
+}
+
+// schemaListKeywords hold a list of subschemas
+var schemaListKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+
+// schemaMapKeywords hold a map of named subschemas
+var schemaMapKeywords = []string{
+	"$defs", "definitions", "dependentSchemas", "patternProperties", "properties",
+}
+
+// translate rewrites a single OpenAPI schema object, and its subschemas, into
+// JSON Schema 2020-12.
+func translate(v any) any {
+	s, ok := v.(map[string]any)
+	if !ok {
+		// Boolean schemas need no translation
+		return v
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		s["$ref"] = rewriteRef(ref)
+	}
+
+	for _, k := range schemaKeywords {
+		if sub, ok := s[k]; ok {
+			s[k] = translate(sub)
+		}
+	}
+	for _, k := range schemaListKeywords {
+		if list, ok := s[k].([]any); ok {
+			for i := range list {
+				list[i] = translate(list[i])
+			}
+		}
+	}
+	for _, k := range schemaMapKeywords {
+		if m, ok := s[k].(map[string]any); ok {
+			for name := range m {
+				m[name] = translate(m[name])
+			}
+		}
+	}
+	switch items := s["items"].(type) {
+	case []any:
+		for i := range items {
+			items[i] = translate(items[i])
+		}
+	case map[string]any, bool:
+		s["items"] = translate(items)
+	}
+
+	collapseRefWrapper(s)
+	translateNullable(s)
+	translateExclusiveBound(s, "exclusiveMinimum", "minimum")
+	translateExclusiveBound(s, "exclusiveMaximum", "maximum")
+
+	// OpenAPI 3.0's example is superseded by the JSON Schema examples keyword
+	if example, ok := s["example"]; ok {
+		if _, exists := s["examples"]; !exists {
+			s["examples"] = []any{example}
+		}
+		delete(s, "example")
+	}
+
+	return s
+}
+
+// rewriteRef points component schema references at $defs. References into
+// other files keep their path, the referenced file is translated when it is
+// loaded.
+func rewriteRef(ref string) string {
+	path, fragment, ok := strings.Cut(ref, "#")
+	if !ok {
+		return ref
+	}
+	return path + "#" + RewritePointer(fragment)
+}
+
+// collapseRefWrapper replaces the OpenAPI 3.0 idiom of wrapping a reference
+// in a single-element allOf, used to attach a description or nullable to a
+// $ref, with a plain $ref. JSON Schema 2020-12 allows keywords alongside $ref.
+func collapseRefWrapper(s map[string]any) {
+	allOf, ok := s["allOf"].([]any)
+	if !ok || len(allOf) != 1 {
+		return
+	}
+	part, ok := allOf[0].(map[string]any)
+	if !ok || len(part) != 1 {
+		return
+	}
+	ref, ok := part["$ref"].(string)
+	if !ok {
+		return
+	}
+	if _, ok := s["$ref"]; ok {
+		return
+	}
+	for _, k := range []string{"type", "properties", "items", "oneOf", "anyOf"} {
+		if _, ok := s[k]; ok {
+			return
+		}
+	}
+	s["$ref"] = ref
+	delete(s, "allOf")
+}
+
+// translateNullable converts OpenAPI 3.0's nullable keyword into a "null"
+// member of the type list, and a null enum value when the schema is an enum.
+// A nullable reference becomes anyOf the reference and null, since keywords
+// next to $ref don't change the referenced schema.
+func translateNullable(s map[string]any) {
+	nullable, _ := s["nullable"].(bool)
+	delete(s, "nullable")
+	if !nullable {
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		delete(s, "$ref")
+		s["anyOf"] = []any{
+			map[string]any{"$ref": ref},
+			map[string]any{"type": "null"},
+		}
+		return
+	}
+
+	switch t := s["type"].(type) {
+	case string:
+		if t != "null" {
+			s["type"] = []any{t, "null"}
+		}
+	case []any:
+		if !containsValue(t, "null") {
+			s["type"] = append(t, "null")
+		}
+	}
+
+	if enum, ok := s["enum"].([]any); ok && !containsValue(enum, nil) {
+		s["enum"] = append(enum, nil)
+	}
+}
+
+// translateExclusiveBound converts OpenAPI 3.0's boolean exclusiveMinimum and
+// exclusiveMaximum, which modify minimum and maximum, into the numeric
+// keywords JSON Schema uses.
+func translateExclusiveBound(s map[string]any, exclusive, bound string) {
+	flag, ok := s[exclusive].(bool)
+	if !ok {
+		return
+	}
+	delete(s, exclusive)
+	if !flag {
+		return
+	}
+	if value, ok := s[bound]; ok {
+		s[exclusive] = value
+		delete(s, bound)
+	}
+}
+
+type translator struct {
+	defs map[string]any
+
+	// owners records which union assigned the discriminator value of each
+	// variant, to report conflicting mappings.
+	owners map[string]string
+}
+
+// discriminators finds discriminated unions in a translated schema. OpenAPI
+// variants identify themselves through the discriminator mapping, whereas
+// schemancer detects unions by a const discriminator property on every
+// variant, so the mapped value is added to each variant as a const.
+func (t *translator) discriminators(name string, v any) error {
+	s, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if d, ok := s["discriminator"].(map[string]any); ok {
+		if err := t.discriminate(name, s, d); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range []string{"properties", "$defs", "definitions"} {
+		if m, ok := s[k].(map[string]any); ok {
+			for _, prop := range sortedKeys(m) {
+				if err := t.discriminators(name+"."+prop, m[prop]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, k := range []string{"items", "additionalProperties"} {
+		if err := t.discriminators(name, s[k]); err != nil {
+			return err
+		}
+	}
+	for _, k := range schemaListKeywords {
+		if list, ok := s[k].([]any); ok {
+			for _, sub := range list {
+				if err := t.discriminators(name, sub); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *translator) discriminate(name string, s, d map[string]any) error {
+	property, ok := d["propertyName"].(string)
+	if !ok || property == "" {
+		return fmt.Errorf("%s: discriminator has no propertyName", name)
+	}
+
+	// Unions are detected from oneOf, and a discriminator implies only one
+	// variant can match anyway.
+	variants, ok := s["oneOf"].([]any)
+	if !ok {
+		if variants, ok = s["anyOf"].([]any); !ok {
+			// A discriminator without oneOf describes inheritance, where
+			// the subtypes reference the base with allOf. That isn't a
+			// closed union, so it is left as a plain object.
+			return nil
+		}
+		s["oneOf"] = variants
+		delete(s, "anyOf")
+	}
+
+	// Invert the mapping to find each variant's value. Mapping values are
+	// either references or bare component names.
+	values := make(map[string]string)
+	if mapping, ok := d["mapping"].(map[string]any); ok {
+		for _, value := range sortedKeys(mapping) {
+			target, ok := mapping[value].(string)
+			if !ok {
+				continue
+			}
+			if !strings.Contains(target, "#") && !strings.Contains(target, "/") {
+				target = defsPrefix + target
+			}
+			values[rewriteRef(target)] = value
+		}
+	}
+
+	for _, variant := range variants {
+		vs, ok := variant.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref, _ := vs["$ref"].(string)
+		if !strings.HasPrefix(ref, defsPrefix) {
+			// Inline and external variants must declare the discriminator
+			// const themselves.
+			continue
+		}
+
+		// Without a mapping entry, the value is the component name
+		target := strings.TrimPrefix(ref, defsPrefix)
+		value, ok := values[ref]
+		if !ok {
+			value = target
+		}
+
+		if err := t.setDiscriminator(name, target, property, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setDiscriminator adds the const discriminator value to a variant schema.
+func (t *translator) setDiscriminator(union, target, property, value string) error {
+	variant, ok := t.defs[target].(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: discriminator variant %q not found in components.schemas", union, target)
+	}
+
+	key := target + "." + property
+	if owner, ok := t.owners[key]; ok {
+		if existing := constValue(variant, property); existing != value {
+			return fmt.Errorf("%s: variant %q is mapped to %q, but %s already maps it to %q", union, target, value, owner, existing)
+		}
+		return nil
+	}
+	t.owners[key] = union
+
+	// Properties declared alongside allOf are overridden by the allOf parts
+	// when merged, so a reference or allOf variant gets the const as a final
+	// allOf part instead.
+	if ref, ok := variant["$ref"]; ok {
+		delete(variant, "$ref")
+		variant["allOf"] = []any{map[string]any{"$ref": ref}}
+	}
+	if allOf, ok := variant["allOf"].([]any); ok {
+		variant["allOf"] = append(allOf, map[string]any{
+			"properties": map[string]any{
+				property: map[string]any{"type": "string", "const": value},
+			},
+			"required": []any{property},
+		})
+		return nil
+	}
+
+	props, ok := variant["properties"].(map[string]any)
+	if !ok {
+		props = make(map[string]any)
+		variant["properties"] = props
+	}
+	prop, ok := props[property].(map[string]any)
+	if !ok {
+		prop = map[string]any{"type": "string"}
+		props[property] = prop
+	}
+	if existing, ok := prop["const"]; ok && fmt.Sprint(existing) != value {
+		return fmt.Errorf("%s: variant %q declares %s const %v, but the discriminator maps it to %q", union, target, property, existing, value)
+	}
+	prop["const"] = value
+	delete(prop, "enum")
+	return nil
+}
+
+// constValue returns the const discriminator value previously set on a variant
+func constValue(variant map[string]any, property string) string {
+	if allOf, ok := variant["allOf"].([]any); ok && len(allOf) > 0 {
+		if last, ok := allOf[len(allOf)-1].(map[string]any); ok {
+			variant = last
+		}
+	}
+	props, _ := variant["properties"].(map[string]any)
+	prop, _ := props[property].(map[string]any)
+	return fmt.Sprint(prop["const"])
+}
+
+func containsValue(list []any, v any) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
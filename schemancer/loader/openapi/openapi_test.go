@@ -0,0 +1,148 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer/loader/openapi"
+)
+
+func translate(t *testing.T, doc string) map[string]any {
+	t.Helper()
+
+	var v any
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &v))
+	require.True(t, openapi.IsDocument(v))
+
+	schema, err := openapi.ToSchema(v.(map[string]any))
+	require.NoError(t, err)
+	return schema["$defs"].(map[string]any)
+}
+
+func TestToSchema_Keywords(t *testing.T) {
+	defs := translate(t, `
+openapi: 3.0.3
+components:
+  schemas:
+    Age:
+      type: integer
+      nullable: true
+      minimum: 0
+      exclusiveMinimum: true
+      maximum: 150
+      exclusiveMaximum: false
+      example: 42
+    Status:
+      type: string
+      nullable: true
+      enum: [active, archived]
+    Owner:
+      description: The owner.
+      allOf:
+        - $ref: "#/components/schemas/Person"
+    Manager:
+      nullable: true
+      allOf:
+        - $ref: "#/components/schemas/Person"
+    Assistant:
+      nullable: true
+      $ref: "#/components/schemas/Person"
+`)
+
+	assert.Equal(t, map[string]any{
+		"type":             []any{"integer", "null"},
+		"exclusiveMinimum": uint64(0),
+		"maximum":          uint64(150),
+		"examples":         []any{uint64(42)},
+	}, defs["Age"])
+
+	assert.Equal(t, map[string]any{
+		"type": []any{"string", "null"},
+		"enum": []any{"active", "archived", nil},
+	}, defs["Status"])
+
+	assert.Equal(t, map[string]any{
+		"description": "The owner.",
+		"$ref":        "#/$defs/Person",
+	}, defs["Owner"])
+
+	nullablePerson := map[string]any{
+		"anyOf": []any{
+			map[string]any{"$ref": "#/$defs/Person"},
+			map[string]any{"type": "null"},
+		},
+	}
+	assert.Equal(t, nullablePerson, defs["Manager"])
+	assert.Equal(t, nullablePerson, defs["Assistant"])
+}
+
+func TestToSchema_DiscriminatorMapping(t *testing.T) {
+	defs := translate(t, `
+openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: "#/components/schemas/Cat"
+    Cat:
+      type: object
+      properties:
+        kind:
+          type: string
+    Dog:
+      allOf:
+        - $ref: "#/components/schemas/Animal"
+`)
+
+	cat := defs["Cat"].(map[string]any)
+	kind := cat["properties"].(map[string]any)["kind"].(map[string]any)
+	assert.Equal(t, "cat", kind["const"])
+
+	// Without a mapping entry the value is the component name, added as a
+	// final allOf part so it takes precedence over the base's property
+	dog := defs["Dog"].(map[string]any)
+	allOf := dog["allOf"].([]any)
+	require.Len(t, allOf, 2)
+	assert.Equal(t, map[string]any{
+		"properties": map[string]any{
+			"kind": map[string]any{"type": "string", "const": "Dog"},
+		},
+		"required": []any{"kind"},
+	}, allOf[1])
+}
+
+func TestToSchema_ConflictingMappings(t *testing.T) {
+	var v any
+	require.NoError(t, yaml.Unmarshal([]byte(`
+openapi: 3.0.3
+components:
+  schemas:
+    A:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: Cat
+    B:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+      discriminator:
+        propertyName: kind
+        mapping:
+          feline: Cat
+    Cat:
+      type: object
+`), &v))
+
+	_, err := openapi.ToSchema(v.(map[string]any))
+	assert.ErrorContains(t, err, `variant "Cat" is mapped to "feline", but A already maps it to "cat"`)
+}
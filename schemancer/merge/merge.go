@@ -4,6 +4,8 @@ package merge
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
@@ -22,7 +24,7 @@ func ResolveSchema(root *jsonschema.Schema, s *jsonschema.Schema) *jsonschema.Sc
 		return s
 	}
 
-	// Only handle local references (#/$defs/... or #/Name)
+	// Only handle local references (#/$defs/..., #/Name or #anchor)
 	if len(s.Ref) > 0 && s.Ref[0] == '#' {
 		refPath := s.Ref[1:]
 
@@ -39,6 +41,11 @@ func ResolveSchema(root *jsonschema.Schema, s *jsonschema.Schema) *jsonschema.Sc
 					return defSchema
 				}
 			}
+		} else if len(refPath) > 0 && refPath[0] != '/' {
+			// Handle anchors (#name) declared on a definition
+			if defSchema, err := findAnchor(root, refPath); err == nil {
+				return defSchema
+			}
 		} else if len(refPath) > 1 && refPath[0] == '/' {
 			// Handle root-level references (#/Name) - look in Extra
 			defName := refPath[1:]
@@ -53,6 +60,56 @@ func ResolveSchema(root *jsonschema.Schema, s *jsonschema.Schema) *jsonschema.Sc
 	return nil
 }
 
+// findAnchor returns the definition declaring an $anchor or $dynamicAnchor
+// with the given name, or nil when no definition declares it. It is an error
+// for more than one definition to declare the same anchor.
+func findAnchor(root *jsonschema.Schema, name string) (*jsonschema.Schema, error) {
+	var found *jsonschema.Schema
+	var foundName string
+	for _, defName := range sortedDefNames(root) {
+		defSchema := root.Defs[defName]
+		if defSchema == nil || (defSchema.Anchor != name && defSchema.DynamicAnchor != name) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("anchor %q is declared by both %s and %s", name, foundName, defName)
+		}
+		found, foundName = defSchema, defName
+	}
+	return found, nil
+}
+
+// CheckAnchors reports an error when two definitions declare the same
+// $anchor or $dynamicAnchor, since references to it would be ambiguous.
+func CheckAnchors(root *jsonschema.Schema) error {
+	declared := make(map[string]string)
+	for _, defName := range sortedDefNames(root) {
+		defSchema := root.Defs[defName]
+		if defSchema == nil {
+			continue
+		}
+		for _, anchor := range []string{defSchema.Anchor, defSchema.DynamicAnchor} {
+			if anchor == "" {
+				continue
+			}
+			if other, ok := declared[anchor]; ok && other != defName {
+				return fmt.Errorf("anchor %q is declared by both %s and %s", anchor, other, defName)
+			}
+			declared[anchor] = defName
+		}
+	}
+	return nil
+}
+
+func sortedDefNames(root *jsonschema.Schema) []string {
+	names := make([]string, 0, len(root.Defs))
+	for name := range root.Defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // parseExtraSchemaHelper converts an Extra value to a jsonschema.Schema
 func parseExtraSchemaHelper(v any) *jsonschema.Schema {
 	if v == nil {
@@ -0,0 +1,32 @@
+package merge_test
+
+import (
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Southclaws/schemancer/schemancer/merge"
+)
+
+func TestResolveSchema_Anchor(t *testing.T) {
+	root := &jsonschema.Schema{Defs: map[string]*jsonschema.Schema{
+		"Name":  {Anchor: "name", Type: "string"},
+		"Count": {DynamicAnchor: "count", Type: "integer"},
+	}}
+
+	assert.Same(t, root.Defs["Name"], merge.ResolveSchema(root, &jsonschema.Schema{Ref: "#name"}))
+	assert.Same(t, root.Defs["Count"], merge.ResolveSchema(root, &jsonschema.Schema{Ref: "#count"}))
+	assert.NoError(t, merge.CheckAnchors(root))
+}
+
+func TestResolveSchema_DuplicateAnchor(t *testing.T) {
+	root := &jsonschema.Schema{Defs: map[string]*jsonschema.Schema{
+		"FirstName": {Anchor: "name", Type: "string"},
+		"LastName":  {DynamicAnchor: "name", Type: "string"},
+	}}
+
+	// An ambiguous anchor doesn't resolve to either definition
+	assert.Nil(t, merge.ResolveSchema(root, &jsonschema.Schema{Ref: "#name"}))
+	assert.EqualError(t, merge.CheckAnchors(root), `anchor "name" is declared by both FirstName and LastName`)
+}
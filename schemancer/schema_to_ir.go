@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -17,6 +18,10 @@ import (
 )
 
 func SchemaToIR(schema *jsonschema.Schema) (*ir.IR, error) {
+	if err := merge.CheckAnchors(schema); err != nil {
+		return nil, err
+	}
+
 	result := &ir.IR{
 		Schema: schema,
 		Types:  []ir.IRType{},
@@ -227,12 +232,78 @@ func SchemaToIR(schema *jsonschema.Schema) (*ir.IR, error) {
 		}
 	}
 
+	assignSources(schema, result.Types)
+
 	// Topologically sort types so dependencies are declared before dependents
 	result.Types = topologicalSort(result.Types)
 
 	return result, nil
 }
 
+// assignSources records the schema file each type was defined in. Definitions
+// hoisted from other files carry their source, and types generated inline
+// belong to the file of the first type that references them.
+func assignSources(root *jsonschema.Schema, types []ir.IRType) {
+	known := make(map[string]bool)
+	for name, def := range root.Defs {
+		known[symbolName(name)] = true
+		if source, ok := def.Extra[ir.SourceExtension].(string); ok {
+			sourceID, _ := def.Extra[ir.SourceIDExtension].(string)
+			for i := range types {
+				if types[i].Name == symbolName(name) {
+					types[i].Source = source
+					types[i].SourceID = sourceID
+				}
+			}
+		}
+	}
+	for name := range root.Extra {
+		known[symbolName(name)] = true
+	}
+	if root.Title != "" {
+		known[symbolName(root.Title)] = true
+	}
+
+	typeMap := make(map[string]ir.IRType)
+	for _, t := range types {
+		typeMap[t.Name] = t
+	}
+
+	order := make([]int, len(types))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return types[order[i]].Name < types[order[j]].Name })
+
+	for changed := true; changed; {
+		changed = false
+		for _, i := range order {
+			if !known[types[i].Name] {
+				continue
+			}
+			deps := extractTypeDependencies(types[i], typeMap)
+			names := make([]string, 0, len(deps))
+			for dep := range deps {
+				names = append(names, dep)
+			}
+			sort.Strings(names)
+			for _, dep := range names {
+				if known[dep] {
+					continue
+				}
+				for j := range types {
+					if types[j].Name == dep {
+						types[j].Source = types[i].Source
+						types[j].SourceID = types[i].SourceID
+					}
+				}
+				known[dep] = true
+				changed = true
+			}
+		}
+	}
+}
+
 func convertDiscriminatedUnion(root *jsonschema.Schema, union *detect.UnionResult, unionName string, inlineTypes *[]ir.IRType) ir.IRType {
 	rootName := symbolName(unionName)
 	if rootName == "" {
@@ -518,8 +589,13 @@ func convertStructToIRType(root *jsonschema.Schema, name string, schema *jsonsch
 			extensions := make(map[string]string)
 			for key, val := range propSchema.Extra {
 				if strings.HasPrefix(key, "x-") {
-					if strVal, ok := val.(string); ok {
-						extensions[key] = strVal
+					switch v := val.(type) {
+					case string:
+						extensions[key] = v
+					case float64:
+						extensions[key] = strconv.FormatFloat(v, 'f', -1, 64)
+					case bool:
+						extensions[key] = strconv.FormatBool(v)
 					}
 				}
 			}
@@ -531,12 +607,32 @@ func convertStructToIRType(root *jsonschema.Schema, name string, schema *jsonsch
 		fields = append(fields, field)
 	}
 
-	return &ir.IRType{
+	t := &ir.IRType{
 		Name:        goName,
 		Description: schema.Description,
 		Kind:        ir.IRKindStruct,
 		Fields:      fields,
 	}
+
+	// Properties besides the declared ones are only kept when the schema
+	// explicitly allows them, with true or a schema for their values.
+	// The inline types declared for their values are recorded, so generators
+	// that leave additional properties out can leave them out too.
+	if ap := schema.AdditionalProperties; ap != nil && !isFalseSchema(ap) {
+		before := len(*inlineTypes)
+		valueRef := schemaToIRTypeRefWithContext(root, ap, goName+"Value", inlineTypes)
+		t.Additional = &valueRef
+		for _, inline := range (*inlineTypes)[before:] {
+			t.AdditionalTypes = append(t.AdditionalTypes, inline.Name)
+		}
+	}
+
+	return t
+}
+
+// isFalseSchema reports whether a schema is false, which validates nothing
+func isFalseSchema(s *jsonschema.Schema) bool {
+	return s.Not != nil && reflect.DeepEqual(*s, jsonschema.Schema{Not: &jsonschema.Schema{}})
 }
 
 // collectUnionVariants builds IRTypeRef variants from oneOf/anyOf schemas.
@@ -616,6 +712,11 @@ func schemaToIRTypeRefWithContext(root *jsonschema.Schema, schema *jsonschema.Sc
 		return ir.IRTypeRef{Builtin: ir.IRBuiltinString, Constraints: constraints}
 	}
 
+	// A reference unioned with null is a nullable reference
+	if ref, ok := nullableRef(schema); ok {
+		return ir.IRTypeRef{Name: refToTypeName(ref), Nullable: true, Constraints: constraints}
+	}
+
 	if len(schema.AnyOf) > 0 || len(schema.OneOf) > 0 {
 		return ir.IRTypeRef{Builtin: ir.IRBuiltinAny, Constraints: constraints}
 	}
@@ -659,6 +760,30 @@ func schemaToIRTypeRefWithContext(root *jsonschema.Schema, schema *jsonschema.Sc
 	return ir.IRTypeRef{Builtin: ir.IRBuiltinAny, Format: schemaFormatToIRFormat(schema.Format), Constraints: constraints}
 }
 
+// nullableRef returns the reference of an anyOf or oneOf holding only a $ref
+// and a null schema, as translated from OpenAPI 3.0's nullable references.
+func nullableRef(schema *jsonschema.Schema) (string, bool) {
+	variants := schema.AnyOf
+	if len(variants) == 0 {
+		variants = schema.OneOf
+	}
+	if len(variants) != 2 || variants[0] == nil || variants[1] == nil {
+		return "", false
+	}
+	for i, v := range variants {
+		other := variants[1-i]
+		if v.Ref != "" && other.Ref == "" && isNullSchema(other) {
+			return v.Ref, true
+		}
+	}
+	return "", false
+}
+
+// isNullSchema reports whether a schema only allows null
+func isNullSchema(s *jsonschema.Schema) bool {
+	return s.Type == "null" || len(s.Types) == 1 && s.Types[0] == "null"
+}
+
 // nullableScalarBuiltin reports the builtin for a `type: [T, "null"]` schema —
 // exactly one concrete scalar type unioned with null — so it can be carried as
 // a nullable T rather than an any. Any other multi-type set returns false.
@@ -1019,6 +1144,9 @@ func extractTypeDependencies(t ir.IRType, typeMap map[string]ir.IRType) map[stri
 		extractFromRef(t.Element)
 	}
 
+	// Extract from additional properties
+	extractFromRef(t.Additional)
+
 	// Extract from base type (allOf $ref inheritance)
 	if t.BaseType != "" {
 		deps[t.BaseType] = true
@@ -1030,6 +1158,7 @@ func extractTypeDependencies(t ir.IRType, typeMap map[string]ir.IRType) map[stri
 			for _, f := range v.Type.Fields {
 				extractFromRef(&f.Type)
 			}
+			extractFromRef(v.Type.Additional)
 			// Also depend on the variant's base type
 			if v.Type.BaseType != "" {
 				deps[v.Type.BaseType] = true
@@ -0,0 +1,46 @@
+#nullable enable
+
+using System.Text.Json.Serialization;
+
+namespace Example.Generated;
+
+public enum HttpStatus : long
+{
+    Value200 = 200,
+    Value201 = 201,
+    Value400 = 400,
+    Value404 = 404,
+    Value500 = 500,
+}
+
+[JsonConverter(typeof(JsonStringEnumConverter<Status>))]
+public enum Status
+{
+    [JsonStringEnumMemberName("pending")]
+    Pending,
+    [JsonStringEnumMemberName("in_progress")]
+    InProgress,
+    [JsonStringEnumMemberName("completed")]
+    Completed,
+    [JsonStringEnumMemberName("failed")]
+    Failed,
+}
+
+public sealed record Task
+{
+    [JsonPropertyName("assignee")]
+    public required string? Assignee { get; init; }
+
+    [JsonPropertyName("httpStatus")]
+    [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+    public HttpStatus? HttpStatus { get; init; }
+
+    [JsonPropertyName("id")]
+    public required string Id { get; init; }
+
+    [JsonPropertyName("status")]
+    public Status Status { get; init; } = Status.Pending;
+
+    [JsonPropertyName("title")]
+    public required string Title { get; init; }
+}
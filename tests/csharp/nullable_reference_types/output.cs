@@ -0,0 +1,51 @@
+using System;
+using System.Collections.Generic;
+using System.Text.Json.Serialization;
+
+namespace Example.Generated;
+
+public sealed record Address
+{
+    [JsonPropertyName("city")]
+    [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+    public string City { get; init; }
+
+    [JsonPropertyName("street")]
+    public required string Street { get; init; }
+}
+
+/// <summary>
+/// A user profile with optional and nullable fields
+/// </summary>
+public sealed record Profile
+{
+    [JsonPropertyName("address")]
+    [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+    public Address Address { get; init; }
+
+    [JsonPropertyName("age")]
+    [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+    public long? Age { get; init; }
+
+    /// <summary>
+    /// Free-form text shown on the profile page
+    /// </summary>
+    [JsonPropertyName("bio")]
+    public required string Bio { get; init; }
+
+    [JsonPropertyName("createdAt")]
+    public required DateTimeOffset CreatedAt { get; init; }
+
+    [JsonPropertyName("displayName")]
+    public required string DisplayName { get; init; }
+
+    [JsonPropertyName("id")]
+    public required Guid Id { get; init; }
+
+    [JsonPropertyName("labels")]
+    [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingNull)]
+    public Dictionary<string, string> Labels { get; init; }
+
+    [JsonPropertyName("verified")]
+    public bool Verified { get; init; } = false;
+}
@@ -0,0 +1,29 @@
+import 'package:json_annotation/json_annotation.dart';
+
+part 'models.g.dart';
+
+/// Server configuration with default values
+@JsonSerializable(explicitToJson: true)
+class ServerConfig {
+  const ServerConfig({
+    this.debug = false,
+    this.host = 'localhost',
+    this.maxRetries = 3,
+    this.port = 8080,
+    this.tags,
+    this.timeout = 30.0,
+  });
+
+  factory ServerConfig.fromJson(Map<String, dynamic> json) => _$ServerConfigFromJson(json);
+
+  final bool debug;
+  /// The hostname to bind to
+  final String host;
+  final int maxRetries;
+  final int port;
+  @JsonKey(includeIfNull: false)
+  final List<String>? tags;
+  final double timeout;
+
+  Map<String, dynamic> toJson() => _$ServerConfigToJson(this);
+}
@@ -0,0 +1,102 @@
+import 'package:json_annotation/json_annotation.dart';
+
+part 'events.g.dart';
+
+@JsonSerializable(explicitToJson: true)
+class BaseEvent {
+  const BaseEvent({
+    required this.timestamp,
+    required this.type,
+  });
+
+  factory BaseEvent.fromJson(Map<String, dynamic> json) => _$BaseEventFromJson(json);
+
+  final DateTime timestamp;
+  final String type;
+
+  Map<String, dynamic> toJson() => _$BaseEventToJson(this);
+}
+
+sealed class Event {
+  const Event();
+
+  factory Event.fromJson(Map<String, dynamic> json) {
+    final discriminator = json['type'];
+    switch (discriminator) {
+      case 'created':
+        return CreatedEvent.fromJson(json);
+      case 'updated':
+        return UpdatedEvent.fromJson(json);
+      case 'deleted':
+        return DeletedEvent.fromJson(json);
+      default:
+        throw ArgumentError.value(discriminator, 'type', 'unknown Event type');
+    }
+  }
+
+  Map<String, dynamic> toJson();
+}
+
+@JsonSerializable(explicitToJson: true)
+class CreatedEvent extends Event {
+  const CreatedEvent({
+    required this.id,
+    required this.name,
+    required this.timestamp,
+  });
+
+  factory CreatedEvent.fromJson(Map<String, dynamic> json) => _$CreatedEventFromJson(json);
+
+  final String id;
+  final String name;
+  final DateTime timestamp;
+
+  @override
+  Map<String, dynamic> toJson() => {
+        'type': 'created',
+        ..._$CreatedEventToJson(this),
+      };
+}
+
+@JsonSerializable(explicitToJson: true)
+class UpdatedEvent extends Event {
+  const UpdatedEvent({
+    required this.changes,
+    required this.id,
+    required this.timestamp,
+  });
+
+  factory UpdatedEvent.fromJson(Map<String, dynamic> json) => _$UpdatedEventFromJson(json);
+
+  final Map<String, Object?> changes;
+  final String id;
+  final DateTime timestamp;
+
+  @override
+  Map<String, dynamic> toJson() => {
+        'type': 'updated',
+        ..._$UpdatedEventToJson(this),
+      };
+}
+
+@JsonSerializable(explicitToJson: true)
+class DeletedEvent extends Event {
+  const DeletedEvent({
+    required this.id,
+    this.reason,
+    required this.timestamp,
+  });
+
+  factory DeletedEvent.fromJson(Map<String, dynamic> json) => _$DeletedEventFromJson(json);
+
+  final String id;
+  @JsonKey(includeIfNull: false)
+  final String? reason;
+  final DateTime timestamp;
+
+  @override
+  Map<String, dynamic> toJson() => {
+        'type': 'deleted',
+        ..._$DeletedEventToJson(this),
+      };
+}
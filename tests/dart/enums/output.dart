@@ -0,0 +1,51 @@
+import 'package:json_annotation/json_annotation.dart';
+
+part 'models.g.dart';
+
+@JsonEnum()
+enum HttpStatus {
+  @JsonValue(200)
+  value200,
+  @JsonValue(201)
+  value201,
+  @JsonValue(400)
+  value400,
+  @JsonValue(404)
+  value404,
+  @JsonValue(500)
+  value500,
+}
+
+@JsonEnum()
+enum Status {
+  @JsonValue('pending')
+  pending,
+  @JsonValue('in_progress')
+  inProgress,
+  @JsonValue('completed')
+  completed,
+  @JsonValue('failed')
+  failed,
+}
+
+@JsonSerializable(explicitToJson: true)
+class Task {
+  const Task({
+    required this.assignee,
+    this.httpStatus,
+    required this.id,
+    this.status = Status.pending,
+    required this.title,
+  });
+
+  factory Task.fromJson(Map<String, dynamic> json) => _$TaskFromJson(json);
+
+  final String? assignee;
+  @JsonKey(includeIfNull: false)
+  final HttpStatus? httpStatus;
+  final String id;
+  final Status status;
+  final String title;
+
+  Map<String, dynamic> toJson() => _$TaskToJson(this);
+}
@@ -0,0 +1,78 @@
+package additional_properties_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdditionalPropertiesRoundTrip(t *testing.T) {
+	input := `{"id":"1","total":9.5,"note":"gift","tags":["a"]}`
+
+	var order Order
+	require.NoError(t, json.Unmarshal([]byte(input), &order))
+	assert.Equal(t, "1", order.ID)
+	assert.Equal(t, map[string]interface{}{"note": "gift", "tags": []interface{}{"a"}}, order.AdditionalProperties)
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(data))
+}
+
+func TestAdditionalPropertiesTyped(t *testing.T) {
+	var labels Labels
+	require.NoError(t, json.Unmarshal([]byte(`{"primary":{"value":"a"},"extra":{"value":"b"}}`), &labels))
+	assert.Equal(t, map[string]Label{"extra": {Value: "b"}}, labels.AdditionalProperties)
+
+	err := json.Unmarshal([]byte(`{"extra":"b"}`), &labels)
+	assert.ErrorContains(t, err, `Labels: additional property "extra"`)
+}
+
+func TestAdditionalPropertiesNone(t *testing.T) {
+	var order Order
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1"}`), &order))
+	assert.Nil(t, order.AdditionalProperties)
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1"}`, string(data))
+}
+
+func TestAdditionalPropertiesDeclaredWins(t *testing.T) {
+	order := Order{ID: "1", AdditionalProperties: map[string]interface{}{"id": "2", "note": "gift"}}
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","note":"gift"}`, string(data))
+}
+
+func TestAdditionalPropertiesUnionVariant(t *testing.T) {
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"text","text":"hi","lang":"en"}`), &msg))
+
+	text, ok := msg.MessageUnion.(*TextMessage)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"lang": "en"}, text.AdditionalProperties)
+	require.NotNil(t, text.Retries)
+	assert.Equal(t, 1, *text.Retries)
+}
+
+func TestAdditionalPropertiesValidate(t *testing.T) {
+	var labels Labels
+	require.NoError(t, json.Unmarshal([]byte(`{"primary":{"value":"a"},"extra":{"value":""}}`), &labels))
+
+	err := labels.Validate()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Failures, 1)
+	assert.Equal(t, "/extra/value", verr.Failures[0].Path)
+
+	var msg Message
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"text","text":"hi","lang":"en-GB-oxendict"}`), &msg))
+	err = msg.Validate()
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Failures, 1)
+	assert.Equal(t, "/lang", verr.Failures[0].Path)
+}
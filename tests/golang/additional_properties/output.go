@@ -0,0 +1,473 @@
+package additional_properties
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+type Closed struct {
+	ID *string `json:"id,omitempty"`
+}
+
+// Validate checks Closed against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Closed) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Closed) validate(v *validator, path string) {
+}
+
+type Label struct {
+	Value string `json:"value"`
+}
+
+// Validate checks Label against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Label) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Label) validate(v *validator, path string) {
+	if utf8.RuneCountInString(x.Value) < 1 {
+		v.fail(path+"/value", "must be at least 1 characters")
+	}
+}
+
+// Unknown properties must be labels
+type Labels struct {
+	Primary *Label `json:"primary,omitempty"`
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]Label `json:"-"`
+}
+
+// MarshalJSON adds AdditionalProperties to the declared properties
+func (x Labels) MarshalJSON() ([]byte, error) {
+	type plain Labels
+	data, err := json.Marshal(plain(x))
+	if err != nil || len(x.AdditionalProperties) == 0 {
+		return data, err
+	}
+	return marshalAdditional(data, x.AdditionalProperties)
+}
+
+// UnmarshalJSON decodes the properties that aren't declared by the schema
+// into AdditionalProperties.
+func (x *Labels) UnmarshalJSON(data []byte) error {
+	type plain Labels
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	additional, err := unmarshalAdditional[Label](data, "primary")
+	if err != nil {
+		return fmt.Errorf("Labels: %w", err)
+	}
+	x.AdditionalProperties = additional
+	return nil
+}
+
+// Validate checks Labels against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Labels) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Labels) validate(v *validator, path string) {
+	if x.Primary != nil {
+		(*x.Primary).validate(v, path+"/primary")
+	}
+	for k, value := range x.AdditionalProperties {
+		value.validate(v, path+"/"+pointerToken(k))
+	}
+}
+
+type MessageUnion interface {
+	MessageType() string
+	isMessage()
+}
+
+type Message struct {
+	MessageUnion
+}
+
+func (w Message) MarshalJSON() ([]byte, error) {
+	if w.MessageUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.MessageUnion)
+}
+
+func (w *Message) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.MessageUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Message: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Message: missing discriminator field %q", "type")
+	}
+
+	var v MessageUnion
+	switch peek.Type {
+	case "text":
+		v = &TextMessage{}
+	case "image":
+		v = &ImageMessage{}
+	default:
+		return fmt.Errorf("Message: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Message: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.MessageUnion = v
+	return nil
+}
+
+// MessageVisitor has a method for every variant of Message
+type MessageVisitor interface {
+	VisitTextMessage(*TextMessage) error
+	VisitImageMessage(*ImageMessage) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Message) Visit(v MessageVisitor) error {
+	switch u := w.MessageUnion.(type) {
+	case *TextMessage:
+		return v.VisitTextMessage(u)
+	case TextMessage:
+		return v.VisitTextMessage(&u)
+	case *ImageMessage:
+		return v.VisitImageMessage(u)
+	case ImageMessage:
+		return v.VisitImageMessage(&u)
+	}
+	return errors.New("Message: no variant is set")
+}
+
+type TextMessage struct {
+	Retries *int   `json:"retries,omitempty"`
+	Text    string `json:"text"`
+	Type    string `json:"type"`
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+func (TextMessage) isMessage() {}
+
+func (TextMessage) MessageType() string { return "text" }
+
+// NewMessageFromTextMessage wraps v, setting its discriminator
+func NewMessageFromTextMessage(v TextMessage) Message {
+	v.Type = "text"
+	return Message{&v}
+}
+
+type ImageMessage struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+func (ImageMessage) isMessage() {}
+
+func (ImageMessage) MessageType() string { return "image" }
+
+// NewMessageFromImageMessage wraps v, setting its discriminator
+func NewMessageFromImageMessage(v ImageMessage) Message {
+	v.Type = "image"
+	return Message{&v}
+}
+
+// MarshalJSON adds AdditionalProperties to the declared properties
+func (x TextMessage) MarshalJSON() ([]byte, error) {
+	type plain TextMessage
+	data, err := json.Marshal(plain(x))
+	if err != nil || len(x.AdditionalProperties) == 0 {
+		return data, err
+	}
+	return marshalAdditional(data, x.AdditionalProperties)
+}
+
+// UnmarshalJSON decodes the properties that aren't declared by the schema
+// into AdditionalProperties.
+func (x *TextMessage) UnmarshalJSON(data []byte) error {
+	type plain TextMessage
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	additional, err := unmarshalAdditional[string](data, "retries", "text", "type")
+	if err != nil {
+		return fmt.Errorf("TextMessage: %w", err)
+	}
+	x.AdditionalProperties = additional
+	x.ApplyDefaults()
+	return nil
+}
+
+// NewTextMessage returns a TextMessage with the defaults of its schema
+func NewTextMessage() TextMessage {
+	x := TextMessage{}
+	x.ApplyDefaults()
+	return x
+}
+
+// ApplyDefaults sets the optional fields that are unset to the defaults of
+// the schema.
+func (x *TextMessage) ApplyDefaults() {
+	if x.Retries == nil {
+		x.Retries = ptrTo(1)
+	}
+}
+
+// Validate checks Message against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (w Message) Validate() error {
+	v := &validator{}
+	w.validate(v, "")
+	return v.err()
+}
+
+func (w Message) validate(v *validator, path string) {
+	if u, ok := w.MessageUnion.(interface{ validate(*validator, string) }); ok {
+		u.validate(v, path)
+	}
+}
+
+// Validate checks TextMessage against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x TextMessage) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x TextMessage) validate(v *validator, path string) {
+	for k, value := range x.AdditionalProperties {
+		if utf8.RuneCountInString(value) > 8 {
+			v.fail(path+"/"+pointerToken(k), "must be at most 8 characters")
+		}
+	}
+}
+
+// Validate checks ImageMessage against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x ImageMessage) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x ImageMessage) validate(v *validator, path string) {
+}
+
+// Unknown properties are kept as raw values
+type Order struct {
+	ID    string   `json:"id"`
+	Total *float64 `json:"total,omitempty"`
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON adds AdditionalProperties to the declared properties
+func (x Order) MarshalJSON() ([]byte, error) {
+	type plain Order
+	data, err := json.Marshal(plain(x))
+	if err != nil || len(x.AdditionalProperties) == 0 {
+		return data, err
+	}
+	return marshalAdditional(data, x.AdditionalProperties)
+}
+
+// UnmarshalJSON decodes the properties that aren't declared by the schema
+// into AdditionalProperties.
+func (x *Order) UnmarshalJSON(data []byte) error {
+	type plain Order
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	additional, err := unmarshalAdditional[interface{}](data, "id", "total")
+	if err != nil {
+		return fmt.Errorf("Order: %w", err)
+	}
+	x.AdditionalProperties = additional
+	return nil
+}
+
+// Validate checks Order against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Order) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Order) validate(v *validator, path string) {
+}
+
+// marshalAdditional adds additional properties to the JSON object of the
+// declared ones, which take precedence over additional properties of the same
+// name.
+func marshalAdditional[T any](data []byte, additional map[string]T) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for k, v := range additional {
+		if _, ok := object[k]; ok {
+			continue
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		object[k] = value
+	}
+	return json.Marshal(object)
+}
+
+// unmarshalAdditional decodes the properties of a JSON object besides the
+// known ones, returning nil when there are none.
+func unmarshalAdditional[T any](data []byte, known ...string) (map[string]T, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		delete(object, k)
+	}
+	if len(object) == 0 {
+		return nil, nil
+	}
+	additional := make(map[string]T, len(object))
+	for k, raw := range object {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("additional property %q: %w", k, err)
+		}
+		additional[k] = v
+	}
+	return additional, nil
+}
+
+// ptrTo returns a pointer to a copy of v
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+// ValidationError is returned by Validate, listing every value that failed
+// validation.
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+// ValidationFailure is a value that failed validation
+type ValidationFailure struct {
+	// Path is the JSON pointer to the value, such as "/items/0/name"
+	Path string
+	// Message describes the constraint the value failed
+	Message string
+}
+
+func (f ValidationFailure) Error() string {
+	return f.Path + ": " + f.Message
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failures, so errors.As can match a ValidationFailure
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Each calls fn with every failure, so types in other packages can collect
+// them.
+func (e *ValidationError) Each(fn func(path, message string)) {
+	for _, f := range e.Failures {
+		fn(f.Path, f.Message)
+	}
+}
+
+type validator struct {
+	failures []ValidationFailure
+}
+
+func (v *validator) fail(path, message string) {
+	v.failures = append(v.failures, ValidationFailure{Path: path, Message: message})
+}
+
+// merge adds the failures of a type from another package under path
+func (v *validator) merge(path string, err error) {
+	if err == nil {
+		return
+	}
+	if e, ok := err.(interface {
+		Each(func(path, message string))
+	}); ok {
+		e.Each(func(p, message string) { v.fail(path+p, message) })
+		return
+	}
+	v.fail(path, err.Error())
+}
+
+func (v *validator) err() error {
+	if len(v.failures) == 0 {
+		return nil
+	}
+	return &ValidationError{Failures: v.failures}
+}
+
+// pointerToken escapes a map key for use in a JSON pointer
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// uniqueItems compares items by their JSON encoding, since they may not be
+// comparable
+func uniqueItems[T any](items []T) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return false
+		}
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+func multipleOf(value, divisor float64) bool {
+	q := value / divisor
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
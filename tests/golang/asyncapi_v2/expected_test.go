@@ -0,0 +1,125 @@
+package asyncapi_v2_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+type LineItem struct {
+	Quantity int    `json:"quantity"`
+	Sku      string `json:"sku"`
+}
+
+// A note attached to an order
+type OrderNote struct {
+	Text string `json:"text"`
+}
+
+type OrdersMessageUnion interface {
+	OrdersMessageType() string
+	isOrdersMessage()
+}
+
+type OrdersMessage struct {
+	OrdersMessageUnion
+}
+
+func (w OrdersMessage) MarshalJSON() ([]byte, error) {
+	if w.OrdersMessageUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.OrdersMessageUnion)
+}
+
+func (w *OrdersMessage) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.OrdersMessageUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("OrdersMessage: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("OrdersMessage: missing discriminator field %q", "type")
+	}
+
+	var v OrdersMessageUnion
+	switch peek.Type {
+	case "order_created":
+		v = &OrderCreated{}
+	case "order_cancelled":
+		v = &OrderCancelled{}
+	default:
+		return fmt.Errorf("OrdersMessage: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("OrdersMessage: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.OrdersMessageUnion = v
+	return nil
+}
+
+// OrdersMessageVisitor has a method for every variant of OrdersMessage
+type OrdersMessageVisitor interface {
+	VisitOrderCreated(*OrderCreated) error
+	VisitOrderCancelled(*OrderCancelled) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w OrdersMessage) Visit(v OrdersMessageVisitor) error {
+	switch u := w.OrdersMessageUnion.(type) {
+	case *OrderCreated:
+		return v.VisitOrderCreated(u)
+	case OrderCreated:
+		return v.VisitOrderCreated(&u)
+	case *OrderCancelled:
+		return v.VisitOrderCancelled(u)
+	case OrderCancelled:
+		return v.VisitOrderCancelled(&u)
+	}
+	return errors.New("OrdersMessage: no variant is set")
+}
+
+// An order was placed
+type OrderCreated struct {
+	Items   []LineItem `json:"items"`
+	OrderID uuid.UUID  `json:"orderId"`
+	Type    string     `json:"type"`
+}
+
+func (OrderCreated) isOrdersMessage() {}
+
+func (OrderCreated) OrdersMessageType() string { return "order_created" }
+
+// NewOrdersMessageFromOrderCreated wraps v, setting its discriminator
+func NewOrdersMessageFromOrderCreated(v OrderCreated) OrdersMessage {
+	v.Type = "order_created"
+	return OrdersMessage{&v}
+}
+
+// An order was cancelled
+type OrderCancelled struct {
+	OrderID uuid.UUID `json:"orderId"`
+	Reason  *string   `json:"reason,omitempty"`
+	Type    string    `json:"type"`
+}
+
+func (OrderCancelled) isOrdersMessage() {}
+
+func (OrderCancelled) OrdersMessageType() string { return "order_cancelled" }
+
+// NewOrdersMessageFromOrderCancelled wraps v, setting its discriminator
+func NewOrdersMessageFromOrderCancelled(v OrderCancelled) OrdersMessage {
+	v.Type = "order_cancelled"
+	return OrdersMessage{&v}
+}
@@ -0,0 +1,116 @@
+package asyncapi_v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+)
+
+type Ping struct {
+	At time.Time `json:"at"`
+}
+
+type UserEventsMessageUnion interface {
+	UserEventsMessageType() string
+	isUserEventsMessage()
+}
+
+type UserEventsMessage struct {
+	UserEventsMessageUnion
+}
+
+func (w UserEventsMessage) MarshalJSON() ([]byte, error) {
+	if w.UserEventsMessageUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.UserEventsMessageUnion)
+}
+
+func (w *UserEventsMessage) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.UserEventsMessageUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"event"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("UserEventsMessage: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("UserEventsMessage: missing discriminator field %q", "event")
+	}
+
+	var v UserEventsMessageUnion
+	switch peek.Type {
+	case "deleted":
+		v = &UserDeleted{}
+	case "signed_up":
+		v = &UserSignedUp{}
+	default:
+		return fmt.Errorf("UserEventsMessage: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("UserEventsMessage: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.UserEventsMessageUnion = v
+	return nil
+}
+
+// UserEventsMessageVisitor has a method for every variant of UserEventsMessage
+type UserEventsMessageVisitor interface {
+	VisitUserDeleted(*UserDeleted) error
+	VisitUserSignedUp(*UserSignedUp) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w UserEventsMessage) Visit(v UserEventsMessageVisitor) error {
+	switch u := w.UserEventsMessageUnion.(type) {
+	case *UserDeleted:
+		return v.VisitUserDeleted(u)
+	case UserDeleted:
+		return v.VisitUserDeleted(&u)
+	case *UserSignedUp:
+		return v.VisitUserSignedUp(u)
+	case UserSignedUp:
+		return v.VisitUserSignedUp(&u)
+	}
+	return errors.New("UserEventsMessage: no variant is set")
+}
+
+type UserDeleted struct {
+	Event string `json:"event"`
+	Hard  *bool  `json:"hard,omitempty"`
+}
+
+func (UserDeleted) isUserEventsMessage() {}
+
+func (UserDeleted) UserEventsMessageType() string { return "deleted" }
+
+// NewUserEventsMessageFromUserDeleted wraps v, setting its discriminator
+func NewUserEventsMessageFromUserDeleted(v UserDeleted) UserEventsMessage {
+	v.Event = "deleted"
+	return UserEventsMessage{&v}
+}
+
+type UserSignedUp struct {
+	Email mail.Address `json:"email"`
+	Event string       `json:"event"`
+}
+
+func (UserSignedUp) isUserEventsMessage() {}
+
+func (UserSignedUp) UserEventsMessageType() string { return "signed_up" }
+
+// NewUserEventsMessageFromUserSignedUp wraps v, setting its discriminator
+func NewUserEventsMessageFromUserSignedUp(v UserSignedUp) UserEventsMessage {
+	v.Event = "signed_up"
+	return UserEventsMessage{&v}
+}
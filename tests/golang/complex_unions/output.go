@@ -3,6 +3,7 @@ package complex_unions
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -12,7 +13,93 @@ type AllOfComposition struct {
 	Timestamp *string `json:"timestamp,omitempty"`
 }
 
-type MultiType = interface{}
+type MultiType struct {
+	String      *string
+	Int         *int
+	Bool        *bool
+	StringArray *[]string
+}
+
+func (u MultiType) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.String != nil:
+		return json.Marshal(u.String)
+	case u.Int != nil:
+		return json.Marshal(u.Int)
+	case u.Bool != nil:
+		return json.Marshal(u.Bool)
+	case u.StringArray != nil:
+		return json.Marshal(u.StringArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *MultiType) UnmarshalJSON(data []byte) error {
+	*u = MultiType{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "string" {
+		if v, err := decode[string](data); err == nil {
+			u.String = &v
+			return nil
+		}
+	}
+	if kind == "number" {
+		if v, err := decode[int](data); err == nil {
+			u.Int = &v
+			return nil
+		}
+	}
+	if kind == "boolean" {
+		if v, err := decode[bool](data); err == nil {
+			u.Bool = &v
+			return nil
+		}
+	}
+	if kind == "array" {
+		if v, err := decode[[]string](data); err == nil {
+			u.StringArray = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("MultiType: %s value matches none of the variants", kind)
+}
+
+// AsString returns the String variant, and whether it is set
+func (u MultiType) AsString() (v string, ok bool) {
+	if u.String != nil {
+		return *u.String, true
+	}
+	return v, false
+}
+
+// AsInt returns the Int variant, and whether it is set
+func (u MultiType) AsInt() (v int, ok bool) {
+	if u.Int != nil {
+		return *u.Int, true
+	}
+	return v, false
+}
+
+// AsBool returns the Bool variant, and whether it is set
+func (u MultiType) AsBool() (v bool, ok bool) {
+	if u.Bool != nil {
+		return *u.Bool, true
+	}
+	return v, false
+}
+
+// AsStringArray returns the StringArray variant, and whether it is set
+func (u MultiType) AsStringArray() (v []string, ok bool) {
+	if u.StringArray != nil {
+		return *u.StringArray, true
+	}
+	return v, false
+}
 
 type NestedUnion struct {
 	Data interface{} `json:"data,omitempty"`
@@ -72,6 +159,32 @@ func (w *ObjectUnion) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// ObjectUnionVisitor has a method for every variant of ObjectUnion
+type ObjectUnionVisitor interface {
+	VisitObjectUnionA(*ObjectUnionA) error
+	VisitObjectUnionB(*ObjectUnionB) error
+	VisitObjectUnionC(*ObjectUnionC) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w ObjectUnion) Visit(v ObjectUnionVisitor) error {
+	switch u := w.ObjectUnionUnion.(type) {
+	case *ObjectUnionA:
+		return v.VisitObjectUnionA(u)
+	case ObjectUnionA:
+		return v.VisitObjectUnionA(&u)
+	case *ObjectUnionB:
+		return v.VisitObjectUnionB(u)
+	case ObjectUnionB:
+		return v.VisitObjectUnionB(&u)
+	case *ObjectUnionC:
+		return v.VisitObjectUnionC(u)
+	case ObjectUnionC:
+		return v.VisitObjectUnionC(&u)
+	}
+	return errors.New("ObjectUnion: no variant is set")
+}
+
 type ObjectUnionA struct {
 	AField string `json:"aField"`
 	Kind   string `json:"kind"`
@@ -81,6 +194,12 @@ func (ObjectUnionA) isObjectUnion() {}
 
 func (ObjectUnionA) ObjectUnionType() string { return "a" }
 
+// NewObjectUnionFromObjectUnionA wraps v, setting its discriminator
+func NewObjectUnionFromObjectUnionA(v ObjectUnionA) ObjectUnion {
+	v.Kind = "a"
+	return ObjectUnion{&v}
+}
+
 type ObjectUnionB struct {
 	BField int    `json:"bField"`
 	Kind   string `json:"kind"`
@@ -90,6 +209,12 @@ func (ObjectUnionB) isObjectUnion() {}
 
 func (ObjectUnionB) ObjectUnionType() string { return "b" }
 
+// NewObjectUnionFromObjectUnionB wraps v, setting its discriminator
+func NewObjectUnionFromObjectUnionB(v ObjectUnionB) ObjectUnion {
+	v.Kind = "b"
+	return ObjectUnion{&v}
+}
+
 type ObjectUnionC struct {
 	CField bool   `json:"cField"`
 	Kind   string `json:"kind"`
@@ -99,6 +224,142 @@ func (ObjectUnionC) isObjectUnion() {}
 
 func (ObjectUnionC) ObjectUnionType() string { return "c" }
 
-type StringOrNull = interface{}
+// NewObjectUnionFromObjectUnionC wraps v, setting its discriminator
+func NewObjectUnionFromObjectUnionC(v ObjectUnionC) ObjectUnion {
+	v.Kind = "c"
+	return ObjectUnion{&v}
+}
+
+type StringOrNull struct {
+	String *string
+}
+
+func (u StringOrNull) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.String != nil:
+		return json.Marshal(u.String)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *StringOrNull) UnmarshalJSON(data []byte) error {
+	*u = StringOrNull{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "string" {
+		if v, err := decode[string](data); err == nil {
+			u.String = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("StringOrNull: %s value matches none of the variants", kind)
+}
+
+// AsString returns the String variant, and whether it is set
+func (u StringOrNull) AsString() (v string, ok bool) {
+	if u.String != nil {
+		return *u.String, true
+	}
+	return v, false
+}
+
+type StringOrNumber struct {
+	String *string
+	Float  *float64
+}
+
+func (u StringOrNumber) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.String != nil:
+		return json.Marshal(u.String)
+	case u.Float != nil:
+		return json.Marshal(u.Float)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *StringOrNumber) UnmarshalJSON(data []byte) error {
+	*u = StringOrNumber{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "string" {
+		if v, err := decode[string](data); err == nil {
+			u.String = &v
+			return nil
+		}
+	}
+	if kind == "number" {
+		if v, err := decode[float64](data); err == nil {
+			u.Float = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("StringOrNumber: %s value matches none of the variants", kind)
+}
+
+// AsString returns the String variant, and whether it is set
+func (u StringOrNumber) AsString() (v string, ok bool) {
+	if u.String != nil {
+		return *u.String, true
+	}
+	return v, false
+}
+
+// AsFloat returns the Float variant, and whether it is set
+func (u StringOrNumber) AsFloat() (v float64, ok bool) {
+	if u.Float != nil {
+		return *u.Float, true
+	}
+	return v, false
+}
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
 
-type StringOrNumber = interface{}
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
@@ -3,6 +3,7 @@ package composed_union_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -59,6 +60,32 @@ func (w *PluginConfigurationField) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// PluginConfigurationFieldVisitor has a method for every variant of PluginConfigurationField
+type PluginConfigurationFieldVisitor interface {
+	VisitPluginConfigurationFieldString(*PluginConfigurationFieldString) error
+	VisitPluginConfigurationFieldNumber(*PluginConfigurationFieldNumber) error
+	VisitPluginConfigurationFieldBoolean(*PluginConfigurationFieldBoolean) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w PluginConfigurationField) Visit(v PluginConfigurationFieldVisitor) error {
+	switch u := w.PluginConfigurationFieldUnion.(type) {
+	case *PluginConfigurationFieldString:
+		return v.VisitPluginConfigurationFieldString(u)
+	case PluginConfigurationFieldString:
+		return v.VisitPluginConfigurationFieldString(&u)
+	case *PluginConfigurationFieldNumber:
+		return v.VisitPluginConfigurationFieldNumber(u)
+	case PluginConfigurationFieldNumber:
+		return v.VisitPluginConfigurationFieldNumber(&u)
+	case *PluginConfigurationFieldBoolean:
+		return v.VisitPluginConfigurationFieldBoolean(u)
+	case PluginConfigurationFieldBoolean:
+		return v.VisitPluginConfigurationFieldBoolean(&u)
+	}
+	return errors.New("PluginConfigurationField: no variant is set")
+}
+
 type PluginConfigurationFieldString struct {
 	Default *string `json:"default,omitempty"`
 	// A description of the configuration field.
@@ -76,6 +103,12 @@ func (PluginConfigurationFieldString) isPluginConfigurationField() {}
 
 func (PluginConfigurationFieldString) PluginConfigurationFieldType() string { return "string" }
 
+// NewPluginConfigurationFieldFromPluginConfigurationFieldString wraps v, setting its discriminator
+func NewPluginConfigurationFieldFromPluginConfigurationFieldString(v PluginConfigurationFieldString) PluginConfigurationField {
+	v.Type = "string"
+	return PluginConfigurationField{&v}
+}
+
 type PluginConfigurationFieldNumber struct {
 	Default *float64 `json:"default,omitempty"`
 	// A description of the configuration field.
@@ -93,6 +126,12 @@ func (PluginConfigurationFieldNumber) isPluginConfigurationField() {}
 
 func (PluginConfigurationFieldNumber) PluginConfigurationFieldType() string { return "number" }
 
+// NewPluginConfigurationFieldFromPluginConfigurationFieldNumber wraps v, setting its discriminator
+func NewPluginConfigurationFieldFromPluginConfigurationFieldNumber(v PluginConfigurationFieldNumber) PluginConfigurationField {
+	v.Type = "number"
+	return PluginConfigurationField{&v}
+}
+
 type PluginConfigurationFieldBoolean struct {
 	Default *bool `json:"default,omitempty"`
 	// A description of the configuration field.
@@ -110,6 +149,12 @@ func (PluginConfigurationFieldBoolean) isPluginConfigurationField() {}
 
 func (PluginConfigurationFieldBoolean) PluginConfigurationFieldType() string { return "boolean" }
 
+// NewPluginConfigurationFieldFromPluginConfigurationFieldBoolean wraps v, setting its discriminator
+func NewPluginConfigurationFieldFromPluginConfigurationFieldBoolean(v PluginConfigurationFieldBoolean) PluginConfigurationField {
+	v.Type = "boolean"
+	return PluginConfigurationField{&v}
+}
+
 type PluginConfigurationFieldSchema = PluginConfigurationField
 
 type PluginConfigurationSchema struct {
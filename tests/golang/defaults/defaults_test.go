@@ -0,0 +1,132 @@
+package defaults_test
+
+import (
+	"encoding/json"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+)
+
+func TestNewServerConfig(t *testing.T) {
+	c := NewServerConfig()
+
+	assert.Equal(t, "localhost", c.Host)
+	assert.Equal(t, 8080, *c.Port)
+	assert.Equal(t, false, *c.Debug)
+	assert.Equal(t, 30.0, *c.Timeout)
+	assert.Equal(t, LogLevelInfo, *c.LogLevel)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *c.StartedAt)
+	assert.Equal(t, []string{"web", "api"}, c.Tags)
+	assert.Equal(t, 100, *c.Limits.Requests)
+	assert.Nil(t, c.Name)
+}
+
+func TestNewServerConfigFormats(t *testing.T) {
+	c := NewServerConfig()
+
+	assert.Equal(t, "2024-03-15", c.LaunchDate.Format(time.DateOnly))
+	assert.True(t, time.Date(2024, 12, 31, 16, 30, 0, 5e8, time.UTC).Equal(*c.ClosesAt))
+	assert.Equal(t, mail.Address{Name: "Ops Team", Address: "ops@example.com"}, *c.Contact)
+	assert.Equal(t, "https://example.com/docs?lang=en#intro", c.Homepage.String())
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", c.InstanceID.String())
+}
+
+func TestNewServerConfigComposite(t *testing.T) {
+	c := NewServerConfig()
+
+	// Integers above 2^53 keep their precision
+	assert.Equal(t, 9007199254740993, *c.MaxBytes)
+	assert.Equal(t, map[string]string{"Accept": "application/json", "X-Env": "prod"}, c.Headers)
+	assert.Equal(t, 10, *c.Limits.Burst)
+
+	job, ok := c.Job.JobUnion.(*EmailJob)
+	require.True(t, ok)
+	assert.Equal(t, EmailJob{Kind: "email", To: "ops@example.com"}, *job)
+}
+
+func TestInvalidDefaults(t *testing.T) {
+	for name, tc := range map[string]struct {
+		property string
+		err      string
+	}{
+		"date":      {"type: string\n        format: date\n        default: next tuesday", `golang: default of Settings.value: "next tuesday" isn't a date`},
+		"integer":   {"type: integer\n        default: 1.5", "golang: default of Settings.value: 1.5 isn't an int64 integer"},
+		"enum":      {"$ref: '#/$defs/Level'\n        default: loud", `golang: default of Settings.value: "loud" isn't a value of Level`},
+		"array":     {"type: array\n        items:\n          type: integer\n        default: [1, two]", `golang: default of Settings.value: item 1: "two" isn't an integer`},
+		"object":    {"$ref: '#/$defs/Limits'\n        default: {requests: 1, bursts: 2}", `golang: default of Settings.value: property "bursts" isn't a field of Limits`},
+		"null":      {"type: array\n        items:\n          type: string\n        default: [a, null]", "golang: default of Settings.value: item 1: null isn't a string"},
+		"unmatched": {"$ref: '#/$defs/Job'\n        default: {kind: archive}", `golang: default of Settings.value: "archive" isn't a variant of Job`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			schema, err := loader.FromReader(strings.NewReader(`
+$defs:
+  Level:
+    type: string
+    enum: [quiet, normal]
+  Limits:
+    type: object
+    properties:
+      requests:
+        type: integer
+  Job:
+    oneOf:
+      - $ref: "#/$defs/EmailJob"
+      - $ref: "#/$defs/CleanupJob"
+  EmailJob:
+    type: object
+    required: [kind]
+    properties:
+      kind:
+        const: email
+  CleanupJob:
+    type: object
+    required: [kind]
+    properties:
+      kind:
+        const: cleanup
+  Settings:
+    type: object
+    properties:
+      value:
+        ` + tc.property + `
+`))
+			require.NoError(t, err)
+
+			_, err = schemancer.Generate(schema, generators.GlobalOptions{}, golang.WithPackageName("defaults"))
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestApplyDefaultsKeepsSetFields(t *testing.T) {
+	port := 9000
+	c := ServerConfig{Port: &port, Tags: []string{}}
+	c.ApplyDefaults()
+
+	assert.Equal(t, 9000, *c.Port)
+	assert.Equal(t, []string{}, c.Tags)
+	assert.Equal(t, LogLevelInfo, *c.LogLevel)
+}
+
+func TestUnmarshalAppliesDefaults(t *testing.T) {
+	var c ServerConfig
+	require.NoError(t, json.Unmarshal([]byte(`{"host": "example.com", "port": 443, "logLevel": "debug"}`), &c))
+
+	assert.Equal(t, "example.com", c.Host)
+	assert.Equal(t, 443, *c.Port)
+	assert.Equal(t, LogLevelDebug, *c.LogLevel)
+	assert.Equal(t, 30.0, *c.Timeout)
+
+	var job Job
+	require.NoError(t, json.Unmarshal([]byte(`{"kind": "email", "to": "a@example.com"}`), &job))
+	assert.Equal(t, 3, *job.JobUnion.(*EmailJob).Retries)
+}
@@ -0,0 +1,281 @@
+package defaults
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+type JobUnion interface {
+	JobType() string
+	isJob()
+}
+
+type Job struct {
+	JobUnion
+}
+
+func (w Job) MarshalJSON() ([]byte, error) {
+	if w.JobUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.JobUnion)
+}
+
+func (w *Job) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.JobUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Job: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Job: missing discriminator field %q", "kind")
+	}
+
+	var v JobUnion
+	switch peek.Type {
+	case "email":
+		v = &EmailJob{}
+	case "cleanup":
+		v = &CleanupJob{}
+	default:
+		return fmt.Errorf("Job: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Job: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.JobUnion = v
+	return nil
+}
+
+// JobVisitor has a method for every variant of Job
+type JobVisitor interface {
+	VisitEmailJob(*EmailJob) error
+	VisitCleanupJob(*CleanupJob) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Job) Visit(v JobVisitor) error {
+	switch u := w.JobUnion.(type) {
+	case *EmailJob:
+		return v.VisitEmailJob(u)
+	case EmailJob:
+		return v.VisitEmailJob(&u)
+	case *CleanupJob:
+		return v.VisitCleanupJob(u)
+	case CleanupJob:
+		return v.VisitCleanupJob(&u)
+	}
+	return errors.New("Job: no variant is set")
+}
+
+type EmailJob struct {
+	Kind    string `json:"kind"`
+	Retries *int   `json:"retries,omitempty"`
+	To      string `json:"to"`
+}
+
+func (EmailJob) isJob() {}
+
+func (EmailJob) JobType() string { return "email" }
+
+// NewJobFromEmailJob wraps v, setting its discriminator
+func NewJobFromEmailJob(v EmailJob) Job {
+	v.Kind = "email"
+	return Job{&v}
+}
+
+type CleanupJob struct {
+	Kind          string `json:"kind"`
+	OlderThanDays *int   `json:"olderThanDays,omitempty"`
+}
+
+func (CleanupJob) isJob() {}
+
+func (CleanupJob) JobType() string { return "cleanup" }
+
+// NewJobFromCleanupJob wraps v, setting its discriminator
+func NewJobFromCleanupJob(v CleanupJob) Job {
+	v.Kind = "cleanup"
+	return Job{&v}
+}
+
+// NewEmailJob returns an EmailJob with the defaults of its schema
+func NewEmailJob() EmailJob {
+	x := EmailJob{}
+	x.ApplyDefaults()
+	return x
+}
+
+// ApplyDefaults sets the optional fields that are unset to the defaults of
+// the schema.
+func (x *EmailJob) ApplyDefaults() {
+	if x.Retries == nil {
+		x.Retries = ptrTo(3)
+	}
+}
+
+// UnmarshalJSON applies the defaults of the schema to the optional fields
+// missing from data.
+func (x *EmailJob) UnmarshalJSON(data []byte) error {
+	type plain EmailJob
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	x.ApplyDefaults()
+	return nil
+}
+
+type Limits struct {
+	Burst    *int `json:"burst,omitempty"`
+	Requests *int `json:"requests,omitempty"`
+}
+
+type LogLevel string
+
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+)
+
+var LogLevelValues = []LogLevel{
+	LogLevelDebug,
+	LogLevelInfo,
+	LogLevelWarn,
+}
+
+// IsValid reports whether the value is one of LogLevelValues
+func (e LogLevel) IsValid() bool {
+	for _, v := range LogLevelValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e LogLevel) String() string {
+	return string(e)
+}
+
+// ParseLogLevel converts a string to a LogLevel, returning an error when it
+// is not one of LogLevelValues.
+func ParseLogLevel(s string) (LogLevel, error) {
+	e := LogLevel(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid LogLevel %q", s)
+	}
+	return e, nil
+}
+
+// Server configuration with default values
+type ServerConfig struct {
+	ClosesAt *time.Time        `json:"closesAt,omitempty"`
+	Contact  *mail.Address     `json:"contact,omitempty"`
+	Debug    *bool             `json:"debug,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Homepage *url.URL          `json:"homepage,omitempty"`
+	// The hostname to bind to
+	Host       string     `json:"host"`
+	InstanceID *uuid.UUID `json:"instanceId,omitempty"`
+	Job        *Job       `json:"job,omitempty"`
+	LaunchDate *time.Time `json:"launchDate,omitempty"`
+	Limits     *Limits    `json:"limits,omitempty"`
+	LogLevel   *LogLevel  `json:"logLevel,omitempty"`
+	MaxBytes   *int       `json:"maxBytes,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	Port       *int       `json:"port,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	Tags       []string   `json:"tags,omitempty"`
+	Timeout    *float64   `json:"timeout,omitempty"`
+}
+
+// NewServerConfig returns a ServerConfig with the defaults of its schema
+func NewServerConfig() ServerConfig {
+	x := ServerConfig{
+		Host: "localhost",
+	}
+	x.ApplyDefaults()
+	return x
+}
+
+// ApplyDefaults sets the optional fields that are unset to the defaults of
+// the schema.
+func (x *ServerConfig) ApplyDefaults() {
+	if x.ClosesAt == nil {
+		x.ClosesAt = ptrTo(time.Date(2024, time.December, 31, 18, 30, 0, 500000000, time.FixedZone("", 7200)))
+	}
+	if x.Contact == nil {
+		x.Contact = ptrTo(mail.Address{Name: "Ops Team", Address: "ops@example.com"})
+	}
+	if x.Debug == nil {
+		x.Debug = ptrTo(false)
+	}
+	if x.Headers == nil {
+		x.Headers = map[string]string{"Accept": "application/json", "X-Env": "prod"}
+	}
+	if x.Homepage == nil {
+		x.Homepage = ptrTo(url.URL{Scheme: "https", Host: "example.com", Path: "/docs", RawQuery: "lang=en", Fragment: "intro"})
+	}
+	if x.InstanceID == nil {
+		x.InstanceID = ptrTo(uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	}
+	if x.Job == nil {
+		x.Job = ptrTo(Job{&EmailJob{Kind: "email", To: "ops@example.com"}})
+	}
+	if x.LaunchDate == nil {
+		x.LaunchDate = ptrTo(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC))
+	}
+	if x.Limits == nil {
+		x.Limits = ptrTo(Limits{Burst: ptrTo(10), Requests: ptrTo(100)})
+	}
+	if x.LogLevel == nil {
+		x.LogLevel = ptrTo(LogLevelInfo)
+	}
+	if x.MaxBytes == nil {
+		x.MaxBytes = ptrTo(9007199254740993)
+	}
+	if x.Port == nil {
+		x.Port = ptrTo(8080)
+	}
+	if x.StartedAt == nil {
+		x.StartedAt = ptrTo(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+	if x.Tags == nil {
+		x.Tags = []string{"web", "api"}
+	}
+	if x.Timeout == nil {
+		x.Timeout = ptrTo(30.0)
+	}
+}
+
+// UnmarshalJSON applies the defaults of the schema to the optional fields
+// missing from data.
+func (x *ServerConfig) UnmarshalJSON(data []byte) error {
+	type plain ServerConfig
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	x.ApplyDefaults()
+	return nil
+}
+
+// ptrTo returns a pointer to a copy of v
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -3,6 +3,7 @@ package discriminated_union
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -65,6 +66,32 @@ func (w *Event) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// EventVisitor has a method for every variant of Event
+type EventVisitor interface {
+	VisitCreatedEvent(*CreatedEvent) error
+	VisitUpdatedEvent(*UpdatedEvent) error
+	VisitDeletedEvent(*DeletedEvent) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Event) Visit(v EventVisitor) error {
+	switch u := w.EventUnion.(type) {
+	case *CreatedEvent:
+		return v.VisitCreatedEvent(u)
+	case CreatedEvent:
+		return v.VisitCreatedEvent(&u)
+	case *UpdatedEvent:
+		return v.VisitUpdatedEvent(u)
+	case UpdatedEvent:
+		return v.VisitUpdatedEvent(&u)
+	case *DeletedEvent:
+		return v.VisitDeletedEvent(u)
+	case DeletedEvent:
+		return v.VisitDeletedEvent(&u)
+	}
+	return errors.New("Event: no variant is set")
+}
+
 type CreatedEvent struct {
 	ID        string    `json:"id"`
 	Name      string    `json:"name"`
@@ -76,6 +103,12 @@ func (CreatedEvent) isEvent() {}
 
 func (CreatedEvent) EventType() string { return "created" }
 
+// NewEventFromCreatedEvent wraps v, setting its discriminator
+func NewEventFromCreatedEvent(v CreatedEvent) Event {
+	v.Type = "created"
+	return Event{&v}
+}
+
 type UpdatedEvent struct {
 	Changes   map[string]interface{} `json:"changes"`
 	ID        string                 `json:"id"`
@@ -87,6 +120,12 @@ func (UpdatedEvent) isEvent() {}
 
 func (UpdatedEvent) EventType() string { return "updated" }
 
+// NewEventFromUpdatedEvent wraps v, setting its discriminator
+func NewEventFromUpdatedEvent(v UpdatedEvent) Event {
+	v.Type = "updated"
+	return Event{&v}
+}
+
 type DeletedEvent struct {
 	ID        string    `json:"id"`
 	Reason    *string   `json:"reason,omitempty"`
@@ -97,3 +136,9 @@ type DeletedEvent struct {
 func (DeletedEvent) isEvent() {}
 
 func (DeletedEvent) EventType() string { return "deleted" }
+
+// NewEventFromDeletedEvent wraps v, setting its discriminator
+func NewEventFromDeletedEvent(v DeletedEvent) Event {
+	v.Type = "deleted"
+	return Event{&v}
+}
@@ -0,0 +1,62 @@
+package discriminated_union_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventNames struct {
+	names []string
+}
+
+func (e *eventNames) VisitCreatedEvent(v *CreatedEvent) error {
+	e.names = append(e.names, "created "+v.Name)
+	return nil
+}
+
+func (e *eventNames) VisitUpdatedEvent(v *UpdatedEvent) error {
+	e.names = append(e.names, "updated "+v.ID)
+	return nil
+}
+
+func (e *eventNames) VisitDeletedEvent(v *DeletedEvent) error {
+	e.names = append(e.names, "deleted "+v.ID)
+	return nil
+}
+
+func TestVisit(t *testing.T) {
+	var events []Event
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type": "created", "id": "1", "name": "first"},
+		{"type": "updated", "id": "1", "changes": {}},
+		{"type": "deleted", "id": "1"}
+	]`), &events))
+
+	visitor := &eventNames{}
+	for _, e := range events {
+		require.NoError(t, e.Visit(visitor))
+	}
+	assert.Equal(t, []string{"created first", "updated 1", "deleted 1"}, visitor.names)
+
+	// Variants held by value are visited too
+	visitor.names = nil
+	require.NoError(t, Event{DeletedEvent{ID: "2"}}.Visit(visitor))
+	assert.Equal(t, []string{"deleted 2"}, visitor.names)
+
+	assert.Error(t, Event{}.Visit(visitor))
+}
+
+func TestConstructors(t *testing.T) {
+	e := NewEventFromCreatedEvent(CreatedEvent{ID: "1", Name: "first"})
+	assert.Equal(t, "created", e.EventType())
+
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, &CreatedEvent{ID: "1", Name: "first", Type: "created"}, decoded.EventUnion)
+}
@@ -1,5 +1,9 @@
 package empty_minimal_test
 
+import (
+	"fmt"
+)
+
 type ArrayNoItems = []interface{}
 
 type EmptyEnum = string
@@ -35,4 +39,28 @@ var SingleEnumValues = []SingleEnum{
 	SingleEnumOnlyValue,
 }
 
+// IsValid reports whether the value is one of SingleEnumValues
+func (e SingleEnum) IsValid() bool {
+	for _, v := range SingleEnumValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e SingleEnum) String() string {
+	return string(e)
+}
+
+// ParseSingleEnum converts a string to a SingleEnum, returning an error when it
+// is not one of SingleEnumValues.
+func ParseSingleEnum(s string) (SingleEnum, error) {
+	e := SingleEnum(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid SingleEnum %q", s)
+	}
+	return e, nil
+}
+
 type TrueSchema = interface{}
@@ -1,5 +1,9 @@
 package enums
 
+import (
+	"fmt"
+)
+
 type HttpMethod string
 
 const (
@@ -22,6 +26,30 @@ var HttpMethodValues = []HttpMethod{
 	HttpMethodOptions,
 }
 
+// IsValid reports whether the value is one of HttpMethodValues
+func (e HttpMethod) IsValid() bool {
+	for _, v := range HttpMethodValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e HttpMethod) String() string {
+	return string(e)
+}
+
+// ParseHttpMethod converts a string to a HttpMethod, returning an error when it
+// is not one of HttpMethodValues.
+func ParseHttpMethod(s string) (HttpMethod, error) {
+	e := HttpMethod(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid HttpMethod %q", s)
+	}
+	return e, nil
+}
+
 type ApiRequest struct {
 	Body   *string    `json:"body,omitempty"`
 	Method HttpMethod `json:"method"`
@@ -44,6 +72,30 @@ var ColorValues = []Color{
 	ColorYellow,
 }
 
+// IsValid reports whether the value is one of ColorValues
+func (e Color) IsValid() bool {
+	for _, v := range ColorValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Color) String() string {
+	return string(e)
+}
+
+// ParseColor converts a string to a Color, returning an error when it
+// is not one of ColorValues.
+func ParseColor(s string) (Color, error) {
+	e := Color(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Color %q", s)
+	}
+	return e, nil
+}
+
 type Priority string
 
 const (
@@ -60,6 +112,30 @@ var PriorityValues = []Priority{
 	PriorityCritical,
 }
 
+// IsValid reports whether the value is one of PriorityValues
+func (e Priority) IsValid() bool {
+	for _, v := range PriorityValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Priority) String() string {
+	return string(e)
+}
+
+// ParsePriority converts a string to a Priority, returning an error when it
+// is not one of PriorityValues.
+func ParsePriority(s string) (Priority, error) {
+	e := Priority(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Priority %q", s)
+	}
+	return e, nil
+}
+
 type Status string
 
 const (
@@ -78,6 +154,30 @@ var StatusValues = []Status{
 	StatusCancelled,
 }
 
+// IsValid reports whether the value is one of StatusValues
+func (e Status) IsValid() bool {
+	for _, v := range StatusValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Status) String() string {
+	return string(e)
+}
+
+// ParseStatus converts a string to a Status, returning an error when it
+// is not one of StatusValues.
+func ParseStatus(s string) (Status, error) {
+	e := Status(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Status %q", s)
+	}
+	return e, nil
+}
+
 type Task struct {
 	Color    *Color    `json:"color,omitempty"`
 	ID       string    `json:"id"`
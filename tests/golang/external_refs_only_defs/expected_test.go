@@ -3,6 +3,7 @@ package external_refs_only_defs_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -20,6 +21,30 @@ var EventTypeValues = []EventType{
 	EventTypeThreadUpdated,
 }
 
+// IsValid reports whether the value is one of EventTypeValues
+func (e EventType) IsValid() bool {
+	for _, v := range EventTypeValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e EventType) String() string {
+	return string(e)
+}
+
+// ParseEventType converts a string to a EventType, returning an error when it
+// is not one of EventTypeValues.
+func ParseEventType(s string) (EventType, error) {
+	e := EventType(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid EventType %q", s)
+	}
+	return e, nil
+}
+
 type EventPayload struct {
 	Data      map[string]interface{} `json:"data"`
 	EventType EventType              `json:"event_type"`
@@ -85,6 +110,27 @@ func (w *RPCRequestToPlugin) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RPCRequestToPluginVisitor has a method for every variant of RPCRequestToPlugin
+type RPCRequestToPluginVisitor interface {
+	VisitRPCRequestEvent(*RPCRequestEvent) error
+	VisitRPCRequestOther(*RPCRequestOther) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w RPCRequestToPlugin) Visit(v RPCRequestToPluginVisitor) error {
+	switch u := w.RPCRequestToPluginUnion.(type) {
+	case *RPCRequestEvent:
+		return v.VisitRPCRequestEvent(u)
+	case RPCRequestEvent:
+		return v.VisitRPCRequestEvent(&u)
+	case *RPCRequestOther:
+		return v.VisitRPCRequestOther(u)
+	case RPCRequestOther:
+		return v.VisitRPCRequestOther(&u)
+	}
+	return errors.New("RPCRequestToPlugin: no variant is set")
+}
+
 type RPCRequestEvent struct {
 	ID      int          `json:"id"`
 	Jsonrpc string       `json:"jsonrpc"`
@@ -96,6 +142,12 @@ func (RPCRequestEvent) isRPCRequestToPlugin() {}
 
 func (RPCRequestEvent) RPCRequestToPluginType() string { return "event" }
 
+// NewRPCRequestToPluginFromRPCRequestEvent wraps v, setting its discriminator
+func NewRPCRequestToPluginFromRPCRequestEvent(v RPCRequestEvent) RPCRequestToPlugin {
+	v.Method = "event"
+	return RPCRequestToPlugin{&v}
+}
+
 type RPCRequestOther struct {
 	ID      int                   `json:"id"`
 	Jsonrpc string                `json:"jsonrpc"`
@@ -106,3 +158,9 @@ type RPCRequestOther struct {
 func (RPCRequestOther) isRPCRequestToPlugin() {}
 
 func (RPCRequestOther) RPCRequestToPluginType() string { return "other" }
+
+// NewRPCRequestToPluginFromRPCRequestOther wraps v, setting its discriminator
+func NewRPCRequestToPluginFromRPCRequestOther(v RPCRequestOther) RPCRequestToPlugin {
+	v.Method = "other"
+	return RPCRequestToPlugin{&v}
+}
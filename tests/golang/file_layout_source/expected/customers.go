@@ -0,0 +1,18 @@
+package orders
+
+import (
+	"time"
+)
+
+type CustomerContact struct {
+	Email string  `json:"email"`
+	Phone *string `json:"phone,omitempty"`
+}
+
+type Customer struct {
+	Address *Address         `json:"address,omitempty"`
+	Contact *CustomerContact `json:"contact,omitempty"`
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Since   *time.Time       `json:"since,omitempty"`
+}
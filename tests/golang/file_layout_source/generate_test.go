@@ -0,0 +1,26 @@
+package file_layout_source_test
+
+import (
+	"testing"
+
+	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+	"github.com/Southclaws/schemancer/tests/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLayoutSource(t *testing.T) {
+	schema, err := loader.FromFile("schema.yaml")
+	require.NoError(t, err, "failed to load schema")
+
+	files, err := schemancer.Generate(schema, generators.GlobalOptions{},
+		golang.WithPackageName("orders"),
+		golang.WithFileLayout(golang.FileLayoutSource),
+	)
+	require.NoError(t, err, "failed to generate")
+
+	testutil.WriteAndCompareMultipleFiles(t, files, "output", "expected")
+}
@@ -0,0 +1,23 @@
+package orders
+
+import (
+	"github.com/google/uuid"
+)
+
+type OrderItemsItem struct {
+	Quantity int    `json:"quantity"`
+	Sku      string `json:"sku"`
+}
+
+type SplitTest struct {
+	Variant string `json:"variant"`
+}
+
+type Order struct {
+	Customer   Customer         `json:"customer"`
+	Experiment *SplitTest       `json:"experiment,omitempty"`
+	ID         uuid.UUID        `json:"id"`
+	Items      []OrderItemsItem `json:"items"`
+	Payment    *Payment         `json:"payment,omitempty"`
+	ShipTo     *Address         `json:"shipTo,omitempty"`
+}
@@ -0,0 +1,13 @@
+package orders
+
+import (
+	"time"
+)
+
+type Customer struct {
+	Address *Address         `json:"address,omitempty"`
+	Contact *CustomerContact `json:"contact,omitempty"`
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Since   *time.Time       `json:"since,omitempty"`
+}
@@ -0,0 +1,110 @@
+package orders
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type PaymentUnion interface {
+	PaymentType() string
+	isPayment()
+}
+
+type Payment struct {
+	PaymentUnion
+}
+
+func (w Payment) MarshalJSON() ([]byte, error) {
+	if w.PaymentUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.PaymentUnion)
+}
+
+func (w *Payment) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.PaymentUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Payment: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Payment: missing discriminator field %q", "kind")
+	}
+
+	var v PaymentUnion
+	switch peek.Type {
+	case "card":
+		v = &CardPayment{}
+	case "bank":
+		v = &BankPayment{}
+	default:
+		return fmt.Errorf("Payment: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Payment: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.PaymentUnion = v
+	return nil
+}
+
+// PaymentVisitor has a method for every variant of Payment
+type PaymentVisitor interface {
+	VisitCardPayment(*CardPayment) error
+	VisitBankPayment(*BankPayment) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Payment) Visit(v PaymentVisitor) error {
+	switch u := w.PaymentUnion.(type) {
+	case *CardPayment:
+		return v.VisitCardPayment(u)
+	case CardPayment:
+		return v.VisitCardPayment(&u)
+	case *BankPayment:
+		return v.VisitBankPayment(u)
+	case BankPayment:
+		return v.VisitBankPayment(&u)
+	}
+	return errors.New("Payment: no variant is set")
+}
+
+type CardPayment struct {
+	Kind  string `json:"kind"`
+	Last4 string `json:"last4"`
+}
+
+func (CardPayment) isPayment() {}
+
+func (CardPayment) PaymentType() string { return "card" }
+
+// NewPaymentFromCardPayment wraps v, setting its discriminator
+func NewPaymentFromCardPayment(v CardPayment) Payment {
+	v.Kind = "card"
+	return Payment{&v}
+}
+
+type BankPayment struct {
+	Iban string `json:"iban"`
+	Kind string `json:"kind"`
+}
+
+func (BankPayment) isPayment() {}
+
+func (BankPayment) PaymentType() string { return "bank" }
+
+// NewPaymentFromBankPayment wraps v, setting its discriminator
+func NewPaymentFromBankPayment(v BankPayment) Payment {
+	v.Kind = "bank"
+	return Payment{&v}
+}
@@ -0,0 +1,5 @@
+package orders
+
+type SplitTest struct {
+	Variant string `json:"variant"`
+}
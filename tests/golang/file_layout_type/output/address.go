@@ -0,0 +1,6 @@
+package orders
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
@@ -0,0 +1,6 @@
+package orders
+
+type CustomerContact struct {
+	Email string  `json:"email"`
+	Phone *string `json:"phone,omitempty"`
+}
@@ -0,0 +1,14 @@
+package orders
+
+import (
+	"github.com/google/uuid"
+)
+
+type Order struct {
+	Customer   Customer         `json:"customer"`
+	Experiment *SplitTest       `json:"experiment,omitempty"`
+	ID         uuid.UUID        `json:"id"`
+	Items      []OrderItemsItem `json:"items"`
+	Payment    *Payment         `json:"payment,omitempty"`
+	ShipTo     *Address         `json:"shipTo,omitempty"`
+}
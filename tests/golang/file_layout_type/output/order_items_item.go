@@ -0,0 +1,6 @@
+package orders
+
+type OrderItemsItem struct {
+	Quantity int    `json:"quantity"`
+	Sku      string `json:"sku"`
+}
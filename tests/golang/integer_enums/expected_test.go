@@ -1,5 +1,10 @@
 package integer_enums_test
 
+import (
+	"fmt"
+	"strconv"
+)
+
 type HttpStatus int
 
 const (
@@ -18,6 +23,30 @@ var HttpStatusValues = []HttpStatus{
 	HttpStatus500,
 }
 
+// IsValid reports whether the value is one of HttpStatusValues
+func (e HttpStatus) IsValid() bool {
+	for _, v := range HttpStatusValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e HttpStatus) String() string {
+	return strconv.Itoa(int(e))
+}
+
+// ParseHttpStatus converts a string to a HttpStatus, returning an error when it
+// is not one of HttpStatusValues.
+func ParseHttpStatus(s string) (HttpStatus, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || !HttpStatus(n).IsValid() {
+		return 0, fmt.Errorf("invalid HttpStatus %q", s)
+	}
+	return HttpStatus(n), nil
+}
+
 type Priority int
 
 const (
@@ -32,6 +61,30 @@ var PriorityValues = []Priority{
 	Priority3,
 }
 
+// IsValid reports whether the value is one of PriorityValues
+func (e Priority) IsValid() bool {
+	for _, v := range PriorityValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Priority) String() string {
+	return strconv.Itoa(int(e))
+}
+
+// ParsePriority converts a string to a Priority, returning an error when it
+// is not one of PriorityValues.
+func ParsePriority(s string) (Priority, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || !Priority(n).IsValid() {
+		return 0, fmt.Errorf("invalid Priority %q", s)
+	}
+	return Priority(n), nil
+}
+
 type Response struct {
 	Priority *Priority  `json:"priority,omitempty"`
 	Status   HttpStatus `json:"status"`
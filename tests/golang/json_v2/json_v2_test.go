@@ -0,0 +1,71 @@
+//go:build go1.27
+
+package json_v2_test
+
+import (
+	"encoding/json/v2"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONv2RoundTrip(t *testing.T) {
+	input := `{"events":[{"id":"1","extra":true,"kind":"user_created"},{"kind":"user_deleted","id":"2"},{"kind":"user_renamed","id":"3"},null],"value":{"x":1,"y":2}}`
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal([]byte(input), &envelope))
+	require.Len(t, envelope.Events, 4)
+
+	assert.Equal(t, &UserCreated{Kind: "user_created", ID: "1", AdditionalProperties: map[string]interface{}{"extra": true}}, envelope.Events[0].EventUnion)
+	assert.Equal(t, &UserDeleted{Kind: "user_deleted", ID: "2"}, envelope.Events[1].EventUnion)
+	assert.Equal(t, &UnknownEvent{Type: "user_renamed", Raw: []byte(`{"kind":"user_renamed","id":"3"}`)}, envelope.Events[2].EventUnion)
+	assert.Nil(t, envelope.Events[3].EventUnion)
+	assert.Equal(t, &Point{X: 1, Y: 2}, envelope.Value.Point)
+
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(data))
+}
+
+func TestJSONv2OmitsUnsetFields(t *testing.T) {
+	data, err := json.Marshal(NewEventFromUserDeleted(UserDeleted{ID: "1"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"1","kind":"user_deleted"}`, string(data))
+}
+
+func TestJSONv2DiscriminatorErrors(t *testing.T) {
+	var event Event
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"id":"1"}`), &event), `missing discriminator field "kind"`)
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"kind":1}`), &event), "kind is not a string")
+	assert.ErrorContains(t, json.Unmarshal([]byte(`["user_created"]`), &event), "expected an object")
+}
+
+func TestJSONv2DecoderOptions(t *testing.T) {
+	// The caller's options apply whether or not the discriminator comes first
+	for _, input := range []string{
+		`{"kind":"user_deleted","id":"1","extra":true}`,
+		`{"id":"1","extra":true,"kind":"user_deleted"}`,
+	} {
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(input), &event))
+		assert.Equal(t, &UserDeleted{Kind: "user_deleted", ID: "1"}, event.EventUnion)
+
+		err := json.Unmarshal([]byte(input), &event, json.RejectUnknownMembers(true))
+		assert.ErrorContains(t, err, `Event: invalid "user_deleted" payload`, input)
+	}
+}
+
+func TestJSONv2PartialBuffer(t *testing.T) {
+	// Reading a byte at a time leaves the discriminator out of the buffer, so
+	// the object is buffered and scanned instead
+	input := `[{"kind":"user_deleted","id":"1"},{"kind":"user_renamed","id":"2"}]`
+
+	var events []Event
+	require.NoError(t, json.UnmarshalRead(iotest.OneByteReader(strings.NewReader(input)), &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, &UserDeleted{Kind: "user_deleted", ID: "1"}, events[0].EventUnion)
+	assert.Equal(t, &UnknownEvent{Type: "user_renamed", Raw: []byte(`{"kind":"user_renamed","id":"2"}`)}, events[1].EventUnion)
+}
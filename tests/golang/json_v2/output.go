@@ -0,0 +1,397 @@
+//go:build go1.27
+
+package json_v2
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+)
+
+type EventUnion interface {
+	EventType() string
+	isEvent()
+}
+
+type Event struct {
+	EventUnion
+}
+
+func (w Event) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if w.EventUnion == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, w.EventUnion)
+}
+
+// UnmarshalJSONFrom decodes the object straight into the variant named by
+// kind when it's the first property. Otherwise the object is buffered
+// and scanned for kind with the tokenizer before it's decoded.
+func (w *Event) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		w.EventUnion = nil
+		_, err := dec.ReadToken()
+		return err
+	}
+
+	var data jsontext.Value
+	typ, ok := leadingDiscriminator(dec, "kind")
+	if !ok {
+		var err error
+		if data, err = dec.ReadValue(); err != nil {
+			return fmt.Errorf("Event: invalid JSON: %w", err)
+		}
+		if typ, err = discriminator(data, "kind"); err != nil {
+			return fmt.Errorf("Event: invalid JSON: %w", err)
+		}
+	}
+	if typ == "" {
+		return fmt.Errorf("Event: missing discriminator field %q", "kind")
+	}
+
+	var v EventUnion
+	switch typ {
+	case "user_created":
+		v = &UserCreated{}
+	case "user_deleted":
+		v = &UserDeleted{}
+	default:
+		if data == nil {
+			var err error
+			if data, err = dec.ReadValue(); err != nil {
+				return fmt.Errorf("Event: invalid JSON: %w", err)
+			}
+		}
+		w.EventUnion = &UnknownEvent{Type: typ, Raw: append(jsontext.Value(nil), data...)}
+		return nil
+	}
+
+	// A buffered object is decoded with the options of dec, so the caller's
+	// options apply either way.
+	if data != nil {
+		dec = jsontext.NewDecoder(bytes.NewReader(data), dec.Options())
+	}
+	if err := json.UnmarshalDecode(dec, v); err != nil {
+		return fmt.Errorf("Event: invalid %q payload: %w", typ, err)
+	}
+
+	w.EventUnion = v
+	return nil
+}
+
+// EventVisitor has a method for every variant of Event
+type EventVisitor interface {
+	VisitUserCreated(*UserCreated) error
+	VisitUserDeleted(*UserDeleted) error
+	VisitUnknownEvent(*UnknownEvent) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Event) Visit(v EventVisitor) error {
+	switch u := w.EventUnion.(type) {
+	case *UserCreated:
+		return v.VisitUserCreated(u)
+	case UserCreated:
+		return v.VisitUserCreated(&u)
+	case *UserDeleted:
+		return v.VisitUserDeleted(u)
+	case UserDeleted:
+		return v.VisitUserDeleted(&u)
+	case *UnknownEvent:
+		return v.VisitUnknownEvent(u)
+	case UnknownEvent:
+		return v.VisitUnknownEvent(&u)
+	}
+	return errors.New("Event: no variant is set")
+}
+
+type UserCreated struct {
+	Email *string `json:"email,omitzero"`
+	ID    string  `json:"id"`
+	Kind  string  `json:"kind"`
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
+func (UserCreated) isEvent() {}
+
+func (UserCreated) EventType() string { return "user_created" }
+
+// NewEventFromUserCreated wraps v, setting its discriminator
+func NewEventFromUserCreated(v UserCreated) Event {
+	v.Kind = "user_created"
+	return Event{&v}
+}
+
+type UserDeleted struct {
+	ID     string  `json:"id"`
+	Kind   string  `json:"kind"`
+	Reason *string `json:"reason,omitzero"`
+}
+
+func (UserDeleted) isEvent() {}
+
+func (UserDeleted) EventType() string { return "user_deleted" }
+
+// NewEventFromUserDeleted wraps v, setting its discriminator
+func NewEventFromUserDeleted(v UserDeleted) Event {
+	v.Kind = "user_deleted"
+	return Event{&v}
+}
+
+// UnknownEvent is a variant of Event whose kind isn't known,
+// keeping its JSON so it's encoded unchanged.
+type UnknownEvent struct {
+	Type string
+	Raw  jsontext.Value
+}
+
+func (UnknownEvent) isEvent() {}
+
+func (u UnknownEvent) EventType() string { return u.Type }
+
+func (u UnknownEvent) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if len(u.Raw) == 0 {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return enc.WriteValue(u.Raw)
+}
+
+// MarshalJSON adds AdditionalProperties to the declared properties
+func (x UserCreated) MarshalJSON() ([]byte, error) {
+	type plain UserCreated
+	data, err := json.Marshal(plain(x))
+	if err != nil || len(x.AdditionalProperties) == 0 {
+		return data, err
+	}
+	return marshalAdditional(data, x.AdditionalProperties)
+}
+
+// UnmarshalJSON decodes the properties that aren't declared by the schema
+// into AdditionalProperties.
+func (x *UserCreated) UnmarshalJSON(data []byte) error {
+	type plain UserCreated
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	additional, err := unmarshalAdditional[interface{}](data, "email", "id", "kind")
+	if err != nil {
+		return fmt.Errorf("UserCreated: %w", err)
+	}
+	x.AdditionalProperties = additional
+	return nil
+}
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Value struct {
+	String *string
+	Point  *Point
+}
+
+func (u Value) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.String != nil:
+		return json.Marshal(u.String)
+	case u.Point != nil:
+		return json.Marshal(u.Point)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *Value) UnmarshalJSON(data []byte) error {
+	*u = Value{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "string" {
+		if v, err := decode[string](data); err == nil {
+			u.String = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "x", "y") {
+		if v, err := decode[Point](data); err == nil {
+			u.Point = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("Value: %s value matches none of the variants", kind)
+}
+
+// AsString returns the String variant, and whether it is set
+func (u Value) AsString() (v string, ok bool) {
+	if u.String != nil {
+		return *u.String, true
+	}
+	return v, false
+}
+
+// AsPoint returns the Point variant, and whether it is set
+func (u Value) AsPoint() (v Point, ok bool) {
+	if u.Point != nil {
+		return *u.Point, true
+	}
+	return v, false
+}
+
+type Envelope struct {
+	Events []Event `json:"events"`
+	Note   *string `json:"note,omitzero"`
+	Value  *Value  `json:"value,omitzero"`
+}
+
+// leadingDiscriminator returns the string value of the first property of the
+// JSON object dec is about to read when that property is name, peeking at the
+// buffered input without reading from dec. It reports false when the object
+// starts with another property, or the property isn't buffered yet.
+func leadingDiscriminator(dec *jsontext.Decoder, name string) (string, bool) {
+	if dec.PeekKind() != '{' {
+		return "", false
+	}
+	buf := dec.UnreadBuffer()
+	peek := jsontext.NewDecoder(bytes.NewReader(buf[bytes.IndexByte(buf, '{'):]))
+	if _, err := peek.ReadToken(); err != nil {
+		return "", false
+	}
+	key, err := peek.ReadToken()
+	if err != nil || key.Kind() != '"' || key.String() != name || peek.PeekKind() != '"' {
+		return "", false
+	}
+	value, err := peek.ReadToken()
+	if err != nil {
+		return "", false
+	}
+	return value.String(), true
+}
+
+// discriminator returns the string value of a property of a JSON object,
+// reading its tokens without decoding the other properties. It returns an
+// empty string when the property is missing.
+func discriminator(data []byte, name string) (string, error) {
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.ReadToken()
+	if err != nil {
+		return "", err
+	}
+	if tok.Kind() != '{' {
+		return "", fmt.Errorf("expected an object, got %v", tok.Kind())
+	}
+	for dec.PeekKind() == '"' {
+		key, err := dec.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		if key.String() != name {
+			if err := dec.SkipValue(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if dec.PeekKind() != '"' {
+			return "", fmt.Errorf("%s is not a string", name)
+		}
+		value, err := dec.ReadToken()
+		if err != nil {
+			return "", err
+		}
+		return value.String(), nil
+	}
+	return "", nil
+}
+
+// marshalAdditional adds additional properties to the JSON object of the
+// declared ones, which take precedence over additional properties of the same
+// name.
+func marshalAdditional[T any](data []byte, additional map[string]T) ([]byte, error) {
+	var object map[string]jsontext.Value
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for k, v := range additional {
+		if _, ok := object[k]; ok {
+			continue
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		object[k] = value
+	}
+	return json.Marshal(object, json.Deterministic(true))
+}
+
+// unmarshalAdditional decodes the properties of a JSON object besides the
+// known ones, returning nil when there are none.
+func unmarshalAdditional[T any](data []byte, known ...string) (map[string]T, error) {
+	var object map[string]jsontext.Value
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		delete(object, k)
+	}
+	if len(object) == 0 {
+		return nil, nil
+	}
+	additional := make(map[string]T, len(object))
+	for k, raw := range object {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("additional property %q: %w", k, err)
+		}
+		additional[k] = v
+	}
+	return additional, nil
+}
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
+
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]jsontext.Value
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
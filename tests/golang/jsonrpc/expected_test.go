@@ -3,6 +3,7 @@ package jsonrpc_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -79,6 +80,27 @@ func (w *RPCRequestToHost) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RPCRequestToHostVisitor has a method for every variant of RPCRequestToHost
+type RPCRequestToHostVisitor interface {
+	VisitRPCRequestGetConfig(*RPCRequestGetConfig) error
+	VisitRPCRequestPublishEvent(*RPCRequestPublishEvent) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w RPCRequestToHost) Visit(v RPCRequestToHostVisitor) error {
+	switch u := w.RPCRequestToHostUnion.(type) {
+	case *RPCRequestGetConfig:
+		return v.VisitRPCRequestGetConfig(u)
+	case RPCRequestGetConfig:
+		return v.VisitRPCRequestGetConfig(&u)
+	case *RPCRequestPublishEvent:
+		return v.VisitRPCRequestPublishEvent(u)
+	case RPCRequestPublishEvent:
+		return v.VisitRPCRequestPublishEvent(&u)
+	}
+	return errors.New("RPCRequestToHost: no variant is set")
+}
+
 type RPCRequestGetConfig struct {
 	ID      int                        `json:"id"`
 	Jsonrpc string                     `json:"jsonrpc"`
@@ -90,6 +112,12 @@ func (RPCRequestGetConfig) isRPCRequestToHost() {}
 
 func (RPCRequestGetConfig) RPCRequestToHostType() string { return "get_config" }
 
+// NewRPCRequestToHostFromRPCRequestGetConfig wraps v, setting its discriminator
+func NewRPCRequestToHostFromRPCRequestGetConfig(v RPCRequestGetConfig) RPCRequestToHost {
+	v.Method = "get_config"
+	return RPCRequestToHost{&v}
+}
+
 type RPCRequestPublishEvent struct {
 	ID      int                          `json:"id"`
 	Jsonrpc string                       `json:"jsonrpc"`
@@ -101,6 +129,12 @@ func (RPCRequestPublishEvent) isRPCRequestToHost() {}
 
 func (RPCRequestPublishEvent) RPCRequestToHostType() string { return "publish_event" }
 
+// NewRPCRequestToHostFromRPCRequestPublishEvent wraps v, setting its discriminator
+func NewRPCRequestToHostFromRPCRequestPublishEvent(v RPCRequestPublishEvent) RPCRequestToHost {
+	v.Method = "publish_event"
+	return RPCRequestToHost{&v}
+}
+
 type RPCRequestToPluginUnion interface {
 	RPCRequestToPluginType() string
 	isRPCRequestToPlugin()
@@ -152,6 +186,27 @@ func (w *RPCRequestToPlugin) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RPCRequestToPluginVisitor has a method for every variant of RPCRequestToPlugin
+type RPCRequestToPluginVisitor interface {
+	VisitRPCRequestEvent(*RPCRequestEvent) error
+	VisitRPCRequestPing(*RPCRequestPing) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w RPCRequestToPlugin) Visit(v RPCRequestToPluginVisitor) error {
+	switch u := w.RPCRequestToPluginUnion.(type) {
+	case *RPCRequestEvent:
+		return v.VisitRPCRequestEvent(u)
+	case RPCRequestEvent:
+		return v.VisitRPCRequestEvent(&u)
+	case *RPCRequestPing:
+		return v.VisitRPCRequestPing(u)
+	case RPCRequestPing:
+		return v.VisitRPCRequestPing(&u)
+	}
+	return errors.New("RPCRequestToPlugin: no variant is set")
+}
+
 type RPCRequestEvent struct {
 	ID      int    `json:"id"`
 	Jsonrpc string `json:"jsonrpc"`
@@ -164,6 +219,12 @@ func (RPCRequestEvent) isRPCRequestToPlugin() {}
 
 func (RPCRequestEvent) RPCRequestToPluginType() string { return "event" }
 
+// NewRPCRequestToPluginFromRPCRequestEvent wraps v, setting its discriminator
+func NewRPCRequestToPluginFromRPCRequestEvent(v RPCRequestEvent) RPCRequestToPlugin {
+	v.Method = "event"
+	return RPCRequestToPlugin{&v}
+}
+
 type RPCRequestPing struct {
 	ID      int                   `json:"id"`
 	Jsonrpc string                `json:"jsonrpc"`
@@ -175,6 +236,12 @@ func (RPCRequestPing) isRPCRequestToPlugin() {}
 
 func (RPCRequestPing) RPCRequestToPluginType() string { return "ping" }
 
+// NewRPCRequestToPluginFromRPCRequestPing wraps v, setting its discriminator
+func NewRPCRequestToPluginFromRPCRequestPing(v RPCRequestPing) RPCRequestToPlugin {
+	v.Method = "ping"
+	return RPCRequestToPlugin{&v}
+}
+
 type RPCResponseBaseError struct {
 	Code    *int    `json:"code,omitempty"`
 	Message *string `json:"message,omitempty"`
@@ -236,7 +303,59 @@ type RPCResponsePublishEvent struct {
 	Result  *RPCResponsePublishEventResult `json:"result,omitempty"`
 }
 
-type RPCResponseFromHost = interface{}
+type RPCResponseFromHost struct {
+	RPCResponseGetConfig    *RPCResponseGetConfig
+	RPCResponsePublishEvent *RPCResponsePublishEvent
+}
+
+func (u RPCResponseFromHost) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.RPCResponseGetConfig != nil:
+		return json.Marshal(u.RPCResponseGetConfig)
+	case u.RPCResponsePublishEvent != nil:
+		return json.Marshal(u.RPCResponsePublishEvent)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *RPCResponseFromHost) UnmarshalJSON(data []byte) error {
+	*u = RPCResponseFromHost{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc") {
+		if v, err := decode[RPCResponseGetConfig](data); err == nil {
+			u.RPCResponseGetConfig = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc") {
+		if v, err := decode[RPCResponsePublishEvent](data); err == nil {
+			u.RPCResponsePublishEvent = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("RPCResponseFromHost: %s value matches none of the variants", kind)
+}
+
+// AsRPCResponseGetConfig returns the RPCResponseGetConfig variant, and whether it is set
+func (u RPCResponseFromHost) AsRPCResponseGetConfig() (v RPCResponseGetConfig, ok bool) {
+	if u.RPCResponseGetConfig != nil {
+		return *u.RPCResponseGetConfig, true
+	}
+	return v, false
+}
+
+// AsRPCResponsePublishEvent returns the RPCResponsePublishEvent variant, and whether it is set
+func (u RPCResponseFromHost) AsRPCResponsePublishEvent() (v RPCResponsePublishEvent, ok bool) {
+	if u.RPCResponsePublishEvent != nil {
+		return *u.RPCResponsePublishEvent, true
+	}
+	return v, false
+}
 
 type RPCResponsePingError struct {
 	Code    *int    `json:"code,omitempty"`
@@ -258,7 +377,59 @@ type RPCResponsePing struct {
 	Result  *RPCResponsePingResult `json:"result,omitempty"`
 }
 
-type RPCResponseFromPlugin = interface{}
+type RPCResponseFromPlugin struct {
+	RPCResponseEvent *RPCResponseEvent
+	RPCResponsePing  *RPCResponsePing
+}
+
+func (u RPCResponseFromPlugin) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.RPCResponseEvent != nil:
+		return json.Marshal(u.RPCResponseEvent)
+	case u.RPCResponsePing != nil:
+		return json.Marshal(u.RPCResponsePing)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *RPCResponseFromPlugin) UnmarshalJSON(data []byte) error {
+	*u = RPCResponseFromPlugin{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc") {
+		if v, err := decode[RPCResponseEvent](data); err == nil {
+			u.RPCResponseEvent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc") {
+		if v, err := decode[RPCResponsePing](data); err == nil {
+			u.RPCResponsePing = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("RPCResponseFromPlugin: %s value matches none of the variants", kind)
+}
+
+// AsRPCResponseEvent returns the RPCResponseEvent variant, and whether it is set
+func (u RPCResponseFromPlugin) AsRPCResponseEvent() (v RPCResponseEvent, ok bool) {
+	if u.RPCResponseEvent != nil {
+		return *u.RPCResponseEvent, true
+	}
+	return v, false
+}
+
+// AsRPCResponsePing returns the RPCResponsePing variant, and whether it is set
+func (u RPCResponseFromPlugin) AsRPCResponsePing() (v RPCResponsePing, ok bool) {
+	if u.RPCResponsePing != nil {
+		return *u.RPCResponsePing, true
+	}
+	return v, false
+}
 
 type RPCResponseShutdownError struct {
 	Code    *int    `json:"code,omitempty"`
@@ -275,3 +446,46 @@ type RPCResponseShutdown struct {
 	Jsonrpc string                     `json:"jsonrpc"`
 	Result  *RPCResponseShutdownResult `json:"result,omitempty"`
 }
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
+
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
@@ -1,6 +1,8 @@
 package mcp_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/url"
 )
 
@@ -17,6 +19,30 @@ var RoleValues = []Role{
 	RoleUser,
 }
 
+// IsValid reports whether the value is one of RoleValues
+func (e Role) IsValid() bool {
+	for _, v := range RoleValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+// ParseRole converts a string to a Role, returning an error when it
+// is not one of RoleValues.
+func ParseRole(s string) (Role, error) {
+	e := Role(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Role %q", s)
+	}
+	return e, nil
+}
+
 // Optional annotations for the client. The client can use annotations to inform how objects are used or displayed
 type Annotations struct {
 	// Describes who the intended audience of this object or data is.
@@ -232,7 +258,110 @@ type TextContent struct {
 	Type string `json:"type"`
 }
 
-type ContentBlock = interface{}
+type ContentBlock struct {
+	TextContent      *TextContent
+	ImageContent     *ImageContent
+	AudioContent     *AudioContent
+	ResourceLink     *ResourceLink
+	EmbeddedResource *EmbeddedResource
+}
+
+func (u ContentBlock) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.TextContent != nil:
+		return json.Marshal(u.TextContent)
+	case u.ImageContent != nil:
+		return json.Marshal(u.ImageContent)
+	case u.AudioContent != nil:
+		return json.Marshal(u.AudioContent)
+	case u.ResourceLink != nil:
+		return json.Marshal(u.ResourceLink)
+	case u.EmbeddedResource != nil:
+		return json.Marshal(u.EmbeddedResource)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ContentBlock) UnmarshalJSON(data []byte) error {
+	*u = ContentBlock{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "text", "type") {
+		if v, err := decode[TextContent](data); err == nil {
+			u.TextContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "data", "mimeType", "type") {
+		if v, err := decode[ImageContent](data); err == nil {
+			u.ImageContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "data", "mimeType", "type") {
+		if v, err := decode[AudioContent](data); err == nil {
+			u.AudioContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "name", "type", "uri") {
+		if v, err := decode[ResourceLink](data); err == nil {
+			u.ResourceLink = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "resource", "type") {
+		if v, err := decode[EmbeddedResource](data); err == nil {
+			u.EmbeddedResource = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ContentBlock: %s value matches none of the variants", kind)
+}
+
+// AsTextContent returns the TextContent variant, and whether it is set
+func (u ContentBlock) AsTextContent() (v TextContent, ok bool) {
+	if u.TextContent != nil {
+		return *u.TextContent, true
+	}
+	return v, false
+}
+
+// AsImageContent returns the ImageContent variant, and whether it is set
+func (u ContentBlock) AsImageContent() (v ImageContent, ok bool) {
+	if u.ImageContent != nil {
+		return *u.ImageContent, true
+	}
+	return v, false
+}
+
+// AsAudioContent returns the AudioContent variant, and whether it is set
+func (u ContentBlock) AsAudioContent() (v AudioContent, ok bool) {
+	if u.AudioContent != nil {
+		return *u.AudioContent, true
+	}
+	return v, false
+}
+
+// AsResourceLink returns the ResourceLink variant, and whether it is set
+func (u ContentBlock) AsResourceLink() (v ResourceLink, ok bool) {
+	if u.ResourceLink != nil {
+		return *u.ResourceLink, true
+	}
+	return v, false
+}
+
+// AsEmbeddedResource returns the EmbeddedResource variant, and whether it is set
+func (u ContentBlock) AsEmbeddedResource() (v EmbeddedResource, ok bool) {
+	if u.EmbeddedResource != nil {
+		return *u.EmbeddedResource, true
+	}
+	return v, false
+}
 
 // The server's response to a tool call.
 type CallToolResult struct {
@@ -294,6 +423,30 @@ var TaskStatusValues = []TaskStatus{
 	TaskStatusWorking,
 }
 
+// IsValid reports whether the value is one of TaskStatusValues
+func (e TaskStatus) IsValid() bool {
+	for _, v := range TaskStatusValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e TaskStatus) String() string {
+	return string(e)
+}
+
+// ParseTaskStatus converts a string to a TaskStatus, returning an error when it
+// is not one of TaskStatusValues.
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	e := TaskStatus(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid TaskStatus %q", s)
+	}
+	return e, nil
+}
+
 // The response to a tasks/cancel request.
 type CancelTaskResult struct {
 	// See [General fields: `_meta`](/specification/2025-11-25/basic/index#meta) for notes on `_meta` usage.
@@ -519,7 +672,110 @@ type TaskStatusNotification struct {
 	Params  TaskStatusNotificationParams `json:"params"`
 }
 
-type ClientNotification = interface{}
+type ClientNotification struct {
+	CancelledNotification        *CancelledNotification
+	InitializedNotification      *InitializedNotification
+	ProgressNotification         *ProgressNotification
+	TaskStatusNotification       *TaskStatusNotification
+	RootsListChangedNotification *RootsListChangedNotification
+}
+
+func (u ClientNotification) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.CancelledNotification != nil:
+		return json.Marshal(u.CancelledNotification)
+	case u.InitializedNotification != nil:
+		return json.Marshal(u.InitializedNotification)
+	case u.ProgressNotification != nil:
+		return json.Marshal(u.ProgressNotification)
+	case u.TaskStatusNotification != nil:
+		return json.Marshal(u.TaskStatusNotification)
+	case u.RootsListChangedNotification != nil:
+		return json.Marshal(u.RootsListChangedNotification)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ClientNotification) UnmarshalJSON(data []byte) error {
+	*u = ClientNotification{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[CancelledNotification](data); err == nil {
+			u.CancelledNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[InitializedNotification](data); err == nil {
+			u.InitializedNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[ProgressNotification](data); err == nil {
+			u.ProgressNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[TaskStatusNotification](data); err == nil {
+			u.TaskStatusNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[RootsListChangedNotification](data); err == nil {
+			u.RootsListChangedNotification = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ClientNotification: %s value matches none of the variants", kind)
+}
+
+// AsCancelledNotification returns the CancelledNotification variant, and whether it is set
+func (u ClientNotification) AsCancelledNotification() (v CancelledNotification, ok bool) {
+	if u.CancelledNotification != nil {
+		return *u.CancelledNotification, true
+	}
+	return v, false
+}
+
+// AsInitializedNotification returns the InitializedNotification variant, and whether it is set
+func (u ClientNotification) AsInitializedNotification() (v InitializedNotification, ok bool) {
+	if u.InitializedNotification != nil {
+		return *u.InitializedNotification, true
+	}
+	return v, false
+}
+
+// AsProgressNotification returns the ProgressNotification variant, and whether it is set
+func (u ClientNotification) AsProgressNotification() (v ProgressNotification, ok bool) {
+	if u.ProgressNotification != nil {
+		return *u.ProgressNotification, true
+	}
+	return v, false
+}
+
+// AsTaskStatusNotification returns the TaskStatusNotification variant, and whether it is set
+func (u ClientNotification) AsTaskStatusNotification() (v TaskStatusNotification, ok bool) {
+	if u.TaskStatusNotification != nil {
+		return *u.TaskStatusNotification, true
+	}
+	return v, false
+}
+
+// AsRootsListChangedNotification returns the RootsListChangedNotification variant, and whether it is set
+func (u ClientNotification) AsRootsListChangedNotification() (v RootsListChangedNotification, ok bool) {
+	if u.RootsListChangedNotification != nil {
+		return *u.RootsListChangedNotification, true
+	}
+	return v, false
+}
 
 // The argument's information
 type CompleteRequestParamsArgument struct {
@@ -791,6 +1047,30 @@ var LoggingLevelValues = []LoggingLevel{
 	LoggingLevelWarning,
 }
 
+// IsValid reports whether the value is one of LoggingLevelValues
+func (e LoggingLevel) IsValid() bool {
+	for _, v := range LoggingLevelValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e LoggingLevel) String() string {
+	return string(e)
+}
+
+// ParseLoggingLevel converts a string to a LoggingLevel, returning an error when it
+// is not one of LoggingLevelValues.
+func ParseLoggingLevel(s string) (LoggingLevel, error) {
+	e := LoggingLevel(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid LoggingLevel %q", s)
+	}
+	return e, nil
+}
+
 // See [General fields: `_meta`](/specification/2025-11-25/basic/index#meta) for notes on `_meta` usage.
 type SetLevelRequestParamsMeta struct {
 	// If specified, the caller is requesting out-of-band progress notifications for this request (as represented by notifications/progress). The value of this parameter is an opaque token that will be attached to any subsequent notifications. The receiver is not obligated to provide these notifications.
@@ -857,7 +1137,314 @@ type UnsubscribeRequest struct {
 	Params  UnsubscribeRequestParams `json:"params"`
 }
 
-type ClientRequest = interface{}
+type ClientRequest struct {
+	InitializeRequest            *InitializeRequest
+	PingRequest                  *PingRequest
+	ListResourcesRequest         *ListResourcesRequest
+	ListResourceTemplatesRequest *ListResourceTemplatesRequest
+	ReadResourceRequest          *ReadResourceRequest
+	SubscribeRequest             *SubscribeRequest
+	UnsubscribeRequest           *UnsubscribeRequest
+	ListPromptsRequest           *ListPromptsRequest
+	GetPromptRequest             *GetPromptRequest
+	ListToolsRequest             *ListToolsRequest
+	CallToolRequest              *CallToolRequest
+	GetTaskRequest               *GetTaskRequest
+	GetTaskPayloadRequest        *GetTaskPayloadRequest
+	CancelTaskRequest            *CancelTaskRequest
+	ListTasksRequest             *ListTasksRequest
+	SetLevelRequest              *SetLevelRequest
+	CompleteRequest              *CompleteRequest
+}
+
+func (u ClientRequest) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.InitializeRequest != nil:
+		return json.Marshal(u.InitializeRequest)
+	case u.PingRequest != nil:
+		return json.Marshal(u.PingRequest)
+	case u.ListResourcesRequest != nil:
+		return json.Marshal(u.ListResourcesRequest)
+	case u.ListResourceTemplatesRequest != nil:
+		return json.Marshal(u.ListResourceTemplatesRequest)
+	case u.ReadResourceRequest != nil:
+		return json.Marshal(u.ReadResourceRequest)
+	case u.SubscribeRequest != nil:
+		return json.Marshal(u.SubscribeRequest)
+	case u.UnsubscribeRequest != nil:
+		return json.Marshal(u.UnsubscribeRequest)
+	case u.ListPromptsRequest != nil:
+		return json.Marshal(u.ListPromptsRequest)
+	case u.GetPromptRequest != nil:
+		return json.Marshal(u.GetPromptRequest)
+	case u.ListToolsRequest != nil:
+		return json.Marshal(u.ListToolsRequest)
+	case u.CallToolRequest != nil:
+		return json.Marshal(u.CallToolRequest)
+	case u.GetTaskRequest != nil:
+		return json.Marshal(u.GetTaskRequest)
+	case u.GetTaskPayloadRequest != nil:
+		return json.Marshal(u.GetTaskPayloadRequest)
+	case u.CancelTaskRequest != nil:
+		return json.Marshal(u.CancelTaskRequest)
+	case u.ListTasksRequest != nil:
+		return json.Marshal(u.ListTasksRequest)
+	case u.SetLevelRequest != nil:
+		return json.Marshal(u.SetLevelRequest)
+	case u.CompleteRequest != nil:
+		return json.Marshal(u.CompleteRequest)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ClientRequest) UnmarshalJSON(data []byte) error {
+	*u = ClientRequest{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[InitializeRequest](data); err == nil {
+			u.InitializeRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[PingRequest](data); err == nil {
+			u.PingRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListResourcesRequest](data); err == nil {
+			u.ListResourcesRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListResourceTemplatesRequest](data); err == nil {
+			u.ListResourceTemplatesRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[ReadResourceRequest](data); err == nil {
+			u.ReadResourceRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[SubscribeRequest](data); err == nil {
+			u.SubscribeRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[UnsubscribeRequest](data); err == nil {
+			u.UnsubscribeRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListPromptsRequest](data); err == nil {
+			u.ListPromptsRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[GetPromptRequest](data); err == nil {
+			u.GetPromptRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListToolsRequest](data); err == nil {
+			u.ListToolsRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[CallToolRequest](data); err == nil {
+			u.CallToolRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[GetTaskRequest](data); err == nil {
+			u.GetTaskRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[GetTaskPayloadRequest](data); err == nil {
+			u.GetTaskPayloadRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[CancelTaskRequest](data); err == nil {
+			u.CancelTaskRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListTasksRequest](data); err == nil {
+			u.ListTasksRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[SetLevelRequest](data); err == nil {
+			u.SetLevelRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[CompleteRequest](data); err == nil {
+			u.CompleteRequest = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ClientRequest: %s value matches none of the variants", kind)
+}
+
+// AsInitializeRequest returns the InitializeRequest variant, and whether it is set
+func (u ClientRequest) AsInitializeRequest() (v InitializeRequest, ok bool) {
+	if u.InitializeRequest != nil {
+		return *u.InitializeRequest, true
+	}
+	return v, false
+}
+
+// AsPingRequest returns the PingRequest variant, and whether it is set
+func (u ClientRequest) AsPingRequest() (v PingRequest, ok bool) {
+	if u.PingRequest != nil {
+		return *u.PingRequest, true
+	}
+	return v, false
+}
+
+// AsListResourcesRequest returns the ListResourcesRequest variant, and whether it is set
+func (u ClientRequest) AsListResourcesRequest() (v ListResourcesRequest, ok bool) {
+	if u.ListResourcesRequest != nil {
+		return *u.ListResourcesRequest, true
+	}
+	return v, false
+}
+
+// AsListResourceTemplatesRequest returns the ListResourceTemplatesRequest variant, and whether it is set
+func (u ClientRequest) AsListResourceTemplatesRequest() (v ListResourceTemplatesRequest, ok bool) {
+	if u.ListResourceTemplatesRequest != nil {
+		return *u.ListResourceTemplatesRequest, true
+	}
+	return v, false
+}
+
+// AsReadResourceRequest returns the ReadResourceRequest variant, and whether it is set
+func (u ClientRequest) AsReadResourceRequest() (v ReadResourceRequest, ok bool) {
+	if u.ReadResourceRequest != nil {
+		return *u.ReadResourceRequest, true
+	}
+	return v, false
+}
+
+// AsSubscribeRequest returns the SubscribeRequest variant, and whether it is set
+func (u ClientRequest) AsSubscribeRequest() (v SubscribeRequest, ok bool) {
+	if u.SubscribeRequest != nil {
+		return *u.SubscribeRequest, true
+	}
+	return v, false
+}
+
+// AsUnsubscribeRequest returns the UnsubscribeRequest variant, and whether it is set
+func (u ClientRequest) AsUnsubscribeRequest() (v UnsubscribeRequest, ok bool) {
+	if u.UnsubscribeRequest != nil {
+		return *u.UnsubscribeRequest, true
+	}
+	return v, false
+}
+
+// AsListPromptsRequest returns the ListPromptsRequest variant, and whether it is set
+func (u ClientRequest) AsListPromptsRequest() (v ListPromptsRequest, ok bool) {
+	if u.ListPromptsRequest != nil {
+		return *u.ListPromptsRequest, true
+	}
+	return v, false
+}
+
+// AsGetPromptRequest returns the GetPromptRequest variant, and whether it is set
+func (u ClientRequest) AsGetPromptRequest() (v GetPromptRequest, ok bool) {
+	if u.GetPromptRequest != nil {
+		return *u.GetPromptRequest, true
+	}
+	return v, false
+}
+
+// AsListToolsRequest returns the ListToolsRequest variant, and whether it is set
+func (u ClientRequest) AsListToolsRequest() (v ListToolsRequest, ok bool) {
+	if u.ListToolsRequest != nil {
+		return *u.ListToolsRequest, true
+	}
+	return v, false
+}
+
+// AsCallToolRequest returns the CallToolRequest variant, and whether it is set
+func (u ClientRequest) AsCallToolRequest() (v CallToolRequest, ok bool) {
+	if u.CallToolRequest != nil {
+		return *u.CallToolRequest, true
+	}
+	return v, false
+}
+
+// AsGetTaskRequest returns the GetTaskRequest variant, and whether it is set
+func (u ClientRequest) AsGetTaskRequest() (v GetTaskRequest, ok bool) {
+	if u.GetTaskRequest != nil {
+		return *u.GetTaskRequest, true
+	}
+	return v, false
+}
+
+// AsGetTaskPayloadRequest returns the GetTaskPayloadRequest variant, and whether it is set
+func (u ClientRequest) AsGetTaskPayloadRequest() (v GetTaskPayloadRequest, ok bool) {
+	if u.GetTaskPayloadRequest != nil {
+		return *u.GetTaskPayloadRequest, true
+	}
+	return v, false
+}
+
+// AsCancelTaskRequest returns the CancelTaskRequest variant, and whether it is set
+func (u ClientRequest) AsCancelTaskRequest() (v CancelTaskRequest, ok bool) {
+	if u.CancelTaskRequest != nil {
+		return *u.CancelTaskRequest, true
+	}
+	return v, false
+}
+
+// AsListTasksRequest returns the ListTasksRequest variant, and whether it is set
+func (u ClientRequest) AsListTasksRequest() (v ListTasksRequest, ok bool) {
+	if u.ListTasksRequest != nil {
+		return *u.ListTasksRequest, true
+	}
+	return v, false
+}
+
+// AsSetLevelRequest returns the SetLevelRequest variant, and whether it is set
+func (u ClientRequest) AsSetLevelRequest() (v SetLevelRequest, ok bool) {
+	if u.SetLevelRequest != nil {
+		return *u.SetLevelRequest, true
+	}
+	return v, false
+}
+
+// AsCompleteRequest returns the CompleteRequest variant, and whether it is set
+func (u ClientRequest) AsCompleteRequest() (v CompleteRequest, ok bool) {
+	if u.CompleteRequest != nil {
+		return *u.CompleteRequest, true
+	}
+	return v, false
+}
 
 // The client's response to a sampling/createMessage request from the server.
 // The client should inform the user before returning the sampled message, to allow them
@@ -983,7 +1570,161 @@ type ListTasksResult struct {
 	Tasks      []Task  `json:"tasks"`
 }
 
-type ClientResult = interface{}
+type ClientResult struct {
+	Result               *Result
+	GetTaskResult        *GetTaskResult
+	GetTaskPayloadResult *GetTaskPayloadResult
+	CancelTaskResult     *CancelTaskResult
+	ListTasksResult      *ListTasksResult
+	CreateMessageResult  *CreateMessageResult
+	ListRootsResult      *ListRootsResult
+	ElicitResult         *ElicitResult
+}
+
+func (u ClientResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.Result != nil:
+		return json.Marshal(u.Result)
+	case u.GetTaskResult != nil:
+		return json.Marshal(u.GetTaskResult)
+	case u.GetTaskPayloadResult != nil:
+		return json.Marshal(u.GetTaskPayloadResult)
+	case u.CancelTaskResult != nil:
+		return json.Marshal(u.CancelTaskResult)
+	case u.ListTasksResult != nil:
+		return json.Marshal(u.ListTasksResult)
+	case u.CreateMessageResult != nil:
+		return json.Marshal(u.CreateMessageResult)
+	case u.ListRootsResult != nil:
+		return json.Marshal(u.ListRootsResult)
+	case u.ElicitResult != nil:
+		return json.Marshal(u.ElicitResult)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ClientResult) UnmarshalJSON(data []byte) error {
+	*u = ClientResult{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" {
+		if v, err := decode[Result](data); err == nil {
+			u.Result = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "createdAt", "lastUpdatedAt", "status", "taskId", "ttl") {
+		if v, err := decode[GetTaskResult](data); err == nil {
+			u.GetTaskResult = &v
+			return nil
+		}
+	}
+	if kind == "object" {
+		if v, err := decode[GetTaskPayloadResult](data); err == nil {
+			u.GetTaskPayloadResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "createdAt", "lastUpdatedAt", "status", "taskId", "ttl") {
+		if v, err := decode[CancelTaskResult](data); err == nil {
+			u.CancelTaskResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "tasks") {
+		if v, err := decode[ListTasksResult](data); err == nil {
+			u.ListTasksResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "content", "model", "role") {
+		if v, err := decode[CreateMessageResult](data); err == nil {
+			u.CreateMessageResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "roots") {
+		if v, err := decode[ListRootsResult](data); err == nil {
+			u.ListRootsResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "action") {
+		if v, err := decode[ElicitResult](data); err == nil {
+			u.ElicitResult = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ClientResult: %s value matches none of the variants", kind)
+}
+
+// AsResult returns the Result variant, and whether it is set
+func (u ClientResult) AsResult() (v Result, ok bool) {
+	if u.Result != nil {
+		return *u.Result, true
+	}
+	return v, false
+}
+
+// AsGetTaskResult returns the GetTaskResult variant, and whether it is set
+func (u ClientResult) AsGetTaskResult() (v GetTaskResult, ok bool) {
+	if u.GetTaskResult != nil {
+		return *u.GetTaskResult, true
+	}
+	return v, false
+}
+
+// AsGetTaskPayloadResult returns the GetTaskPayloadResult variant, and whether it is set
+func (u ClientResult) AsGetTaskPayloadResult() (v GetTaskPayloadResult, ok bool) {
+	if u.GetTaskPayloadResult != nil {
+		return *u.GetTaskPayloadResult, true
+	}
+	return v, false
+}
+
+// AsCancelTaskResult returns the CancelTaskResult variant, and whether it is set
+func (u ClientResult) AsCancelTaskResult() (v CancelTaskResult, ok bool) {
+	if u.CancelTaskResult != nil {
+		return *u.CancelTaskResult, true
+	}
+	return v, false
+}
+
+// AsListTasksResult returns the ListTasksResult variant, and whether it is set
+func (u ClientResult) AsListTasksResult() (v ListTasksResult, ok bool) {
+	if u.ListTasksResult != nil {
+		return *u.ListTasksResult, true
+	}
+	return v, false
+}
+
+// AsCreateMessageResult returns the CreateMessageResult variant, and whether it is set
+func (u ClientResult) AsCreateMessageResult() (v CreateMessageResult, ok bool) {
+	if u.CreateMessageResult != nil {
+		return *u.CreateMessageResult, true
+	}
+	return v, false
+}
+
+// AsListRootsResult returns the ListRootsResult variant, and whether it is set
+func (u ClientResult) AsListRootsResult() (v ListRootsResult, ok bool) {
+	if u.ListRootsResult != nil {
+		return *u.ListRootsResult, true
+	}
+	return v, false
+}
+
+// AsElicitResult returns the ElicitResult variant, and whether it is set
+func (u ClientResult) AsElicitResult() (v ElicitResult, ok bool) {
+	if u.ElicitResult != nil {
+		return *u.ElicitResult, true
+	}
+	return v, false
+}
 
 type CompleteResultCompletion struct {
 	// Indicates whether there are additional completion options beyond those provided in the current response, even if the exact total is unknown.
@@ -1384,7 +2125,161 @@ type UntitledSingleSelectEnumSchema struct {
 
 // Restricted schema definitions that only allow primitive types
 // without nested objects or arrays.
-type PrimitiveSchemaDefinition = interface{}
+type PrimitiveSchemaDefinition struct {
+	StringSchema                   *StringSchema
+	NumberSchema                   *NumberSchema
+	BooleanSchema                  *BooleanSchema
+	UntitledSingleSelectEnumSchema *UntitledSingleSelectEnumSchema
+	TitledSingleSelectEnumSchema   *TitledSingleSelectEnumSchema
+	UntitledMultiSelectEnumSchema  *UntitledMultiSelectEnumSchema
+	TitledMultiSelectEnumSchema    *TitledMultiSelectEnumSchema
+	LegacyTitledEnumSchema         *LegacyTitledEnumSchema
+}
+
+func (u PrimitiveSchemaDefinition) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.StringSchema != nil:
+		return json.Marshal(u.StringSchema)
+	case u.NumberSchema != nil:
+		return json.Marshal(u.NumberSchema)
+	case u.BooleanSchema != nil:
+		return json.Marshal(u.BooleanSchema)
+	case u.UntitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledSingleSelectEnumSchema)
+	case u.TitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.TitledSingleSelectEnumSchema)
+	case u.UntitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledMultiSelectEnumSchema)
+	case u.TitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.TitledMultiSelectEnumSchema)
+	case u.LegacyTitledEnumSchema != nil:
+		return json.Marshal(u.LegacyTitledEnumSchema)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *PrimitiveSchemaDefinition) UnmarshalJSON(data []byte) error {
+	*u = PrimitiveSchemaDefinition{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "type") {
+		if v, err := decode[StringSchema](data); err == nil {
+			u.StringSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "type") {
+		if v, err := decode[NumberSchema](data); err == nil {
+			u.NumberSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "type") {
+		if v, err := decode[BooleanSchema](data); err == nil {
+			u.BooleanSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "enum", "type") {
+		if v, err := decode[UntitledSingleSelectEnumSchema](data); err == nil {
+			u.UntitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "oneOf", "type") {
+		if v, err := decode[TitledSingleSelectEnumSchema](data); err == nil {
+			u.TitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[UntitledMultiSelectEnumSchema](data); err == nil {
+			u.UntitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[TitledMultiSelectEnumSchema](data); err == nil {
+			u.TitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "enum", "type") {
+		if v, err := decode[LegacyTitledEnumSchema](data); err == nil {
+			u.LegacyTitledEnumSchema = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("PrimitiveSchemaDefinition: %s value matches none of the variants", kind)
+}
+
+// AsStringSchema returns the StringSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsStringSchema() (v StringSchema, ok bool) {
+	if u.StringSchema != nil {
+		return *u.StringSchema, true
+	}
+	return v, false
+}
+
+// AsNumberSchema returns the NumberSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsNumberSchema() (v NumberSchema, ok bool) {
+	if u.NumberSchema != nil {
+		return *u.NumberSchema, true
+	}
+	return v, false
+}
+
+// AsBooleanSchema returns the BooleanSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsBooleanSchema() (v BooleanSchema, ok bool) {
+	if u.BooleanSchema != nil {
+		return *u.BooleanSchema, true
+	}
+	return v, false
+}
+
+// AsUntitledSingleSelectEnumSchema returns the UntitledSingleSelectEnumSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsUntitledSingleSelectEnumSchema() (v UntitledSingleSelectEnumSchema, ok bool) {
+	if u.UntitledSingleSelectEnumSchema != nil {
+		return *u.UntitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledSingleSelectEnumSchema returns the TitledSingleSelectEnumSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsTitledSingleSelectEnumSchema() (v TitledSingleSelectEnumSchema, ok bool) {
+	if u.TitledSingleSelectEnumSchema != nil {
+		return *u.TitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsUntitledMultiSelectEnumSchema returns the UntitledMultiSelectEnumSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsUntitledMultiSelectEnumSchema() (v UntitledMultiSelectEnumSchema, ok bool) {
+	if u.UntitledMultiSelectEnumSchema != nil {
+		return *u.UntitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledMultiSelectEnumSchema returns the TitledMultiSelectEnumSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsTitledMultiSelectEnumSchema() (v TitledMultiSelectEnumSchema, ok bool) {
+	if u.TitledMultiSelectEnumSchema != nil {
+		return *u.TitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsLegacyTitledEnumSchema returns the LegacyTitledEnumSchema variant, and whether it is set
+func (u PrimitiveSchemaDefinition) AsLegacyTitledEnumSchema() (v LegacyTitledEnumSchema, ok bool) {
+	if u.LegacyTitledEnumSchema != nil {
+		return *u.LegacyTitledEnumSchema, true
+	}
+	return v, false
+}
 
 // A restricted subset of JSON Schema.
 // Only top-level properties are allowed, without nesting.
@@ -1444,7 +2339,59 @@ type ElicitRequestURLParams struct {
 }
 
 // The parameters for a request to elicit additional information from the user via the client.
-type ElicitRequestParams = interface{}
+type ElicitRequestParams struct {
+	ElicitRequestURLParams  *ElicitRequestURLParams
+	ElicitRequestFormParams *ElicitRequestFormParams
+}
+
+func (u ElicitRequestParams) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.ElicitRequestURLParams != nil:
+		return json.Marshal(u.ElicitRequestURLParams)
+	case u.ElicitRequestFormParams != nil:
+		return json.Marshal(u.ElicitRequestFormParams)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ElicitRequestParams) UnmarshalJSON(data []byte) error {
+	*u = ElicitRequestParams{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "elicitationId", "message", "mode", "url") {
+		if v, err := decode[ElicitRequestURLParams](data); err == nil {
+			u.ElicitRequestURLParams = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "message", "requestedSchema") {
+		if v, err := decode[ElicitRequestFormParams](data); err == nil {
+			u.ElicitRequestFormParams = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ElicitRequestParams: %s value matches none of the variants", kind)
+}
+
+// AsElicitRequestURLParams returns the ElicitRequestURLParams variant, and whether it is set
+func (u ElicitRequestParams) AsElicitRequestURLParams() (v ElicitRequestURLParams, ok bool) {
+	if u.ElicitRequestURLParams != nil {
+		return *u.ElicitRequestURLParams, true
+	}
+	return v, false
+}
+
+// AsElicitRequestFormParams returns the ElicitRequestFormParams variant, and whether it is set
+func (u ElicitRequestParams) AsElicitRequestFormParams() (v ElicitRequestFormParams, ok bool) {
+	if u.ElicitRequestFormParams != nil {
+		return *u.ElicitRequestFormParams, true
+	}
+	return v, false
+}
 
 // A request from the server to elicit additional information from the user via the client.
 type ElicitRequest struct {
@@ -1468,7 +2415,110 @@ type ElicitationCompleteNotification struct {
 
 type EmptyResult = interface{}
 
-type EnumSchema = interface{}
+type EnumSchema struct {
+	UntitledSingleSelectEnumSchema *UntitledSingleSelectEnumSchema
+	TitledSingleSelectEnumSchema   *TitledSingleSelectEnumSchema
+	UntitledMultiSelectEnumSchema  *UntitledMultiSelectEnumSchema
+	TitledMultiSelectEnumSchema    *TitledMultiSelectEnumSchema
+	LegacyTitledEnumSchema         *LegacyTitledEnumSchema
+}
+
+func (u EnumSchema) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.UntitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledSingleSelectEnumSchema)
+	case u.TitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.TitledSingleSelectEnumSchema)
+	case u.UntitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledMultiSelectEnumSchema)
+	case u.TitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.TitledMultiSelectEnumSchema)
+	case u.LegacyTitledEnumSchema != nil:
+		return json.Marshal(u.LegacyTitledEnumSchema)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *EnumSchema) UnmarshalJSON(data []byte) error {
+	*u = EnumSchema{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "enum", "type") {
+		if v, err := decode[UntitledSingleSelectEnumSchema](data); err == nil {
+			u.UntitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "oneOf", "type") {
+		if v, err := decode[TitledSingleSelectEnumSchema](data); err == nil {
+			u.TitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[UntitledMultiSelectEnumSchema](data); err == nil {
+			u.UntitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[TitledMultiSelectEnumSchema](data); err == nil {
+			u.TitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "enum", "type") {
+		if v, err := decode[LegacyTitledEnumSchema](data); err == nil {
+			u.LegacyTitledEnumSchema = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("EnumSchema: %s value matches none of the variants", kind)
+}
+
+// AsUntitledSingleSelectEnumSchema returns the UntitledSingleSelectEnumSchema variant, and whether it is set
+func (u EnumSchema) AsUntitledSingleSelectEnumSchema() (v UntitledSingleSelectEnumSchema, ok bool) {
+	if u.UntitledSingleSelectEnumSchema != nil {
+		return *u.UntitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledSingleSelectEnumSchema returns the TitledSingleSelectEnumSchema variant, and whether it is set
+func (u EnumSchema) AsTitledSingleSelectEnumSchema() (v TitledSingleSelectEnumSchema, ok bool) {
+	if u.TitledSingleSelectEnumSchema != nil {
+		return *u.TitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsUntitledMultiSelectEnumSchema returns the UntitledMultiSelectEnumSchema variant, and whether it is set
+func (u EnumSchema) AsUntitledMultiSelectEnumSchema() (v UntitledMultiSelectEnumSchema, ok bool) {
+	if u.UntitledMultiSelectEnumSchema != nil {
+		return *u.UntitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledMultiSelectEnumSchema returns the TitledMultiSelectEnumSchema variant, and whether it is set
+func (u EnumSchema) AsTitledMultiSelectEnumSchema() (v TitledMultiSelectEnumSchema, ok bool) {
+	if u.TitledMultiSelectEnumSchema != nil {
+		return *u.TitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsLegacyTitledEnumSchema returns the LegacyTitledEnumSchema variant, and whether it is set
+func (u EnumSchema) AsLegacyTitledEnumSchema() (v LegacyTitledEnumSchema, ok bool) {
+	if u.LegacyTitledEnumSchema != nil {
+		return *u.LegacyTitledEnumSchema, true
+	}
+	return v, false
+}
 
 type Error struct {
 	// The error type that occurred.
@@ -1638,10 +2688,148 @@ type JSONRPCResultResponse struct {
 }
 
 // Refers to any valid JSON-RPC object that can be decoded off the wire, or encoded to be sent.
-type JSONRPCMessage = interface{}
+type JSONRPCMessage struct {
+	JSONRPCRequest        *JSONRPCRequest
+	JSONRPCNotification   *JSONRPCNotification
+	JSONRPCResultResponse *JSONRPCResultResponse
+	JSONRPCErrorResponse  *JSONRPCErrorResponse
+}
+
+func (u JSONRPCMessage) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.JSONRPCRequest != nil:
+		return json.Marshal(u.JSONRPCRequest)
+	case u.JSONRPCNotification != nil:
+		return json.Marshal(u.JSONRPCNotification)
+	case u.JSONRPCResultResponse != nil:
+		return json.Marshal(u.JSONRPCResultResponse)
+	case u.JSONRPCErrorResponse != nil:
+		return json.Marshal(u.JSONRPCErrorResponse)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *JSONRPCMessage) UnmarshalJSON(data []byte) error {
+	*u = JSONRPCMessage{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[JSONRPCRequest](data); err == nil {
+			u.JSONRPCRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[JSONRPCNotification](data); err == nil {
+			u.JSONRPCNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "result") {
+		if v, err := decode[JSONRPCResultResponse](data); err == nil {
+			u.JSONRPCResultResponse = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "error", "jsonrpc") {
+		if v, err := decode[JSONRPCErrorResponse](data); err == nil {
+			u.JSONRPCErrorResponse = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("JSONRPCMessage: %s value matches none of the variants", kind)
+}
+
+// AsJSONRPCRequest returns the JSONRPCRequest variant, and whether it is set
+func (u JSONRPCMessage) AsJSONRPCRequest() (v JSONRPCRequest, ok bool) {
+	if u.JSONRPCRequest != nil {
+		return *u.JSONRPCRequest, true
+	}
+	return v, false
+}
+
+// AsJSONRPCNotification returns the JSONRPCNotification variant, and whether it is set
+func (u JSONRPCMessage) AsJSONRPCNotification() (v JSONRPCNotification, ok bool) {
+	if u.JSONRPCNotification != nil {
+		return *u.JSONRPCNotification, true
+	}
+	return v, false
+}
+
+// AsJSONRPCResultResponse returns the JSONRPCResultResponse variant, and whether it is set
+func (u JSONRPCMessage) AsJSONRPCResultResponse() (v JSONRPCResultResponse, ok bool) {
+	if u.JSONRPCResultResponse != nil {
+		return *u.JSONRPCResultResponse, true
+	}
+	return v, false
+}
+
+// AsJSONRPCErrorResponse returns the JSONRPCErrorResponse variant, and whether it is set
+func (u JSONRPCMessage) AsJSONRPCErrorResponse() (v JSONRPCErrorResponse, ok bool) {
+	if u.JSONRPCErrorResponse != nil {
+		return *u.JSONRPCErrorResponse, true
+	}
+	return v, false
+}
 
 // A response to a request, containing either the result or error.
-type JSONRPCResponse = interface{}
+type JSONRPCResponse struct {
+	JSONRPCResultResponse *JSONRPCResultResponse
+	JSONRPCErrorResponse  *JSONRPCErrorResponse
+}
+
+func (u JSONRPCResponse) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.JSONRPCResultResponse != nil:
+		return json.Marshal(u.JSONRPCResultResponse)
+	case u.JSONRPCErrorResponse != nil:
+		return json.Marshal(u.JSONRPCErrorResponse)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *JSONRPCResponse) UnmarshalJSON(data []byte) error {
+	*u = JSONRPCResponse{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "result") {
+		if v, err := decode[JSONRPCResultResponse](data); err == nil {
+			u.JSONRPCResultResponse = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "error", "jsonrpc") {
+		if v, err := decode[JSONRPCErrorResponse](data); err == nil {
+			u.JSONRPCErrorResponse = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("JSONRPCResponse: %s value matches none of the variants", kind)
+}
+
+// AsJSONRPCResultResponse returns the JSONRPCResultResponse variant, and whether it is set
+func (u JSONRPCResponse) AsJSONRPCResultResponse() (v JSONRPCResultResponse, ok bool) {
+	if u.JSONRPCResultResponse != nil {
+		return *u.JSONRPCResultResponse, true
+	}
+	return v, false
+}
+
+// AsJSONRPCErrorResponse returns the JSONRPCErrorResponse variant, and whether it is set
+func (u JSONRPCResponse) AsJSONRPCErrorResponse() (v JSONRPCErrorResponse, ok bool) {
+	if u.JSONRPCErrorResponse != nil {
+		return *u.JSONRPCErrorResponse, true
+	}
+	return v, false
+}
 
 // Describes an argument that a prompt can accept.
 type PromptArgument struct {
@@ -1836,7 +3024,59 @@ type LoggingMessageNotification struct {
 	Params  LoggingMessageNotificationParams `json:"params"`
 }
 
-type MultiSelectEnumSchema = interface{}
+type MultiSelectEnumSchema struct {
+	UntitledMultiSelectEnumSchema *UntitledMultiSelectEnumSchema
+	TitledMultiSelectEnumSchema   *TitledMultiSelectEnumSchema
+}
+
+func (u MultiSelectEnumSchema) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.UntitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledMultiSelectEnumSchema)
+	case u.TitledMultiSelectEnumSchema != nil:
+		return json.Marshal(u.TitledMultiSelectEnumSchema)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *MultiSelectEnumSchema) UnmarshalJSON(data []byte) error {
+	*u = MultiSelectEnumSchema{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[UntitledMultiSelectEnumSchema](data); err == nil {
+			u.UntitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "items", "type") {
+		if v, err := decode[TitledMultiSelectEnumSchema](data); err == nil {
+			u.TitledMultiSelectEnumSchema = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("MultiSelectEnumSchema: %s value matches none of the variants", kind)
+}
+
+// AsUntitledMultiSelectEnumSchema returns the UntitledMultiSelectEnumSchema variant, and whether it is set
+func (u MultiSelectEnumSchema) AsUntitledMultiSelectEnumSchema() (v UntitledMultiSelectEnumSchema, ok bool) {
+	if u.UntitledMultiSelectEnumSchema != nil {
+		return *u.UntitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledMultiSelectEnumSchema returns the TitledMultiSelectEnumSchema variant, and whether it is set
+func (u MultiSelectEnumSchema) AsTitledMultiSelectEnumSchema() (v TitledMultiSelectEnumSchema, ok bool) {
+	if u.TitledMultiSelectEnumSchema != nil {
+		return *u.TitledMultiSelectEnumSchema, true
+	}
+	return v, false
+}
 
 type Notification struct {
 	Method string                 `json:"method"`
@@ -1997,7 +3237,110 @@ type ToolUseContent struct {
 	Type string `json:"type"`
 }
 
-type SamplingMessageContentBlock = interface{}
+type SamplingMessageContentBlock struct {
+	TextContent       *TextContent
+	ImageContent      *ImageContent
+	AudioContent      *AudioContent
+	ToolUseContent    *ToolUseContent
+	ToolResultContent *ToolResultContent
+}
+
+func (u SamplingMessageContentBlock) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.TextContent != nil:
+		return json.Marshal(u.TextContent)
+	case u.ImageContent != nil:
+		return json.Marshal(u.ImageContent)
+	case u.AudioContent != nil:
+		return json.Marshal(u.AudioContent)
+	case u.ToolUseContent != nil:
+		return json.Marshal(u.ToolUseContent)
+	case u.ToolResultContent != nil:
+		return json.Marshal(u.ToolResultContent)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *SamplingMessageContentBlock) UnmarshalJSON(data []byte) error {
+	*u = SamplingMessageContentBlock{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "text", "type") {
+		if v, err := decode[TextContent](data); err == nil {
+			u.TextContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "data", "mimeType", "type") {
+		if v, err := decode[ImageContent](data); err == nil {
+			u.ImageContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "data", "mimeType", "type") {
+		if v, err := decode[AudioContent](data); err == nil {
+			u.AudioContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "input", "name", "type") {
+		if v, err := decode[ToolUseContent](data); err == nil {
+			u.ToolUseContent = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "content", "toolUseId", "type") {
+		if v, err := decode[ToolResultContent](data); err == nil {
+			u.ToolResultContent = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("SamplingMessageContentBlock: %s value matches none of the variants", kind)
+}
+
+// AsTextContent returns the TextContent variant, and whether it is set
+func (u SamplingMessageContentBlock) AsTextContent() (v TextContent, ok bool) {
+	if u.TextContent != nil {
+		return *u.TextContent, true
+	}
+	return v, false
+}
+
+// AsImageContent returns the ImageContent variant, and whether it is set
+func (u SamplingMessageContentBlock) AsImageContent() (v ImageContent, ok bool) {
+	if u.ImageContent != nil {
+		return *u.ImageContent, true
+	}
+	return v, false
+}
+
+// AsAudioContent returns the AudioContent variant, and whether it is set
+func (u SamplingMessageContentBlock) AsAudioContent() (v AudioContent, ok bool) {
+	if u.AudioContent != nil {
+		return *u.AudioContent, true
+	}
+	return v, false
+}
+
+// AsToolUseContent returns the ToolUseContent variant, and whether it is set
+func (u SamplingMessageContentBlock) AsToolUseContent() (v ToolUseContent, ok bool) {
+	if u.ToolUseContent != nil {
+		return *u.ToolUseContent, true
+	}
+	return v, false
+}
+
+// AsToolResultContent returns the ToolResultContent variant, and whether it is set
+func (u SamplingMessageContentBlock) AsToolResultContent() (v ToolResultContent, ok bool) {
+	if u.ToolResultContent != nil {
+		return *u.ToolResultContent, true
+	}
+	return v, false
+}
 
 // An optional notification from the server to the client, informing it that the list of tools it offers has changed. This may be issued by servers without any previous subscription from the client.
 type ToolListChangedNotification struct {
@@ -2006,13 +3349,646 @@ type ToolListChangedNotification struct {
 	Params  *NotificationParams `json:"params,omitempty"`
 }
 
-type ServerNotification = interface{}
-
-type ServerRequest = interface{}
-
-type ServerResult = interface{}
-
-type SingleSelectEnumSchema = interface{}
+type ServerNotification struct {
+	CancelledNotification           *CancelledNotification
+	ProgressNotification            *ProgressNotification
+	ResourceListChangedNotification *ResourceListChangedNotification
+	ResourceUpdatedNotification     *ResourceUpdatedNotification
+	PromptListChangedNotification   *PromptListChangedNotification
+	ToolListChangedNotification     *ToolListChangedNotification
+	TaskStatusNotification          *TaskStatusNotification
+	LoggingMessageNotification      *LoggingMessageNotification
+	ElicitationCompleteNotification *ElicitationCompleteNotification
+}
+
+func (u ServerNotification) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.CancelledNotification != nil:
+		return json.Marshal(u.CancelledNotification)
+	case u.ProgressNotification != nil:
+		return json.Marshal(u.ProgressNotification)
+	case u.ResourceListChangedNotification != nil:
+		return json.Marshal(u.ResourceListChangedNotification)
+	case u.ResourceUpdatedNotification != nil:
+		return json.Marshal(u.ResourceUpdatedNotification)
+	case u.PromptListChangedNotification != nil:
+		return json.Marshal(u.PromptListChangedNotification)
+	case u.ToolListChangedNotification != nil:
+		return json.Marshal(u.ToolListChangedNotification)
+	case u.TaskStatusNotification != nil:
+		return json.Marshal(u.TaskStatusNotification)
+	case u.LoggingMessageNotification != nil:
+		return json.Marshal(u.LoggingMessageNotification)
+	case u.ElicitationCompleteNotification != nil:
+		return json.Marshal(u.ElicitationCompleteNotification)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ServerNotification) UnmarshalJSON(data []byte) error {
+	*u = ServerNotification{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[CancelledNotification](data); err == nil {
+			u.CancelledNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[ProgressNotification](data); err == nil {
+			u.ProgressNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[ResourceListChangedNotification](data); err == nil {
+			u.ResourceListChangedNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[ResourceUpdatedNotification](data); err == nil {
+			u.ResourceUpdatedNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[PromptListChangedNotification](data); err == nil {
+			u.PromptListChangedNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method") {
+		if v, err := decode[ToolListChangedNotification](data); err == nil {
+			u.ToolListChangedNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[TaskStatusNotification](data); err == nil {
+			u.TaskStatusNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[LoggingMessageNotification](data); err == nil {
+			u.LoggingMessageNotification = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "jsonrpc", "method", "params") {
+		if v, err := decode[ElicitationCompleteNotification](data); err == nil {
+			u.ElicitationCompleteNotification = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ServerNotification: %s value matches none of the variants", kind)
+}
+
+// AsCancelledNotification returns the CancelledNotification variant, and whether it is set
+func (u ServerNotification) AsCancelledNotification() (v CancelledNotification, ok bool) {
+	if u.CancelledNotification != nil {
+		return *u.CancelledNotification, true
+	}
+	return v, false
+}
+
+// AsProgressNotification returns the ProgressNotification variant, and whether it is set
+func (u ServerNotification) AsProgressNotification() (v ProgressNotification, ok bool) {
+	if u.ProgressNotification != nil {
+		return *u.ProgressNotification, true
+	}
+	return v, false
+}
+
+// AsResourceListChangedNotification returns the ResourceListChangedNotification variant, and whether it is set
+func (u ServerNotification) AsResourceListChangedNotification() (v ResourceListChangedNotification, ok bool) {
+	if u.ResourceListChangedNotification != nil {
+		return *u.ResourceListChangedNotification, true
+	}
+	return v, false
+}
+
+// AsResourceUpdatedNotification returns the ResourceUpdatedNotification variant, and whether it is set
+func (u ServerNotification) AsResourceUpdatedNotification() (v ResourceUpdatedNotification, ok bool) {
+	if u.ResourceUpdatedNotification != nil {
+		return *u.ResourceUpdatedNotification, true
+	}
+	return v, false
+}
+
+// AsPromptListChangedNotification returns the PromptListChangedNotification variant, and whether it is set
+func (u ServerNotification) AsPromptListChangedNotification() (v PromptListChangedNotification, ok bool) {
+	if u.PromptListChangedNotification != nil {
+		return *u.PromptListChangedNotification, true
+	}
+	return v, false
+}
+
+// AsToolListChangedNotification returns the ToolListChangedNotification variant, and whether it is set
+func (u ServerNotification) AsToolListChangedNotification() (v ToolListChangedNotification, ok bool) {
+	if u.ToolListChangedNotification != nil {
+		return *u.ToolListChangedNotification, true
+	}
+	return v, false
+}
+
+// AsTaskStatusNotification returns the TaskStatusNotification variant, and whether it is set
+func (u ServerNotification) AsTaskStatusNotification() (v TaskStatusNotification, ok bool) {
+	if u.TaskStatusNotification != nil {
+		return *u.TaskStatusNotification, true
+	}
+	return v, false
+}
+
+// AsLoggingMessageNotification returns the LoggingMessageNotification variant, and whether it is set
+func (u ServerNotification) AsLoggingMessageNotification() (v LoggingMessageNotification, ok bool) {
+	if u.LoggingMessageNotification != nil {
+		return *u.LoggingMessageNotification, true
+	}
+	return v, false
+}
+
+// AsElicitationCompleteNotification returns the ElicitationCompleteNotification variant, and whether it is set
+func (u ServerNotification) AsElicitationCompleteNotification() (v ElicitationCompleteNotification, ok bool) {
+	if u.ElicitationCompleteNotification != nil {
+		return *u.ElicitationCompleteNotification, true
+	}
+	return v, false
+}
+
+type ServerRequest struct {
+	PingRequest           *PingRequest
+	GetTaskRequest        *GetTaskRequest
+	GetTaskPayloadRequest *GetTaskPayloadRequest
+	CancelTaskRequest     *CancelTaskRequest
+	ListTasksRequest      *ListTasksRequest
+	CreateMessageRequest  *CreateMessageRequest
+	ListRootsRequest      *ListRootsRequest
+	ElicitRequest         *ElicitRequest
+}
+
+func (u ServerRequest) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.PingRequest != nil:
+		return json.Marshal(u.PingRequest)
+	case u.GetTaskRequest != nil:
+		return json.Marshal(u.GetTaskRequest)
+	case u.GetTaskPayloadRequest != nil:
+		return json.Marshal(u.GetTaskPayloadRequest)
+	case u.CancelTaskRequest != nil:
+		return json.Marshal(u.CancelTaskRequest)
+	case u.ListTasksRequest != nil:
+		return json.Marshal(u.ListTasksRequest)
+	case u.CreateMessageRequest != nil:
+		return json.Marshal(u.CreateMessageRequest)
+	case u.ListRootsRequest != nil:
+		return json.Marshal(u.ListRootsRequest)
+	case u.ElicitRequest != nil:
+		return json.Marshal(u.ElicitRequest)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ServerRequest) UnmarshalJSON(data []byte) error {
+	*u = ServerRequest{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[PingRequest](data); err == nil {
+			u.PingRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[GetTaskRequest](data); err == nil {
+			u.GetTaskRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[GetTaskPayloadRequest](data); err == nil {
+			u.GetTaskPayloadRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[CancelTaskRequest](data); err == nil {
+			u.CancelTaskRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListTasksRequest](data); err == nil {
+			u.ListTasksRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[CreateMessageRequest](data); err == nil {
+			u.CreateMessageRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method") {
+		if v, err := decode[ListRootsRequest](data); err == nil {
+			u.ListRootsRequest = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "id", "jsonrpc", "method", "params") {
+		if v, err := decode[ElicitRequest](data); err == nil {
+			u.ElicitRequest = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ServerRequest: %s value matches none of the variants", kind)
+}
+
+// AsPingRequest returns the PingRequest variant, and whether it is set
+func (u ServerRequest) AsPingRequest() (v PingRequest, ok bool) {
+	if u.PingRequest != nil {
+		return *u.PingRequest, true
+	}
+	return v, false
+}
+
+// AsGetTaskRequest returns the GetTaskRequest variant, and whether it is set
+func (u ServerRequest) AsGetTaskRequest() (v GetTaskRequest, ok bool) {
+	if u.GetTaskRequest != nil {
+		return *u.GetTaskRequest, true
+	}
+	return v, false
+}
+
+// AsGetTaskPayloadRequest returns the GetTaskPayloadRequest variant, and whether it is set
+func (u ServerRequest) AsGetTaskPayloadRequest() (v GetTaskPayloadRequest, ok bool) {
+	if u.GetTaskPayloadRequest != nil {
+		return *u.GetTaskPayloadRequest, true
+	}
+	return v, false
+}
+
+// AsCancelTaskRequest returns the CancelTaskRequest variant, and whether it is set
+func (u ServerRequest) AsCancelTaskRequest() (v CancelTaskRequest, ok bool) {
+	if u.CancelTaskRequest != nil {
+		return *u.CancelTaskRequest, true
+	}
+	return v, false
+}
+
+// AsListTasksRequest returns the ListTasksRequest variant, and whether it is set
+func (u ServerRequest) AsListTasksRequest() (v ListTasksRequest, ok bool) {
+	if u.ListTasksRequest != nil {
+		return *u.ListTasksRequest, true
+	}
+	return v, false
+}
+
+// AsCreateMessageRequest returns the CreateMessageRequest variant, and whether it is set
+func (u ServerRequest) AsCreateMessageRequest() (v CreateMessageRequest, ok bool) {
+	if u.CreateMessageRequest != nil {
+		return *u.CreateMessageRequest, true
+	}
+	return v, false
+}
+
+// AsListRootsRequest returns the ListRootsRequest variant, and whether it is set
+func (u ServerRequest) AsListRootsRequest() (v ListRootsRequest, ok bool) {
+	if u.ListRootsRequest != nil {
+		return *u.ListRootsRequest, true
+	}
+	return v, false
+}
+
+// AsElicitRequest returns the ElicitRequest variant, and whether it is set
+func (u ServerRequest) AsElicitRequest() (v ElicitRequest, ok bool) {
+	if u.ElicitRequest != nil {
+		return *u.ElicitRequest, true
+	}
+	return v, false
+}
+
+type ServerResult struct {
+	Result                      *Result
+	InitializeResult            *InitializeResult
+	ListResourcesResult         *ListResourcesResult
+	ListResourceTemplatesResult *ListResourceTemplatesResult
+	ReadResourceResult          *ReadResourceResult
+	ListPromptsResult           *ListPromptsResult
+	GetPromptResult             *GetPromptResult
+	ListToolsResult             *ListToolsResult
+	CallToolResult              *CallToolResult
+	GetTaskResult               *GetTaskResult
+	GetTaskPayloadResult        *GetTaskPayloadResult
+	CancelTaskResult            *CancelTaskResult
+	ListTasksResult             *ListTasksResult
+	CompleteResult              *CompleteResult
+}
+
+func (u ServerResult) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.Result != nil:
+		return json.Marshal(u.Result)
+	case u.InitializeResult != nil:
+		return json.Marshal(u.InitializeResult)
+	case u.ListResourcesResult != nil:
+		return json.Marshal(u.ListResourcesResult)
+	case u.ListResourceTemplatesResult != nil:
+		return json.Marshal(u.ListResourceTemplatesResult)
+	case u.ReadResourceResult != nil:
+		return json.Marshal(u.ReadResourceResult)
+	case u.ListPromptsResult != nil:
+		return json.Marshal(u.ListPromptsResult)
+	case u.GetPromptResult != nil:
+		return json.Marshal(u.GetPromptResult)
+	case u.ListToolsResult != nil:
+		return json.Marshal(u.ListToolsResult)
+	case u.CallToolResult != nil:
+		return json.Marshal(u.CallToolResult)
+	case u.GetTaskResult != nil:
+		return json.Marshal(u.GetTaskResult)
+	case u.GetTaskPayloadResult != nil:
+		return json.Marshal(u.GetTaskPayloadResult)
+	case u.CancelTaskResult != nil:
+		return json.Marshal(u.CancelTaskResult)
+	case u.ListTasksResult != nil:
+		return json.Marshal(u.ListTasksResult)
+	case u.CompleteResult != nil:
+		return json.Marshal(u.CompleteResult)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *ServerResult) UnmarshalJSON(data []byte) error {
+	*u = ServerResult{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" {
+		if v, err := decode[Result](data); err == nil {
+			u.Result = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "capabilities", "protocolVersion", "serverInfo") {
+		if v, err := decode[InitializeResult](data); err == nil {
+			u.InitializeResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "resources") {
+		if v, err := decode[ListResourcesResult](data); err == nil {
+			u.ListResourcesResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "resourceTemplates") {
+		if v, err := decode[ListResourceTemplatesResult](data); err == nil {
+			u.ListResourceTemplatesResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "contents") {
+		if v, err := decode[ReadResourceResult](data); err == nil {
+			u.ReadResourceResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "prompts") {
+		if v, err := decode[ListPromptsResult](data); err == nil {
+			u.ListPromptsResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "messages") {
+		if v, err := decode[GetPromptResult](data); err == nil {
+			u.GetPromptResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "tools") {
+		if v, err := decode[ListToolsResult](data); err == nil {
+			u.ListToolsResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "content") {
+		if v, err := decode[CallToolResult](data); err == nil {
+			u.CallToolResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "createdAt", "lastUpdatedAt", "status", "taskId", "ttl") {
+		if v, err := decode[GetTaskResult](data); err == nil {
+			u.GetTaskResult = &v
+			return nil
+		}
+	}
+	if kind == "object" {
+		if v, err := decode[GetTaskPayloadResult](data); err == nil {
+			u.GetTaskPayloadResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "createdAt", "lastUpdatedAt", "status", "taskId", "ttl") {
+		if v, err := decode[CancelTaskResult](data); err == nil {
+			u.CancelTaskResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "tasks") {
+		if v, err := decode[ListTasksResult](data); err == nil {
+			u.ListTasksResult = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "completion") {
+		if v, err := decode[CompleteResult](data); err == nil {
+			u.CompleteResult = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("ServerResult: %s value matches none of the variants", kind)
+}
+
+// AsResult returns the Result variant, and whether it is set
+func (u ServerResult) AsResult() (v Result, ok bool) {
+	if u.Result != nil {
+		return *u.Result, true
+	}
+	return v, false
+}
+
+// AsInitializeResult returns the InitializeResult variant, and whether it is set
+func (u ServerResult) AsInitializeResult() (v InitializeResult, ok bool) {
+	if u.InitializeResult != nil {
+		return *u.InitializeResult, true
+	}
+	return v, false
+}
+
+// AsListResourcesResult returns the ListResourcesResult variant, and whether it is set
+func (u ServerResult) AsListResourcesResult() (v ListResourcesResult, ok bool) {
+	if u.ListResourcesResult != nil {
+		return *u.ListResourcesResult, true
+	}
+	return v, false
+}
+
+// AsListResourceTemplatesResult returns the ListResourceTemplatesResult variant, and whether it is set
+func (u ServerResult) AsListResourceTemplatesResult() (v ListResourceTemplatesResult, ok bool) {
+	if u.ListResourceTemplatesResult != nil {
+		return *u.ListResourceTemplatesResult, true
+	}
+	return v, false
+}
+
+// AsReadResourceResult returns the ReadResourceResult variant, and whether it is set
+func (u ServerResult) AsReadResourceResult() (v ReadResourceResult, ok bool) {
+	if u.ReadResourceResult != nil {
+		return *u.ReadResourceResult, true
+	}
+	return v, false
+}
+
+// AsListPromptsResult returns the ListPromptsResult variant, and whether it is set
+func (u ServerResult) AsListPromptsResult() (v ListPromptsResult, ok bool) {
+	if u.ListPromptsResult != nil {
+		return *u.ListPromptsResult, true
+	}
+	return v, false
+}
+
+// AsGetPromptResult returns the GetPromptResult variant, and whether it is set
+func (u ServerResult) AsGetPromptResult() (v GetPromptResult, ok bool) {
+	if u.GetPromptResult != nil {
+		return *u.GetPromptResult, true
+	}
+	return v, false
+}
+
+// AsListToolsResult returns the ListToolsResult variant, and whether it is set
+func (u ServerResult) AsListToolsResult() (v ListToolsResult, ok bool) {
+	if u.ListToolsResult != nil {
+		return *u.ListToolsResult, true
+	}
+	return v, false
+}
+
+// AsCallToolResult returns the CallToolResult variant, and whether it is set
+func (u ServerResult) AsCallToolResult() (v CallToolResult, ok bool) {
+	if u.CallToolResult != nil {
+		return *u.CallToolResult, true
+	}
+	return v, false
+}
+
+// AsGetTaskResult returns the GetTaskResult variant, and whether it is set
+func (u ServerResult) AsGetTaskResult() (v GetTaskResult, ok bool) {
+	if u.GetTaskResult != nil {
+		return *u.GetTaskResult, true
+	}
+	return v, false
+}
+
+// AsGetTaskPayloadResult returns the GetTaskPayloadResult variant, and whether it is set
+func (u ServerResult) AsGetTaskPayloadResult() (v GetTaskPayloadResult, ok bool) {
+	if u.GetTaskPayloadResult != nil {
+		return *u.GetTaskPayloadResult, true
+	}
+	return v, false
+}
+
+// AsCancelTaskResult returns the CancelTaskResult variant, and whether it is set
+func (u ServerResult) AsCancelTaskResult() (v CancelTaskResult, ok bool) {
+	if u.CancelTaskResult != nil {
+		return *u.CancelTaskResult, true
+	}
+	return v, false
+}
+
+// AsListTasksResult returns the ListTasksResult variant, and whether it is set
+func (u ServerResult) AsListTasksResult() (v ListTasksResult, ok bool) {
+	if u.ListTasksResult != nil {
+		return *u.ListTasksResult, true
+	}
+	return v, false
+}
+
+// AsCompleteResult returns the CompleteResult variant, and whether it is set
+func (u ServerResult) AsCompleteResult() (v CompleteResult, ok bool) {
+	if u.CompleteResult != nil {
+		return *u.CompleteResult, true
+	}
+	return v, false
+}
+
+type SingleSelectEnumSchema struct {
+	UntitledSingleSelectEnumSchema *UntitledSingleSelectEnumSchema
+	TitledSingleSelectEnumSchema   *TitledSingleSelectEnumSchema
+}
+
+func (u SingleSelectEnumSchema) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.UntitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.UntitledSingleSelectEnumSchema)
+	case u.TitledSingleSelectEnumSchema != nil:
+		return json.Marshal(u.TitledSingleSelectEnumSchema)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *SingleSelectEnumSchema) UnmarshalJSON(data []byte) error {
+	*u = SingleSelectEnumSchema{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "enum", "type") {
+		if v, err := decode[UntitledSingleSelectEnumSchema](data); err == nil {
+			u.UntitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "oneOf", "type") {
+		if v, err := decode[TitledSingleSelectEnumSchema](data); err == nil {
+			u.TitledSingleSelectEnumSchema = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("SingleSelectEnumSchema: %s value matches none of the variants", kind)
+}
+
+// AsUntitledSingleSelectEnumSchema returns the UntitledSingleSelectEnumSchema variant, and whether it is set
+func (u SingleSelectEnumSchema) AsUntitledSingleSelectEnumSchema() (v UntitledSingleSelectEnumSchema, ok bool) {
+	if u.UntitledSingleSelectEnumSchema != nil {
+		return *u.UntitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
+
+// AsTitledSingleSelectEnumSchema returns the TitledSingleSelectEnumSchema variant, and whether it is set
+func (u SingleSelectEnumSchema) AsTitledSingleSelectEnumSchema() (v TitledSingleSelectEnumSchema, ok bool) {
+	if u.TitledSingleSelectEnumSchema != nil {
+		return *u.TitledSingleSelectEnumSchema, true
+	}
+	return v, false
+}
 
 // See [General fields: `_meta`](/specification/2025-11-25/basic/index#meta) for notes on `_meta` usage.
 type TaskAugmentedRequestParamsMeta struct {
@@ -2050,3 +4026,46 @@ type URLElicitationRequiredError struct {
 	ID      *RequestId  `json:"id,omitempty"`
 	Jsonrpc string      `json:"jsonrpc"`
 }
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
+
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
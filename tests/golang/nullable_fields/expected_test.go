@@ -0,0 +1,229 @@
+package nullable_fields_test
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Address struct {
+	Line1 string        `json:"line1"`
+	Line2 Field[string] `json:"line2,omitzero"`
+}
+
+// Validate checks Address against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Address) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Address) validate(v *validator, path string) {
+	if utf8.RuneCountInString(x.Line1) < 1 {
+		v.fail(path+"/line1", "must be at least 1 characters")
+	}
+}
+
+type UserPatch struct {
+	Address  Field[Address]           `json:"address,omitzero"`
+	Age      Field[int]               `json:"age,omitzero"`
+	ID       string                   `json:"id"`
+	Metadata Field[map[string]string] `json:"metadata,omitzero"`
+	Name     Field[string]            `json:"name,omitzero"`
+	// Required, but may be cleared with null
+	Nickname Field[string]           `json:"nickname"`
+	Scores   Field[[]Field[float64]] `json:"scores,omitzero"`
+	Tags     Field[[]string]         `json:"tags,omitzero"`
+}
+
+// Validate checks UserPatch against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x UserPatch) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x UserPatch) validate(v *validator, path string) {
+	if val, ok := x.Address.Get(); ok {
+		val.validate(v, path+"/address")
+	}
+	if val, ok := x.Age.Get(); ok {
+		if val < 0 {
+			v.fail(path+"/age", "must be >= 0")
+		}
+	}
+	if val, ok := x.Name.Get(); ok {
+		if utf8.RuneCountInString(val) < 1 {
+			v.fail(path+"/name", "must be at least 1 characters")
+		}
+	}
+	if val, ok := x.Tags.Get(); ok {
+		for i1, item1 := range val {
+			if utf8.RuneCountInString(item1) > 16 {
+				v.fail(path+"/tags/"+strconv.Itoa(i1), "must be at most 16 characters")
+			}
+		}
+	}
+}
+
+// Field is an optional value that tells a field absent from the JSON apart from
+// one set to null. The zero value is absent.
+type Field[T any] struct {
+	value T
+	state fieldState
+}
+
+type fieldState uint8
+
+const (
+	fieldAbsent fieldState = iota
+	fieldNull
+	fieldSet
+)
+
+// NewField returns a Field set to v
+func NewField[T any](v T) Field[T] {
+	return Field[T]{value: v, state: fieldSet}
+}
+
+// NullField returns a Field set to null
+func NullField[T any]() Field[T] {
+	return Field[T]{state: fieldNull}
+}
+
+// Get returns the value, and whether it is set
+func (f Field[T]) Get() (T, bool) {
+	return f.value, f.state == fieldSet
+}
+
+// IsSet reports whether the field holds a value
+func (f Field[T]) IsSet() bool { return f.state == fieldSet }
+
+// IsNull reports whether the field was set to null
+func (f Field[T]) IsNull() bool { return f.state == fieldNull }
+
+// IsZero reports whether the field is absent, so omitzero leaves it out
+func (f Field[T]) IsZero() bool { return f.state == fieldAbsent }
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if f.state != fieldSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.value)
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = NullField[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*f = NewField(v)
+	return nil
+}
+
+// ValidationError is returned by Validate, listing every value that failed
+// validation.
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+// ValidationFailure is a value that failed validation
+type ValidationFailure struct {
+	// Path is the JSON pointer to the value, such as "/items/0/name"
+	Path string
+	// Message describes the constraint the value failed
+	Message string
+}
+
+func (f ValidationFailure) Error() string {
+	return f.Path + ": " + f.Message
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failures, so errors.As can match a ValidationFailure
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Each calls fn with every failure, so types in other packages can collect
+// them.
+func (e *ValidationError) Each(fn func(path, message string)) {
+	for _, f := range e.Failures {
+		fn(f.Path, f.Message)
+	}
+}
+
+type validator struct {
+	failures []ValidationFailure
+}
+
+func (v *validator) fail(path, message string) {
+	v.failures = append(v.failures, ValidationFailure{Path: path, Message: message})
+}
+
+// merge adds the failures of a type from another package under path
+func (v *validator) merge(path string, err error) {
+	if err == nil {
+		return
+	}
+	if e, ok := err.(interface {
+		Each(func(path, message string))
+	}); ok {
+		e.Each(func(p, message string) { v.fail(path+p, message) })
+		return
+	}
+	v.fail(path, err.Error())
+}
+
+func (v *validator) err() error {
+	if len(v.failures) == 0 {
+		return nil
+	}
+	return &ValidationError{Failures: v.failures}
+}
+
+// pointerToken escapes a map key for use in a JSON pointer
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// uniqueItems compares items by their JSON encoding, since they may not be
+// comparable
+func uniqueItems[T any](items []T) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return false
+		}
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+func multipleOf(value, divisor float64) bool {
+	q := value / divisor
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
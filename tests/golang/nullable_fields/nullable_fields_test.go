@@ -0,0 +1,56 @@
+package nullable_fields_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalStates(t *testing.T) {
+	var patch UserPatch
+	require.NoError(t, json.Unmarshal([]byte(`{"id": "1", "nickname": null, "name": "Ada", "age": null}`), &patch))
+
+	name, ok := patch.Name.Get()
+	assert.True(t, ok)
+	assert.Equal(t, "Ada", name)
+
+	assert.True(t, patch.Age.IsNull())
+	assert.False(t, patch.Age.IsSet())
+	assert.True(t, patch.Nickname.IsNull())
+
+	// Absent fields are neither set nor null
+	assert.True(t, patch.Address.IsZero())
+	assert.False(t, patch.Address.IsNull())
+	assert.False(t, patch.Tags.IsSet())
+}
+
+func TestMarshalStates(t *testing.T) {
+	patch := UserPatch{
+		ID:   "1",
+		Name: NewField("Ada"),
+		Age:  NullField[int](),
+		Tags: NewField([]string{"a"}),
+	}
+
+	data, err := json.Marshal(patch)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1", "nickname": null, "name": "Ada", "age": null, "tags": ["a"]}`, string(data))
+}
+
+func TestValidateSetFields(t *testing.T) {
+	patch := UserPatch{
+		ID:      "1",
+		Name:    NewField(""),
+		Age:     NullField[int](),
+		Address: NewField(Address{}),
+	}
+
+	var verr *ValidationError
+	require.ErrorAs(t, patch.Validate(), &verr)
+	assert.Equal(t, []ValidationFailure{
+		{Path: "/address/line1", Message: "must be at least 1 characters"},
+		{Path: "/name", Message: "must be at least 1 characters"},
+	}, verr.Failures)
+}
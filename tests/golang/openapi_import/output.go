@@ -0,0 +1,191 @@
+package openapi_import
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type DogSize string
+
+const (
+	DogSizeSmall  DogSize = "small"
+	DogSizeMedium DogSize = "medium"
+	DogSizeLarge  DogSize = "large"
+)
+
+var DogSizeValues = []DogSize{
+	DogSizeSmall,
+	DogSizeMedium,
+	DogSizeLarge,
+}
+
+// IsValid reports whether the value is one of DogSizeValues
+func (e DogSize) IsValid() bool {
+	for _, v := range DogSizeValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e DogSize) String() string {
+	return string(e)
+}
+
+// ParseDogSize converts a string to a DogSize, returning an error when it
+// is not one of DogSizeValues.
+func ParseDogSize(s string) (DogSize, error) {
+	e := DogSize(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid DogSize %q", s)
+	}
+	return e, nil
+}
+
+type Owner struct {
+	Age  *int   `json:"age,omitempty"`
+	Name string `json:"name"`
+}
+
+type PetBase struct {
+	Birthday *time.Time `json:"birthday,omitempty"`
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name"`
+}
+
+type PetUnion interface {
+	PetType() string
+	isPet()
+}
+
+type Pet struct {
+	PetUnion
+}
+
+func (w Pet) MarshalJSON() ([]byte, error) {
+	if w.PetUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.PetUnion)
+}
+
+func (w *Pet) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.PetUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Pet: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Pet: missing discriminator field %q", "kind")
+	}
+
+	var v PetUnion
+	switch peek.Type {
+	case "cat":
+		v = &Cat{}
+	case "dog":
+		v = &Dog{}
+	case "Lizard":
+		v = &Lizard{}
+	default:
+		return fmt.Errorf("Pet: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Pet: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.PetUnion = v
+	return nil
+}
+
+// PetVisitor has a method for every variant of Pet
+type PetVisitor interface {
+	VisitCat(*Cat) error
+	VisitDog(*Dog) error
+	VisitLizard(*Lizard) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Pet) Visit(v PetVisitor) error {
+	switch u := w.PetUnion.(type) {
+	case *Cat:
+		return v.VisitCat(u)
+	case Cat:
+		return v.VisitCat(&u)
+	case *Dog:
+		return v.VisitDog(u)
+	case Dog:
+		return v.VisitDog(&u)
+	case *Lizard:
+		return v.VisitLizard(u)
+	case Lizard:
+		return v.VisitLizard(&u)
+	}
+	return errors.New("Pet: no variant is set")
+}
+
+type Cat struct {
+	Birthday *time.Time `json:"birthday,omitempty"`
+	Indoor   *bool      `json:"indoor,omitempty"`
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name"`
+}
+
+func (Cat) isPet() {}
+
+func (Cat) PetType() string { return "cat" }
+
+// NewPetFromCat wraps v, setting its discriminator
+func NewPetFromCat(v Cat) Pet {
+	v.Kind = "cat"
+	return Pet{&v}
+}
+
+type Dog struct {
+	Birthday *time.Time `json:"birthday,omitempty"`
+	Kind     string     `json:"kind"`
+	Name     string     `json:"name"`
+	// The dog's owner, if known.
+	Owner *Owner   `json:"owner,omitempty"`
+	Size  *DogSize `json:"size,omitempty"`
+}
+
+func (Dog) isPet() {}
+
+func (Dog) PetType() string { return "dog" }
+
+// NewPetFromDog wraps v, setting its discriminator
+func NewPetFromDog(v Dog) Pet {
+	v.Kind = "dog"
+	return Pet{&v}
+}
+
+type Lizard struct {
+	// The keeper, null when the lizard is wild.
+	Keeper     *Owner `json:"keeper"`
+	Kind       string `json:"kind"`
+	LovesRocks *bool  `json:"lovesRocks,omitempty"`
+	Vet        *Owner `json:"vet"`
+}
+
+func (Lizard) isPet() {}
+
+func (Lizard) PetType() string { return "Lizard" }
+
+// NewPetFromLizard wraps v, setting its discriminator
+func NewPetFromLizard(v Lizard) Pet {
+	v.Kind = "Lizard"
+	return Pet{&v}
+}
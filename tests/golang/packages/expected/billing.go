@@ -0,0 +1,17 @@
+package billing
+
+import (
+	"github.com/Southclaws/schemancer/tests/golang/packages/expected/common"
+)
+
+type InvoiceLine struct {
+	Amount      common.Money `json:"amount"`
+	Description string       `json:"description"`
+}
+
+type Invoice struct {
+	Customer common.Party  `json:"customer"`
+	ID       string        `json:"id"`
+	Lines    []InvoiceLine `json:"lines"`
+	Total    common.Money  `json:"total"`
+}
@@ -0,0 +1,31 @@
+package packages_test
+
+import (
+	"testing"
+
+	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+	"github.com/Southclaws/schemancer/tests/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackages(t *testing.T) {
+	schema, err := loader.FromFile("schema.yaml")
+	require.NoError(t, err, "failed to load schema")
+
+	files, err := schemancer.Generate(schema, generators.GlobalOptions{},
+		golang.WithPackageName("billing"),
+		golang.WithPackages(map[string]golang.Package{
+			"common.yaml": {
+				ImportPath: "github.com/Southclaws/schemancer/tests/golang/packages/expected/common",
+				Dir:        "common",
+			},
+		}),
+	)
+	require.NoError(t, err, "failed to generate")
+
+	testutil.WriteAndCompareMultipleFiles(t, files, "output", "expected")
+}
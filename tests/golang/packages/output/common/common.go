@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+)
+
+type Currency string
+
+const (
+	CurrencyEur Currency = "EUR"
+	CurrencyGbp Currency = "GBP"
+	CurrencyUsd Currency = "USD"
+)
+
+var CurrencyValues = []Currency{
+	CurrencyEur,
+	CurrencyGbp,
+	CurrencyUsd,
+}
+
+// IsValid reports whether the value is one of CurrencyValues
+func (e Currency) IsValid() bool {
+	for _, v := range CurrencyValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Currency) String() string {
+	return string(e)
+}
+
+// ParseCurrency converts a string to a Currency, returning an error when it
+// is not one of CurrencyValues.
+func ParseCurrency(s string) (Currency, error) {
+	e := Currency(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Currency %q", s)
+	}
+	return e, nil
+}
+
+type Money struct {
+	// Decimal amount, such as "12.50"
+	Amount   string   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+type PartyAddress struct {
+	Country string `json:"country"`
+	Line1   string `json:"line1"`
+}
+
+type Party struct {
+	Address *PartyAddress `json:"address,omitempty"`
+	Name    string        `json:"name"`
+}
@@ -0,0 +1,41 @@
+package strict_enums_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictUnmarshal(t *testing.T) {
+	var account Account
+	require.NoError(t, json.Unmarshal([]byte(`{"status": "active", "level": 2, "history": ["suspended"]}`), &account))
+	assert.Equal(t, StatusActive, account.Status)
+	assert.Equal(t, Level2, *account.Level)
+	assert.Equal(t, []Status{StatusSuspended}, account.History)
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"status": "closed"}`), &account), `invalid Status "closed"`)
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"status": "active", "level": 4}`), &account), "invalid Level 4")
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"status": "active", "history": ["active", "gone"]}`), &account), `invalid Status "gone"`)
+	assert.Error(t, json.Unmarshal([]byte(`{"status": 1}`), &account))
+}
+
+func TestParse(t *testing.T) {
+	status, err := ParseStatus("suspended")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuspended, status)
+	assert.Equal(t, "suspended", status.String())
+
+	_, err = ParseStatus("closed")
+	assert.Error(t, err)
+
+	level, err := ParseLevel("3")
+	require.NoError(t, err)
+	assert.Equal(t, Level3, level)
+	assert.Equal(t, "3", level.String())
+
+	_, err = ParseLevel("9")
+	assert.Error(t, err)
+	assert.False(t, Level(0).IsValid())
+}
@@ -0,0 +1,120 @@
+package strict_enums
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type Level int
+
+const (
+	Level1 Level = 1
+	Level2 Level = 2
+	Level3 Level = 3
+)
+
+var LevelValues = []Level{
+	Level1,
+	Level2,
+	Level3,
+}
+
+// IsValid reports whether the value is one of LevelValues
+func (e Level) IsValid() bool {
+	for _, v := range LevelValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Level) String() string {
+	return strconv.Itoa(int(e))
+}
+
+// ParseLevel converts a string to a Level, returning an error when it
+// is not one of LevelValues.
+func ParseLevel(s string) (Level, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || !Level(n).IsValid() {
+		return 0, fmt.Errorf("invalid Level %q", s)
+	}
+	return Level(n), nil
+}
+
+// UnmarshalJSON rejects values that are not one of LevelValues
+func (e *Level) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("Level: %w", err)
+	}
+	if !Level(n).IsValid() {
+		return fmt.Errorf("invalid Level %d", n)
+	}
+	*e = Level(n)
+	return nil
+}
+
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+)
+
+var StatusValues = []Status{
+	StatusActive,
+	StatusSuspended,
+}
+
+// IsValid reports whether the value is one of StatusValues
+func (e Status) IsValid() bool {
+	for _, v := range StatusValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Status) String() string {
+	return string(e)
+}
+
+// ParseStatus converts a string to a Status, returning an error when it
+// is not one of StatusValues.
+func ParseStatus(s string) (Status, error) {
+	e := Status(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Status %q", s)
+	}
+	return e, nil
+}
+
+// UnmarshalJSON rejects values that are not one of StatusValues
+func (e *Status) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Status: %w", err)
+	}
+	v, err := ParseStatus(s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+type Account struct {
+	History []Status `json:"history,omitempty"`
+	Level   *Level   `json:"level,omitempty"`
+	Status  Status   `json:"status"`
+}
@@ -0,0 +1,190 @@
+package struct_tags_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+type ShapeUnion interface {
+	ShapeType() string
+	isShape()
+}
+
+type Shape struct {
+	ShapeUnion
+}
+
+func (w Shape) MarshalJSON() ([]byte, error) {
+	if w.ShapeUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.ShapeUnion)
+}
+
+func (w *Shape) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.ShapeUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Shape: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Shape: missing discriminator field %q", "kind")
+	}
+
+	var v ShapeUnion
+	switch peek.Type {
+	case "circle":
+		v = &Circle{}
+	case "square":
+		v = &Square{}
+	default:
+		return fmt.Errorf("Shape: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Shape: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.ShapeUnion = v
+	return nil
+}
+
+// ShapeVisitor has a method for every variant of Shape
+type ShapeVisitor interface {
+	VisitCircle(*Circle) error
+	VisitSquare(*Square) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Shape) Visit(v ShapeVisitor) error {
+	switch u := w.ShapeUnion.(type) {
+	case *Circle:
+		return v.VisitCircle(u)
+	case Circle:
+		return v.VisitCircle(&u)
+	case *Square:
+		return v.VisitSquare(u)
+	case Square:
+		return v.VisitSquare(&u)
+	}
+	return errors.New("Shape: no variant is set")
+}
+
+type Circle struct {
+	Kind   string  `json:"kind" bson:"kind" db:"kind" yaml:"kind"`
+	Radius float64 `json:"radius" bson:"radius" db:"radius" yaml:"radius" validate:"gt=0"`
+}
+
+func (Circle) isShape() {}
+
+func (Circle) ShapeType() string { return "circle" }
+
+// NewShapeFromCircle wraps v, setting its discriminator
+func NewShapeFromCircle(v Circle) Shape {
+	v.Kind = "circle"
+	return Shape{&v}
+}
+
+type Square struct {
+	Kind       string  `json:"kind" bson:"kind" db:"kind" yaml:"kind"`
+	SideLength float64 `json:"sideLength" bson:"sideLength" db:"side_length" yaml:"side_length"`
+}
+
+func (Square) isShape() {}
+
+func (Square) ShapeType() string { return "square" }
+
+// NewShapeFromSquare wraps v, setting its discriminator
+func NewShapeFromSquare(v Square) Shape {
+	v.Kind = "square"
+	return Shape{&v}
+}
+
+type User struct {
+	DisplayName   *string      `json:"displayName,omitempty" bson:"displayName" db:"display_name" yaml:"display_name"`
+	EmailAddress  mail.Address `json:"emailAddress" bson:"emailAddress" db:"email_address" yaml:"email_address" validate:"required,email"`
+	InternalNotes *string      `json:"internalNotes,omitempty" bson:"-" db:"internal_notes" yaml:"-"`
+	UserID        string       `json:"userId" bson:"_id" db:"id" yaml:"user_id"`
+	// AdditionalProperties holds the properties not declared by the schema
+	AdditionalProperties map[string]interface{} `json:"-" bson:"-" db:"-" yaml:"-"`
+}
+
+// MarshalJSON adds AdditionalProperties to the declared properties
+func (x User) MarshalJSON() ([]byte, error) {
+	type plain User
+	data, err := json.Marshal(plain(x))
+	if err != nil || len(x.AdditionalProperties) == 0 {
+		return data, err
+	}
+	return marshalAdditional(data, x.AdditionalProperties)
+}
+
+// UnmarshalJSON decodes the properties that aren't declared by the schema
+// into AdditionalProperties.
+func (x *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	if err := json.Unmarshal(data, (*plain)(x)); err != nil {
+		return err
+	}
+	additional, err := unmarshalAdditional[interface{}](data, "displayName", "emailAddress", "internalNotes", "userId")
+	if err != nil {
+		return fmt.Errorf("User: %w", err)
+	}
+	x.AdditionalProperties = additional
+	return nil
+}
+
+// marshalAdditional adds additional properties to the JSON object of the
+// declared ones, which take precedence over additional properties of the same
+// name.
+func marshalAdditional[T any](data []byte, additional map[string]T) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for k, v := range additional {
+		if _, ok := object[k]; ok {
+			continue
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		object[k] = value
+	}
+	return json.Marshal(object)
+}
+
+// unmarshalAdditional decodes the properties of a JSON object besides the
+// known ones, returning nil when there are none.
+func unmarshalAdditional[T any](data []byte, known ...string) (map[string]T, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, k := range known {
+		delete(object, k)
+	}
+	if len(object) == 0 {
+		return nil, nil
+	}
+	additional := make(map[string]T, len(object))
+	for k, raw := range object {
+		var v T
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, fmt.Errorf("additional property %q: %w", k, err)
+		}
+		additional[k] = v
+	}
+	return additional, nil
+}
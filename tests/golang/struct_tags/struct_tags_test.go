@@ -0,0 +1,73 @@
+package struct_tags_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/golang"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+)
+
+func tagOf(t *testing.T, v any, field string) reflect.StructTag {
+	t.Helper()
+	f, ok := reflect.TypeOf(v).FieldByName(field)
+	require.True(t, ok)
+	return f.Tag
+}
+
+func TestStructTagsLookup(t *testing.T) {
+	tag := tagOf(t, User{}, "UserID")
+	assert.Equal(t, "userId", tag.Get("json"))
+	assert.Equal(t, "id", tag.Get("db"))
+	assert.Equal(t, "_id", tag.Get("bson"))
+	assert.Equal(t, "user_id", tag.Get("yaml"))
+
+	assert.Equal(t, "required,email", tagOf(t, User{}, "EmailAddress").Get("validate"))
+	assert.Equal(t, "-", tagOf(t, User{}, "AdditionalProperties").Get("db"))
+}
+
+func TestStructTagsInvalidExtension(t *testing.T) {
+	schema, err := loader.FromReader(strings.NewReader(`
+$defs:
+  Item:
+    type: object
+    properties:
+      id:
+        type: string
+        x-go-tag: 'db:id'
+`))
+	require.NoError(t, err)
+
+	_, err = schemancer.Generate(schema, generators.GlobalOptions{}, golang.WithPackageName("struct_tags"))
+	assert.ErrorContains(t, err, "x-go-tag of id")
+}
+
+func TestStructTagsJSONExtension(t *testing.T) {
+	schema, err := loader.FromReader(strings.NewReader(`
+$defs:
+  Item:
+    type: object
+    properties:
+      id:
+        type: string
+        x-go-tag: 'db:"id" json:"item_id"'
+`))
+	require.NoError(t, err)
+
+	_, err = schemancer.Generate(schema, generators.GlobalOptions{}, golang.WithPackageName("struct_tags"))
+	assert.ErrorContains(t, err, "x-go-tag of id can't set the json tag")
+}
+
+func TestStructTagsUnknownCasing(t *testing.T) {
+	schema, err := loader.FromFile("schema.yaml")
+	require.NoError(t, err)
+
+	_, err = schemancer.Generate(schema, generators.GlobalOptions{}, golang.WithStructTags(map[string]golang.TagCasing{"yaml": "upper"}))
+	assert.ErrorContains(t, err, `yaml tag: unknown casing "upper"`)
+}
@@ -0,0 +1,317 @@
+package sum_types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Circle struct {
+	Radius float64 `json:"radius"`
+}
+
+type Color string
+
+const (
+	ColorRed   Color = "red"
+	ColorGreen Color = "green"
+	ColorBlue  Color = "blue"
+)
+
+var ColorValues = []Color{
+	ColorRed,
+	ColorGreen,
+	ColorBlue,
+}
+
+// IsValid reports whether the value is one of ColorValues
+func (e Color) IsValid() bool {
+	for _, v := range ColorValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Color) String() string {
+	return string(e)
+}
+
+// ParseColor converts a string to a Color, returning an error when it
+// is not one of ColorValues.
+func ParseColor(s string) (Color, error) {
+	e := Color(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Color %q", s)
+	}
+	return e, nil
+}
+
+type Fill struct {
+	Color *Color
+	Time  *time.Time
+}
+
+func (u Fill) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.Color != nil:
+		return json.Marshal(u.Color)
+	case u.Time != nil:
+		return json.Marshal(u.Time)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *Fill) UnmarshalJSON(data []byte) error {
+	*u = Fill{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "string" {
+		if v, err := decode[Color](data); err == nil && v.IsValid() {
+			u.Color = &v
+			return nil
+		}
+	}
+	if kind == "string" {
+		if v, err := decode[time.Time](data); err == nil {
+			u.Time = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("Fill: %s value matches none of the variants", kind)
+}
+
+// AsColor returns the Color variant, and whether it is set
+func (u Fill) AsColor() (v Color, ok bool) {
+	if u.Color != nil {
+		return *u.Color, true
+	}
+	return v, false
+}
+
+// AsTime returns the Time variant, and whether it is set
+func (u Fill) AsTime() (v time.Time, ok bool) {
+	if u.Time != nil {
+		return *u.Time, true
+	}
+	return v, false
+}
+
+type Rectangle struct {
+	Height float64 `json:"height"`
+	Width  float64 `json:"width"`
+}
+
+// A circle, a rectangle or the name of a predefined shape
+type Shape struct {
+	Circle    *Circle
+	Rectangle *Rectangle
+	String    *string
+}
+
+func (u Shape) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.Circle != nil:
+		return json.Marshal(u.Circle)
+	case u.Rectangle != nil:
+		return json.Marshal(u.Rectangle)
+	case u.String != nil:
+		return json.Marshal(u.String)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *Shape) UnmarshalJSON(data []byte) error {
+	*u = Shape{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "object" && hasKeys(data, "radius") {
+		if v, err := decode[Circle](data); err == nil {
+			u.Circle = &v
+			return nil
+		}
+	}
+	if kind == "object" && hasKeys(data, "height", "width") {
+		if v, err := decode[Rectangle](data); err == nil {
+			u.Rectangle = &v
+			return nil
+		}
+	}
+	if kind == "string" {
+		if v, err := decode[string](data); err == nil {
+			u.String = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("Shape: %s value matches none of the variants", kind)
+}
+
+// AsCircle returns the Circle variant, and whether it is set
+func (u Shape) AsCircle() (v Circle, ok bool) {
+	if u.Circle != nil {
+		return *u.Circle, true
+	}
+	return v, false
+}
+
+// AsRectangle returns the Rectangle variant, and whether it is set
+func (u Shape) AsRectangle() (v Rectangle, ok bool) {
+	if u.Rectangle != nil {
+		return *u.Rectangle, true
+	}
+	return v, false
+}
+
+// AsString returns the String variant, and whether it is set
+func (u Shape) AsString() (v string, ok bool) {
+	if u.String != nil {
+		return *u.String, true
+	}
+	return v, false
+}
+
+type Value struct {
+	Int         *int
+	Float       *float64
+	Bool        *bool
+	StringArray *[]string
+}
+
+func (u Value) MarshalJSON() ([]byte, error) {
+	switch {
+	case u.Int != nil:
+		return json.Marshal(u.Int)
+	case u.Float != nil:
+		return json.Marshal(u.Float)
+	case u.Bool != nil:
+		return json.Marshal(u.Bool)
+	case u.StringArray != nil:
+		return json.Marshal(u.StringArray)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes the first variant matching the kind of JSON value, and
+// for objects its required properties.
+func (u *Value) UnmarshalJSON(data []byte) error {
+	*u = Value{}
+	kind := jsonKind(data)
+	if kind == "null" {
+		return nil
+	}
+	if kind == "number" {
+		if v, err := decode[int](data); err == nil {
+			u.Int = &v
+			return nil
+		}
+	}
+	if kind == "number" {
+		if v, err := decode[float64](data); err == nil {
+			u.Float = &v
+			return nil
+		}
+	}
+	if kind == "boolean" {
+		if v, err := decode[bool](data); err == nil {
+			u.Bool = &v
+			return nil
+		}
+	}
+	if kind == "array" {
+		if v, err := decode[[]string](data); err == nil {
+			u.StringArray = &v
+			return nil
+		}
+	}
+	return fmt.Errorf("Value: %s value matches none of the variants", kind)
+}
+
+// AsInt returns the Int variant, and whether it is set
+func (u Value) AsInt() (v int, ok bool) {
+	if u.Int != nil {
+		return *u.Int, true
+	}
+	return v, false
+}
+
+// AsFloat returns the Float variant, and whether it is set
+func (u Value) AsFloat() (v float64, ok bool) {
+	if u.Float != nil {
+		return *u.Float, true
+	}
+	return v, false
+}
+
+// AsBool returns the Bool variant, and whether it is set
+func (u Value) AsBool() (v bool, ok bool) {
+	if u.Bool != nil {
+		return *u.Bool, true
+	}
+	return v, false
+}
+
+// AsStringArray returns the StringArray variant, and whether it is set
+func (u Value) AsStringArray() (v []string, ok bool) {
+	if u.StringArray != nil {
+		return *u.StringArray, true
+	}
+	return v, false
+}
+
+type Drawing struct {
+	Fill   *Fill   `json:"fill,omitempty"`
+	Shapes []Shape `json:"shapes"`
+	Value  *Value  `json:"value,omitempty"`
+}
+
+// jsonKind returns the kind of a JSON value from its first byte
+func jsonKind(data []byte) string {
+	for _, c := range data {
+		switch {
+		case c == 'n':
+			return "null"
+		case c == 't' || c == 'f':
+			return "boolean"
+		case c == '"':
+			return "string"
+		case c == '[':
+			return "array"
+		case c == '{':
+			return "object"
+		case c == '-' || c >= '0' && c <= '9':
+			return "number"
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			return "invalid"
+		}
+	}
+	return "invalid"
+}
+
+// hasKeys reports whether data is a JSON object holding every key
+func hasKeys(data []byte, keys ...string) bool {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return false
+	}
+	for _, key := range keys {
+		if _, ok := object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func decode[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
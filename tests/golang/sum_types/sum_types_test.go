@@ -0,0 +1,92 @@
+package sum_types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalVariants(t *testing.T) {
+	var drawing Drawing
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"shapes": [{"radius": 2}, {"width": 3, "height": 4}, "triangle"],
+		"fill": "blue",
+		"value": 1.5
+	}`), &drawing))
+
+	require.Len(t, drawing.Shapes, 3)
+	circle, ok := drawing.Shapes[0].AsCircle()
+	assert.True(t, ok)
+	assert.Equal(t, Circle{Radius: 2}, circle)
+	_, ok = drawing.Shapes[0].AsRectangle()
+	assert.False(t, ok)
+	assert.Equal(t, &Rectangle{Width: 3, Height: 4}, drawing.Shapes[1].Rectangle)
+	name, ok := drawing.Shapes[2].AsString()
+	assert.True(t, ok)
+	assert.Equal(t, "triangle", name)
+
+	color, ok := drawing.Fill.AsColor()
+	assert.True(t, ok)
+	assert.Equal(t, ColorBlue, color)
+
+	// 1.5 isn't an integer, so the number variant is decoded
+	assert.Nil(t, drawing.Value.Int)
+	assert.Equal(t, 1.5, *drawing.Value.Float)
+}
+
+func TestUnmarshalVariantOrder(t *testing.T) {
+	var value Value
+	require.NoError(t, json.Unmarshal([]byte(`7`), &value))
+	assert.Equal(t, 7, *value.Int)
+	assert.Nil(t, value.Float)
+
+	require.NoError(t, json.Unmarshal([]byte(`["a", "b"]`), &value))
+	assert.Nil(t, value.Int, "previous variant is cleared")
+	assert.Equal(t, []string{"a", "b"}, *value.StringArray)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &value))
+	assert.Equal(t, Value{}, value)
+
+	// Strings that aren't one of the enum's values fall through
+	var fill Fill
+	require.NoError(t, json.Unmarshal([]byte(`"2024-05-01T10:00:00Z"`), &fill))
+	assert.Nil(t, fill.Color)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), *fill.Time)
+}
+
+func TestUnmarshalNoMatch(t *testing.T) {
+	var shape Shape
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"width": 3}`), &shape), "Shape: object value matches none of the variants")
+
+	var value Value
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{}`), &value), "Value: object value matches none of the variants")
+
+	var fill Fill
+	assert.Error(t, json.Unmarshal([]byte(`"purple"`), &fill))
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	square, yes := "square", true
+	drawing := Drawing{
+		Shapes: []Shape{
+			{Circle: &Circle{Radius: 1}},
+			{String: &square},
+		},
+		Value: &Value{Bool: &yes},
+	}
+
+	data, err := json.Marshal(drawing)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"shapes": [{"radius": 1}, "square"], "value": true}`, string(data))
+
+	var decoded Drawing
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, drawing, decoded)
+
+	data, err = json.Marshal(Shape{})
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
@@ -0,0 +1,139 @@
+package unknown_variants_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type EventUnion interface {
+	EventType() string
+	isEvent()
+}
+
+type Event struct {
+	EventUnion
+}
+
+func (w Event) MarshalJSON() ([]byte, error) {
+	if w.EventUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.EventUnion)
+}
+
+func (w *Event) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.EventUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Event: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Event: missing discriminator field %q", "kind")
+	}
+
+	var v EventUnion
+	switch peek.Type {
+	case "user_created":
+		v = &UserCreated{}
+	case "user_deleted":
+		v = &UserDeleted{}
+	default:
+		w.EventUnion = &UnknownEvent{Type: peek.Type, Raw: append(json.RawMessage(nil), data...)}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Event: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.EventUnion = v
+	return nil
+}
+
+// EventVisitor has a method for every variant of Event
+type EventVisitor interface {
+	VisitUserCreated(*UserCreated) error
+	VisitUserDeleted(*UserDeleted) error
+	VisitUnknownEvent(*UnknownEvent) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Event) Visit(v EventVisitor) error {
+	switch u := w.EventUnion.(type) {
+	case *UserCreated:
+		return v.VisitUserCreated(u)
+	case UserCreated:
+		return v.VisitUserCreated(&u)
+	case *UserDeleted:
+		return v.VisitUserDeleted(u)
+	case UserDeleted:
+		return v.VisitUserDeleted(&u)
+	case *UnknownEvent:
+		return v.VisitUnknownEvent(u)
+	case UnknownEvent:
+		return v.VisitUnknownEvent(&u)
+	}
+	return errors.New("Event: no variant is set")
+}
+
+type UserCreated struct {
+	Email string `json:"email"`
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+}
+
+func (UserCreated) isEvent() {}
+
+func (UserCreated) EventType() string { return "user_created" }
+
+// NewEventFromUserCreated wraps v, setting its discriminator
+func NewEventFromUserCreated(v UserCreated) Event {
+	v.Kind = "user_created"
+	return Event{&v}
+}
+
+type UserDeleted struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+}
+
+func (UserDeleted) isEvent() {}
+
+func (UserDeleted) EventType() string { return "user_deleted" }
+
+// NewEventFromUserDeleted wraps v, setting its discriminator
+func NewEventFromUserDeleted(v UserDeleted) Event {
+	v.Kind = "user_deleted"
+	return Event{&v}
+}
+
+// UnknownEvent is a variant of Event whose kind isn't known,
+// keeping its JSON so it's encoded unchanged.
+type UnknownEvent struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (UnknownEvent) isEvent() {}
+
+func (u UnknownEvent) EventType() string { return u.Type }
+
+func (u UnknownEvent) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+
+type Envelope struct {
+	Events []Event `json:"events"`
+}
@@ -0,0 +1,55 @@
+package unknown_variants_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownVariantRoundTrip(t *testing.T) {
+	input := `{"events":[{"kind":"user_created","id":"1","email":"a@example.com"},{"kind":"user_renamed","id":"1","name":{"first":"Ada"}}]}`
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal([]byte(input), &envelope))
+	require.Len(t, envelope.Events, 2)
+
+	assert.Equal(t, &UserCreated{Kind: "user_created", ID: "1", Email: "a@example.com"}, envelope.Events[0].EventUnion)
+
+	unknown, ok := envelope.Events[1].EventUnion.(*UnknownEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user_renamed", unknown.Type)
+	assert.Equal(t, "user_renamed", envelope.Events[1].EventType())
+
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(data))
+}
+
+type eventCounts struct {
+	known, unknown int
+}
+
+func (c *eventCounts) VisitUserCreated(*UserCreated) error { c.known++; return nil }
+
+func (c *eventCounts) VisitUserDeleted(*UserDeleted) error { c.known++; return nil }
+
+func (c *eventCounts) VisitUnknownEvent(*UnknownEvent) error { c.unknown++; return nil }
+
+func TestUnknownVariantVisit(t *testing.T) {
+	var events []Event
+	require.NoError(t, json.Unmarshal([]byte(`[{"kind":"user_deleted","id":"1"},{"kind":"user_banned"}]`), &events))
+
+	counts := &eventCounts{}
+	for _, e := range events {
+		require.NoError(t, e.Visit(counts))
+	}
+	assert.Equal(t, eventCounts{known: 1, unknown: 1}, *counts)
+}
+
+func TestUnknownVariantInvalidPayload(t *testing.T) {
+	// Known variants are still decoded strictly
+	var e Event
+	assert.Error(t, json.Unmarshal([]byte(`{"kind":"user_deleted","id":1}`), &e))
+}
@@ -0,0 +1,436 @@
+package validation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Address struct {
+	Line1    string  `json:"line1"`
+	Postcode *string `json:"postcode,omitempty"`
+}
+
+// Validate checks Address against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Address) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Address) validate(v *validator, path string) {
+	if utf8.RuneCountInString(x.Line1) < 1 {
+		v.fail(path+"/line1", "must be at least 1 characters")
+	}
+	if x.Postcode != nil {
+		if !addressPostcodePattern.MatchString(*x.Postcode) {
+			v.fail(path+"/postcode", "must match pattern ^[0-9]{5}$")
+		}
+	}
+}
+
+var (
+	addressPostcodePattern = regexp.MustCompile(`^[0-9]{5}$`)
+)
+
+type ContactUnion interface {
+	ContactType() string
+	isContact()
+}
+
+type Contact struct {
+	ContactUnion
+}
+
+func (w Contact) MarshalJSON() ([]byte, error) {
+	if w.ContactUnion == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(w.ContactUnion)
+}
+
+func (w *Contact) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		w.ContactUnion = nil
+		return nil
+	}
+
+	var peek struct {
+		Type string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &peek); err != nil {
+		return fmt.Errorf("Contact: invalid JSON: %w", err)
+	}
+	if peek.Type == "" {
+		return fmt.Errorf("Contact: missing discriminator field %q", "kind")
+	}
+
+	var v ContactUnion
+	switch peek.Type {
+	case "email":
+		v = &EmailContact{}
+	case "phone":
+		v = &PhoneContact{}
+	default:
+		return fmt.Errorf("Contact: unknown type %q", peek.Type)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Contact: invalid %q payload: %w", peek.Type, err)
+	}
+
+	w.ContactUnion = v
+	return nil
+}
+
+// ContactVisitor has a method for every variant of Contact
+type ContactVisitor interface {
+	VisitEmailContact(*EmailContact) error
+	VisitPhoneContact(*PhoneContact) error
+}
+
+// Visit calls the method of v for the variant held by w
+func (w Contact) Visit(v ContactVisitor) error {
+	switch u := w.ContactUnion.(type) {
+	case *EmailContact:
+		return v.VisitEmailContact(u)
+	case EmailContact:
+		return v.VisitEmailContact(&u)
+	case *PhoneContact:
+		return v.VisitPhoneContact(u)
+	case PhoneContact:
+		return v.VisitPhoneContact(&u)
+	}
+	return errors.New("Contact: no variant is set")
+}
+
+type EmailContact struct {
+	Email string `json:"email"`
+	Kind  string `json:"kind"`
+}
+
+func (EmailContact) isContact() {}
+
+func (EmailContact) ContactType() string { return "email" }
+
+// NewContactFromEmailContact wraps v, setting its discriminator
+func NewContactFromEmailContact(v EmailContact) Contact {
+	v.Kind = "email"
+	return Contact{&v}
+}
+
+type PhoneContact struct {
+	Digits int    `json:"digits"`
+	Kind   string `json:"kind"`
+}
+
+func (PhoneContact) isContact() {}
+
+func (PhoneContact) ContactType() string { return "phone" }
+
+// NewContactFromPhoneContact wraps v, setting its discriminator
+func NewContactFromPhoneContact(v PhoneContact) Contact {
+	v.Kind = "phone"
+	return Contact{&v}
+}
+
+// Validate checks Contact against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (w Contact) Validate() error {
+	v := &validator{}
+	w.validate(v, "")
+	return v.err()
+}
+
+func (w Contact) validate(v *validator, path string) {
+	if u, ok := w.ContactUnion.(interface{ validate(*validator, string) }); ok {
+		u.validate(v, path)
+	}
+}
+
+// Validate checks EmailContact against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x EmailContact) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x EmailContact) validate(v *validator, path string) {
+	if utf8.RuneCountInString(x.Email) > 254 {
+		v.fail(path+"/email", "must be at most 254 characters")
+	}
+}
+
+// Validate checks PhoneContact against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x PhoneContact) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x PhoneContact) validate(v *validator, path string) {
+	if x.Digits < 1000000 {
+		v.fail(path+"/digits", "must be >= 1000000")
+	}
+}
+
+type Plan string
+
+const (
+	PlanFree Plan = "free"
+	PlanPro  Plan = "pro"
+)
+
+var PlanValues = []Plan{
+	PlanFree,
+	PlanPro,
+}
+
+// IsValid reports whether the value is one of PlanValues
+func (e Plan) IsValid() bool {
+	for _, v := range PlanValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Plan) String() string {
+	return string(e)
+}
+
+// ParsePlan converts a string to a Plan, returning an error when it
+// is not one of PlanValues.
+func ParsePlan(s string) (Plan, error) {
+	e := Plan(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Plan %q", s)
+	}
+	return e, nil
+}
+
+type Signup struct {
+	Address *Address `json:"address,omitempty"`
+	Age     *int     `json:"age,omitempty"`
+	Contact Contact  `json:"contact"`
+	// Values nested ten arrays deep
+	Cube     [][][][][][][][][][]string `json:"cube,omitempty"`
+	Labels   map[string]string          `json:"labels,omitempty"`
+	Plan     Plan                       `json:"plan"`
+	Referrer *string                    `json:"referrer,omitempty"`
+	Score    *float64                   `json:"score,omitempty"`
+	Tags     []string                   `json:"tags"`
+	Username string                     `json:"username"`
+}
+
+// Validate checks Signup against the constraints of its schema, returning a
+// *ValidationError with the JSON pointer of every value that failed.
+func (x Signup) Validate() error {
+	v := &validator{}
+	x.validate(v, "")
+	return v.err()
+}
+
+func (x Signup) validate(v *validator, path string) {
+	if x.Address != nil {
+		(*x.Address).validate(v, path+"/address")
+	}
+	if x.Age != nil {
+		if *x.Age < 13 {
+			v.fail(path+"/age", "must be >= 13")
+		}
+		if *x.Age > 130 {
+			v.fail(path+"/age", "must be <= 130")
+		}
+	}
+	if x.Contact.ContactUnion == nil {
+		v.fail(path+"/contact", "is required")
+	} else {
+		x.Contact.validate(v, path+"/contact")
+	}
+	if x.Cube != nil {
+		for i, item := range x.Cube {
+			for i1, item1 := range item {
+				for i2, item2 := range item1 {
+					for i3, item3 := range item2 {
+						for i4, item4 := range item3 {
+							for i5, item5 := range item4 {
+								for i6, item6 := range item5 {
+									for i7, item7 := range item6 {
+										for i8, item8 := range item7 {
+											for i9, item9 := range item8 {
+												if utf8.RuneCountInString(item9) < 1 {
+													v.fail(path+"/cube/"+strconv.Itoa(i)+"/"+strconv.Itoa(i1)+"/"+strconv.Itoa(i2)+"/"+strconv.Itoa(i3)+"/"+strconv.Itoa(i4)+"/"+strconv.Itoa(i5)+"/"+strconv.Itoa(i6)+"/"+strconv.Itoa(i7)+"/"+strconv.Itoa(i8)+"/"+strconv.Itoa(i9), "must be at least 1 characters")
+												}
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	if x.Labels != nil {
+		for k, value := range x.Labels {
+			if utf8.RuneCountInString(value) > 32 {
+				v.fail(path+"/labels/"+pointerToken(k), "must be at most 32 characters")
+			}
+		}
+	}
+	if !x.Plan.IsValid() {
+		v.fail(path+"/plan", "must be one of \"free\", \"pro\"")
+	}
+	// pattern "^(?!admin).*$" is not supported by Go's regexp package
+	if x.Score != nil {
+		if *x.Score <= 0 {
+			v.fail(path+"/score", "must be > 0")
+		}
+		if !multipleOf(*x.Score, 0.5) {
+			v.fail(path+"/score", "must be a multiple of 0.5")
+		}
+	}
+	if x.Tags == nil {
+		v.fail(path+"/tags", "is required")
+	} else {
+		if len(x.Tags) < 1 {
+			v.fail(path+"/tags", "must have at least 1 items")
+		}
+		if len(x.Tags) > 5 {
+			v.fail(path+"/tags", "must have at most 5 items")
+		}
+		if !uniqueItems(x.Tags) {
+			v.fail(path+"/tags", "must have unique items")
+		}
+		for i, item := range x.Tags {
+			if utf8.RuneCountInString(item) < 1 {
+				v.fail(path+"/tags/"+strconv.Itoa(i), "must be at least 1 characters")
+			}
+		}
+	}
+	if utf8.RuneCountInString(x.Username) < 3 {
+		v.fail(path+"/username", "must be at least 3 characters")
+	}
+	if utf8.RuneCountInString(x.Username) > 16 {
+		v.fail(path+"/username", "must be at most 16 characters")
+	}
+	if !signupUsernamePattern.MatchString(x.Username) {
+		v.fail(path+"/username", "must match pattern ^[a-z][a-z0-9_]*$")
+	}
+}
+
+var (
+	signupUsernamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// ValidationError is returned by Validate, listing every value that failed
+// validation.
+type ValidationError struct {
+	Failures []ValidationFailure
+}
+
+// ValidationFailure is a value that failed validation
+type ValidationFailure struct {
+	// Path is the JSON pointer to the value, such as "/items/0/name"
+	Path string
+	// Message describes the constraint the value failed
+	Message string
+}
+
+func (f ValidationFailure) Error() string {
+	return f.Path + ": " + f.Message
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Error()
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the failures, so errors.As can match a ValidationFailure
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Each calls fn with every failure, so types in other packages can collect
+// them.
+func (e *ValidationError) Each(fn func(path, message string)) {
+	for _, f := range e.Failures {
+		fn(f.Path, f.Message)
+	}
+}
+
+type validator struct {
+	failures []ValidationFailure
+}
+
+func (v *validator) fail(path, message string) {
+	v.failures = append(v.failures, ValidationFailure{Path: path, Message: message})
+}
+
+// merge adds the failures of a type from another package under path
+func (v *validator) merge(path string, err error) {
+	if err == nil {
+		return
+	}
+	if e, ok := err.(interface {
+		Each(func(path, message string))
+	}); ok {
+		e.Each(func(p, message string) { v.fail(path+p, message) })
+		return
+	}
+	v.fail(path, err.Error())
+}
+
+func (v *validator) err() error {
+	if len(v.failures) == 0 {
+		return nil
+	}
+	return &ValidationError{Failures: v.failures}
+}
+
+// pointerToken escapes a map key for use in a JSON pointer
+func pointerToken(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// uniqueItems compares items by their JSON encoding, since they may not be
+// comparable
+func uniqueItems[T any](items []T) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return false
+		}
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+func multipleOf(value, divisor float64) bool {
+	q := value / divisor
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
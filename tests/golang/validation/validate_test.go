@@ -0,0 +1,78 @@
+package validation_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	var valid Signup
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"username": "ada_l",
+		"age": 36,
+		"score": 9.5,
+		"tags": ["math", "engines"],
+		"plan": "pro",
+		"address": {"line1": "12 St James's Square", "postcode": "10001"},
+		"labels": {"team": "analytical"},
+		"contact": {"kind": "email", "email": "ada@example.com"}
+	}`), &valid))
+	assert.NoError(t, valid.Validate())
+
+	var invalid Signup
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"username": "Ada",
+		"age": 7,
+		"score": 9.25,
+		"tags": ["math", "math", ""],
+		"plan": "enterprise",
+		"address": {"line1": "", "postcode": "N1"},
+		"labels": {"a/b": "0123456789012345678901234567890123456789"},
+		"contact": {"kind": "phone", "digits": 12}
+	}`), &invalid))
+
+	err := invalid.Validate()
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	var paths []string
+	for _, f := range verr.Failures {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{
+		"/address/line1",
+		"/address/postcode",
+		"/age",
+		"/contact/digits",
+		"/labels/a~1b",
+		"/plan",
+		"/score",
+		"/tags",
+		"/tags/2",
+		"/username",
+	}, paths)
+
+	var failure ValidationFailure
+	require.True(t, errors.As(err, &failure))
+	assert.Equal(t, "/address/line1: must be at least 1 characters", failure.Error())
+
+	assert.EqualError(t, Signup{Username: "ada"}.Validate(),
+		`validation failed: /contact: is required; /plan: must be one of "free", "pro"; /tags: is required`)
+}
+
+func TestValidateDeeplyNested(t *testing.T) {
+	var signup Signup
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"username": "ada",
+		"tags": ["math"],
+		"plan": "pro",
+		"contact": {"kind": "email", "email": "ada@example.com"},
+		"cube": [[[[[[[[[["a", ""]]]]]]]]]]
+	}`), &signup))
+
+	assert.EqualError(t, signup.Validate(), "validation failed: /cube/0/0/0/0/0/0/0/0/0/1: must be at least 1 characters")
+}
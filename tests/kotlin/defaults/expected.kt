@@ -0,0 +1,15 @@
+package com.example.generated
+
+import kotlinx.serialization.Serializable
+
+/** Server configuration with default values */
+@Serializable
+data class ServerConfig(
+    val debug: Boolean = false,
+    /** The hostname to bind to */
+    val host: String = "localhost",
+    val maxRetries: Long = 3L,
+    val port: Long = 8080L,
+    val tags: List<String>? = null,
+    val timeout: Double = 30.0,
+)
@@ -0,0 +1,51 @@
+package com.example.generated
+
+import kotlinx.datetime.Instant
+import kotlinx.serialization.ExperimentalSerializationApi
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.JsonClassDiscriminator
+import kotlinx.serialization.json.JsonElement
+
+@Serializable
+data class BaseEvent(
+    val timestamp: Instant,
+    val type: String,
+)
+
+@OptIn(ExperimentalSerializationApi::class)
+@Serializable
+@JsonClassDiscriminator("type")
+sealed interface Event {
+    val type: String
+}
+
+@Serializable
+@SerialName("created")
+data class CreatedEvent(
+    val id: String,
+    val name: String,
+    val timestamp: Instant,
+) : Event {
+    override val type: String get() = "created"
+}
+
+@Serializable
+@SerialName("updated")
+data class UpdatedEvent(
+    val changes: Map<String, JsonElement>,
+    val id: String,
+    val timestamp: Instant,
+) : Event {
+    override val type: String get() = "updated"
+}
+
+@Serializable
+@SerialName("deleted")
+data class DeletedEvent(
+    val id: String,
+    val reason: String? = null,
+    val timestamp: Instant,
+) : Event {
+    override val type: String get() = "deleted"
+}
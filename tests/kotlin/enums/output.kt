@@ -0,0 +1,54 @@
+package com.example.generated
+
+import kotlinx.serialization.KSerializer
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.SerializationException
+import kotlinx.serialization.descriptors.PrimitiveKind
+import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor
+import kotlinx.serialization.descriptors.SerialDescriptor
+import kotlinx.serialization.encoding.Decoder
+import kotlinx.serialization.encoding.Encoder
+
+@Serializable(with = HttpStatus.Serializer::class)
+enum class HttpStatus(val value: Long) {
+    VALUE_200(200),
+    VALUE_201(201),
+    VALUE_400(400),
+    VALUE_404(404),
+    VALUE_500(500),
+    ;
+
+    object Serializer : KSerializer<HttpStatus> {
+        override val descriptor: SerialDescriptor = PrimitiveSerialDescriptor("HttpStatus", PrimitiveKind.LONG)
+
+        override fun serialize(encoder: Encoder, value: HttpStatus) = encoder.encodeLong(value.value)
+
+        override fun deserialize(decoder: Decoder): HttpStatus {
+            val value = decoder.decodeLong()
+            return entries.firstOrNull { it.value == value }
+                ?: throw SerializationException("invalid HttpStatus value: $value")
+        }
+    }
+}
+
+@Serializable
+enum class Status(val value: String) {
+    @SerialName("pending")
+    PENDING("pending"),
+    @SerialName("in_progress")
+    IN_PROGRESS("in_progress"),
+    @SerialName("completed")
+    COMPLETED("completed"),
+    @SerialName("failed")
+    FAILED("failed"),
+}
+
+@Serializable
+data class Task(
+    val assignee: String?,
+    val httpStatus: HttpStatus? = null,
+    val id: String,
+    val status: Status = Status.PENDING,
+    val title: String,
+)
@@ -0,0 +1,28 @@
+package com.example.generated
+
+import kotlinx.serialization.Serializable
+
+@Serializable
+data class User(
+    val age: Long,
+    val email: String,
+    val rating: Double? = null,
+    val score: Double? = null,
+    val tags: List<String>? = null,
+    val username: String,
+) {
+    init {
+        require(age >= 0L) { "age must be >= 0" }
+        require(age <= 150L) { "age must be <= 150" }
+        rating?.let { require(it > 0.0) { "rating must be > 0" } }
+        rating?.let { require(it < 5.0) { "rating must be < 5" } }
+        score?.let { require(it >= 0.0) { "score must be >= 0" } }
+        score?.let { require(it <= 100.0) { "score must be <= 100" } }
+        score?.let { require((it / 0.5).let { q -> q == kotlin.math.floor(q) }) { "score must be a multiple of 0.5" } }
+        tags?.let { require(it.size >= 1) { "tags must have at least 1 items" } }
+        tags?.let { require(it.size <= 10) { "tags must have at most 10 items" } }
+        require(username.length >= 3) { "username must be at least 3 characters" }
+        require(username.length <= 20) { "username must be at most 20 characters" }
+        require(Regex("""^[a-z_][a-z0-9_]*${'$'}""").containsMatchIn(username)) { "username must match pattern ^[a-z_][a-z0-9_]*\$" }
+    }
+}
@@ -1,6 +1,7 @@
 package multi
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 )
 
@@ -24,6 +25,30 @@ var StatusValues = []Status{
 	StatusPending,
 }
 
+// IsValid reports whether the value is one of StatusValues
+func (e Status) IsValid() bool {
+	for _, v := range StatusValues {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (e Status) String() string {
+	return string(e)
+}
+
+// ParseStatus converts a string to a Status, returning an error when it
+// is not one of StatusValues.
+func ParseStatus(s string) (Status, error) {
+	e := Status(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid Status %q", s)
+	}
+	return e, nil
+}
+
 type Person struct {
 	Address *Address  `json:"address,omitempty"`
 	Age     *int      `json:"age,omitempty"`
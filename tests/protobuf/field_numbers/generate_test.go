@@ -0,0 +1,30 @@
+package field_numbers_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Southclaws/schemancer/schemancer"
+	"github.com/Southclaws/schemancer/schemancer/generators"
+	"github.com/Southclaws/schemancer/schemancer/generators/protobuf"
+	"github.com/Southclaws/schemancer/schemancer/loader"
+	"github.com/Southclaws/schemancer/tests/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldNumbers(t *testing.T) {
+	schema, err := loader.FromFile("schema.yaml")
+	require.NoError(t, err, "failed to load schema")
+
+	lock, err := os.ReadFile("existing.lock.json")
+	require.NoError(t, err, "failed to read existing lock file")
+
+	files, err := schemancer.Generate(schema, generators.GlobalOptions{
+		Language: generators.LanguageProtobuf,
+	}, protobuf.WithPackageName("example.v1"), protobuf.WithLock(lock))
+	require.NoError(t, err, "failed to generate")
+	require.Len(t, files, 2, "expected proto and lock files")
+
+	testutil.WriteAndCompareMultipleFiles(t, files, "output", "expected")
+}
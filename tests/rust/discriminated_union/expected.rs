@@ -0,0 +1,41 @@
+use serde::{Deserialize, Serialize};
+use std::collections::HashMap;
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct BaseEvent {
+    pub timestamp: chrono::DateTime<chrono::Utc>,
+    pub r#type: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+#[serde(tag = "type")]
+pub enum Event {
+    #[serde(rename = "created")]
+    CreatedEvent(CreatedEvent),
+    #[serde(rename = "updated")]
+    UpdatedEvent(UpdatedEvent),
+    #[serde(rename = "deleted")]
+    DeletedEvent(DeletedEvent),
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct CreatedEvent {
+    pub id: String,
+    pub name: String,
+    pub timestamp: chrono::DateTime<chrono::Utc>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct UpdatedEvent {
+    pub changes: HashMap<String, serde_json::Value>,
+    pub id: String,
+    pub timestamp: chrono::DateTime<chrono::Utc>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct DeletedEvent {
+    pub id: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub reason: Option<String>,
+    pub timestamp: chrono::DateTime<chrono::Utc>,
+}
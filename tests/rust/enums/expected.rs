@@ -0,0 +1,71 @@
+use serde::{Deserialize, Serialize};
+use std::collections::HashMap;
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum HttpMethod {
+    #[serde(rename = "GET")]
+    Get,
+    #[serde(rename = "POST")]
+    Post,
+    #[serde(rename = "DELETE")]
+    Delete,
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+#[serde(into = "i64", try_from = "i64")]
+#[repr(i64)]
+pub enum HttpStatus {
+    Value200 = 200,
+    Value201 = 201,
+    Value400 = 400,
+    Value404 = 404,
+    Value500 = 500,
+}
+
+impl From<HttpStatus> for i64 {
+    fn from(value: HttpStatus) -> Self {
+        value as i64
+    }
+}
+
+impl TryFrom<i64> for HttpStatus {
+    type Error = String;
+
+    fn try_from(value: i64) -> std::result::Result<Self, Self::Error> {
+        match value {
+            200 => Ok(Self::Value200),
+            201 => Ok(Self::Value201),
+            400 => Ok(Self::Value400),
+            404 => Ok(Self::Value404),
+            500 => Ok(Self::Value500),
+            _ => Err(format!("invalid HttpStatus value: {}", value)),
+        }
+    }
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Hash, Serialize, Deserialize)]
+pub enum Status {
+    #[serde(rename = "pending")]
+    Pending,
+    #[serde(rename = "in_progress")]
+    InProgress,
+    #[serde(rename = "completed")]
+    Completed,
+    #[serde(rename = "failed")]
+    Failed,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Task {
+    #[serde(rename = "dueAt", skip_serializing_if = "Option::is_none")]
+    pub due_at: Option<chrono::DateTime<chrono::Utc>>,
+    #[serde(rename = "httpStatus", skip_serializing_if = "Option::is_none")]
+    pub http_status: Option<HttpStatus>,
+    pub id: uuid::Uuid,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub labels: Option<HashMap<String, String>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub method: Option<HttpMethod>,
+    pub status: Status,
+    pub title: String,
+}
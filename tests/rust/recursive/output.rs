@@ -0,0 +1,53 @@
+use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct BinaryTree {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub left: Option<Box<BinaryTree>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub right: Option<Box<BinaryTree>>,
+    pub value: f64,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct GraphEdgesItem {
+    pub target: Graph,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub weight: Option<f64>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct Graph {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub edges: Option<Vec<GraphEdgesItem>>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub id: Option<String>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct LinkedListNode {
+    pub data: i64,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub next: Option<Box<LinkedListNode>>,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct MutualB {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub a: Option<Box<MutualA>>,
+    pub name: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct MutualA {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub b: Option<Box<MutualB>>,
+    pub name: String,
+}
+
+#[derive(Debug, Clone, PartialEq, Serialize, Deserialize)]
+pub struct TreeNode {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub children: Option<Vec<TreeNode>>,
+    pub value: String,
+}
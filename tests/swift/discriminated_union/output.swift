@@ -0,0 +1,174 @@
+import Foundation
+
+public struct BaseEvent: Codable, Equatable {
+    public var timestamp: Date
+    public var type: String
+
+    public init(
+        timestamp: Date,
+        type: String
+    ) {
+        self.timestamp = timestamp
+        self.type = type
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case timestamp
+        case type
+    }
+}
+
+public enum Event: Codable, Equatable {
+    case created(CreatedEvent)
+    case updated(UpdatedEvent)
+    case deleted(DeletedEvent)
+
+    private enum DiscriminatorCodingKeys: String, CodingKey {
+        case discriminator = "type"
+    }
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: DiscriminatorCodingKeys.self)
+        let discriminator = try container.decode(String.self, forKey: .discriminator)
+        switch discriminator {
+        case "created":
+            self = .created(try CreatedEvent(from: decoder))
+        case "updated":
+            self = .updated(try UpdatedEvent(from: decoder))
+        case "deleted":
+            self = .deleted(try DeletedEvent(from: decoder))
+        default:
+            throw DecodingError.dataCorruptedError(
+                forKey: .discriminator,
+                in: container,
+                debugDescription: "unknown Event type \"\(discriminator)\""
+            )
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.container(keyedBy: DiscriminatorCodingKeys.self)
+        switch self {
+        case .created(let value):
+            try container.encode("created", forKey: .discriminator)
+            try value.encode(to: encoder)
+        case .updated(let value):
+            try container.encode("updated", forKey: .discriminator)
+            try value.encode(to: encoder)
+        case .deleted(let value):
+            try container.encode("deleted", forKey: .discriminator)
+            try value.encode(to: encoder)
+        }
+    }
+}
+
+public struct CreatedEvent: Codable, Equatable {
+    public var id: String
+    public var name: String
+    public var timestamp: Date
+
+    public init(
+        id: String,
+        name: String,
+        timestamp: Date
+    ) {
+        self.id = id
+        self.name = name
+        self.timestamp = timestamp
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case id
+        case name
+        case timestamp
+    }
+}
+
+public struct UpdatedEvent: Codable, Equatable {
+    public var changes: [String: JSONValue]
+    public var id: String
+    public var timestamp: Date
+
+    public init(
+        changes: [String: JSONValue],
+        id: String,
+        timestamp: Date
+    ) {
+        self.changes = changes
+        self.id = id
+        self.timestamp = timestamp
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case changes
+        case id
+        case timestamp
+    }
+}
+
+public struct DeletedEvent: Codable, Equatable {
+    public var id: String
+    public var reason: String?
+    public var timestamp: Date
+
+    public init(
+        id: String,
+        reason: String? = nil,
+        timestamp: Date
+    ) {
+        self.id = id
+        self.reason = reason
+        self.timestamp = timestamp
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case id
+        case reason
+        case timestamp
+    }
+}
+
+/// JSONValue holds an arbitrary JSON value.
+public enum JSONValue: Codable, Equatable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let value = try? container.decode(Bool.self) {
+            self = .bool(value)
+        } else if let value = try? container.decode(Double.self) {
+            self = .number(value)
+        } else if let value = try? container.decode(String.self) {
+            self = .string(value)
+        } else if let value = try? container.decode([JSONValue].self) {
+            self = .array(value)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .null:
+            try container.encodeNil()
+        case .bool(let value):
+            try container.encode(value)
+        case .number(let value):
+            try container.encode(value)
+        case .string(let value):
+            try container.encode(value)
+        case .array(let value):
+            try container.encode(value)
+        case .object(let value):
+            try container.encode(value)
+        }
+    }
+}
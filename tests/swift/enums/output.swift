@@ -0,0 +1,55 @@
+import Foundation
+
+public enum HttpStatus: Int, Codable, CaseIterable {
+    case value200 = 200
+    case value201 = 201
+    case value400 = 400
+    case value404 = 404
+    case value500 = 500
+}
+
+public enum Status: String, Codable, CaseIterable {
+    case pending
+    case inProgress = "in_progress"
+    case completed
+    case failed
+}
+
+public struct Task: Codable, Equatable {
+    public var assignee: String?
+    public var httpStatus: HttpStatus?
+    public var id: String
+    public var status: Status
+    public var title: String
+
+    public init(
+        assignee: String?,
+        httpStatus: HttpStatus? = nil,
+        id: String,
+        status: Status = .pending,
+        title: String
+    ) {
+        self.assignee = assignee
+        self.httpStatus = httpStatus
+        self.id = id
+        self.status = status
+        self.title = title
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case assignee
+        case httpStatus
+        case id
+        case status
+        case title
+    }
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        assignee = try container.decodeIfPresent(String.self, forKey: .assignee)
+        httpStatus = try container.decodeIfPresent(HttpStatus.self, forKey: .httpStatus)
+        id = try container.decode(String.self, forKey: .id)
+        status = try container.decodeIfPresent(Status.self, forKey: .status) ?? .pending
+        title = try container.decode(String.self, forKey: .title)
+    }
+}
@@ -0,0 +1,152 @@
+import Foundation
+
+public struct BinaryTree: Codable, Equatable {
+    public var left: Indirect<BinaryTree>?
+    public var right: Indirect<BinaryTree>?
+    public var value: Double
+
+    public init(
+        left: Indirect<BinaryTree>? = nil,
+        right: Indirect<BinaryTree>? = nil,
+        value: Double
+    ) {
+        self.left = left
+        self.right = right
+        self.value = value
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case left
+        case right
+        case value
+    }
+}
+
+public struct GraphEdgesItem: Codable, Equatable {
+    public var target: Graph
+    public var weight: Double?
+
+    public init(
+        target: Graph,
+        weight: Double? = nil
+    ) {
+        self.target = target
+        self.weight = weight
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case target
+        case weight
+    }
+}
+
+public struct Graph: Codable, Equatable {
+    public var edges: [GraphEdgesItem]?
+    public var id: String?
+
+    public init(
+        edges: [GraphEdgesItem]? = nil,
+        id: String? = nil
+    ) {
+        self.edges = edges
+        self.id = id
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case edges
+        case id
+    }
+}
+
+public struct LinkedListNode: Codable, Equatable {
+    public var data: Int
+    public var next: Indirect<LinkedListNode>?
+
+    public init(
+        data: Int,
+        next: Indirect<LinkedListNode>? = nil
+    ) {
+        self.data = data
+        self.next = next
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case data
+        case next
+    }
+}
+
+public struct MutualB: Codable, Equatable {
+    public var a: Indirect<MutualA>?
+    public var name: String
+
+    public init(
+        a: Indirect<MutualA>? = nil,
+        name: String
+    ) {
+        self.a = a
+        self.name = name
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case a
+        case name
+    }
+}
+
+public struct MutualA: Codable, Equatable {
+    public var b: Indirect<MutualB>?
+    public var name: String
+
+    public init(
+        b: Indirect<MutualB>? = nil,
+        name: String
+    ) {
+        self.b = b
+        self.name = name
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case b
+        case name
+    }
+}
+
+public struct TreeNode: Codable, Equatable {
+    public var children: [TreeNode]?
+    public var value: String
+
+    public init(
+        children: [TreeNode]? = nil,
+        value: String
+    ) {
+        self.children = children
+        self.value = value
+    }
+
+    enum CodingKeys: String, CodingKey {
+        case children
+        case value
+    }
+}
+
+/// Indirect boxes a value so that recursive structs have a finite size.
+public final class Indirect<Value: Codable & Equatable>: Codable, Equatable {
+    public let value: Value
+
+    public init(_ value: Value) {
+        self.value = value
+    }
+
+    public init(from decoder: Decoder) throws {
+        value = try Value(from: decoder)
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        try value.encode(to: encoder)
+    }
+
+    public static func == (lhs: Indirect, rhs: Indirect) -> Bool {
+        lhs.value == rhs.value
+    }
+}
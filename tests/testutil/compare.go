@@ -32,10 +32,14 @@ func CompareGenerated(t *testing.T, generated, expected []byte) {
 	assert.Equal(t, expBody, genBody, "generated code does not match expected")
 }
 
+// stripPackageLine removes the package clause, which follows the build
+// constraint when there is one.
 func stripPackageLine(s string) string {
-	parts := strings.SplitN(s, "\n", 2)
-	if len(parts) > 1 {
-		return parts[1]
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "package ") {
+			return strings.Join(lines[:i], "") + strings.Join(lines[i+1:], "")
+		}
 	}
 	return s
 }